@@ -0,0 +1,31 @@
+package dgcache
+
+import (
+	"context"
+
+	"github.com/donnigundala/dg-core/contracts/cache"
+)
+
+// bypassKey is the context key marking a cache bypass.
+type bypassKey struct{}
+
+// WithBypass returns a context that makes Get and Remember skip the cache read
+// and treat it as a miss. Remember still runs its callback and stores the fresh
+// result, so a bypass doubles as a forced refresh.
+func WithBypass(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassKey{}, true)
+}
+
+// isBypassed reports whether ctx was created by WithBypass.
+func isBypassed(ctx context.Context) bool {
+	bypass, _ := ctx.Value(bypassKey{}).(bool)
+	return bypass
+}
+
+// getOrBypass reads key from store, or reports a miss if ctx is bypassed.
+func getOrBypass(ctx context.Context, store cache.Store, key string) (interface{}, error) {
+	if isBypassed(ctx) {
+		return nil, ErrKeyNotFound
+	}
+	return store.Get(ctx, key)
+}
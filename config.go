@@ -17,6 +17,51 @@ type Config struct {
 
 	// Stores contains the configuration for each cache store.
 	Stores map[string]StoreConfig `mapstructure:"stores"`
+
+	// MissReturnsError controls how the Manager reports a cache miss from Get.
+	// When true (default, also when unset), a miss returns ErrKeyNotFound.
+	// When false, a miss returns (nil, nil) like a map lookup.
+	MissReturnsError *bool `mapstructure:"miss_returns_error"`
+
+	// ErrorTTL is how long a failed Remember callback error is cached.
+	// While cached, Remember returns a *CachedError without invoking the callback.
+	// Errors are stored in the same store under the reserved "__dgcache:error:"
+	// namespace, and are dropped when the key is written or forgotten.
+	// 0 disables error caching (default).
+	ErrorTTL time.Duration `mapstructure:"error_ttl"`
+
+	// FallbackBackfillTTL is the TTL used by GetWithFallback when copying a value
+	// found in a later store into the earlier stores that missed.
+	// 0 disables backfilling (default).
+	FallbackBackfillTTL time.Duration `mapstructure:"fallback_backfill_ttl"`
+
+	// FallbackReadRepairRate is the fraction of GetWithFallback hits in an
+	// earlier store that are verified against the last store and repaired
+	// when they differ from it. Each verification costs a read of the last store.
+	// 0 disables read-repair (default); 1 verifies every hit.
+	FallbackReadRepairRate float64 `mapstructure:"fallback_read_repair_rate"`
+
+	// RecoverPanics makes Remember and RememberForever recover from a panicking
+	// callback and return a *PanicError instead of crashing the goroutine.
+	// Default: false
+	RecoverPanics bool `mapstructure:"recover_panics"`
+
+	// MaxConcurrentCallbacks caps how many Remember callbacks and refresh
+	// loaders run at once across all keys, so a burst of cold keys cannot
+	// overwhelm the backing datastore. Callbacks over the limit wait for a
+	// slot until their context is done.
+	// 0 means no limit (default).
+	MaxConcurrentCallbacks int `mapstructure:"max_concurrent_callbacks"`
+
+	// Metrics enables statistics collection on every store.
+	// Default: false
+	Metrics bool `mapstructure:"metrics"`
+
+	// FallbackToDefault makes Store return the default store, with a logged
+	// warning, for store names that are not configured instead of failing
+	// with ErrStoreNotFound.
+	// Default: false
+	FallbackToDefault bool `mapstructure:"fallback_to_default"`
 }
 
 // StoreConfig represents the configuration for a single cache store.
@@ -31,16 +76,51 @@ type StoreConfig struct {
 	// This overrides the global prefix for this store.
 	Prefix string `mapstructure:"prefix"`
 
+	// Middleware lists registered middleware names to wrap the driver with.
+	// The first entry is the outermost layer (see RegisterMiddleware).
+	Middleware []string `mapstructure:"middleware"`
+
+	// SampleRate is the fraction of operations, from 0.0 to 1.0, that sampling
+	// middleware such as "tracing" instruments. Store statistics stay exact.
+	// Default: nil (every operation)
+	SampleRate *float64 `mapstructure:"sample_rate"`
+
+	// Metrics enables statistics collection (hits, misses, sets, deletes) for this store.
+	// The memory driver's enable_metrics option is still honored.
+	Metrics bool `mapstructure:"metrics"`
+
 	// Options contains driver-specific configuration options.
 	Options map[string]interface{} `mapstructure:"options"`
 }
 
+// MetricsEnabled reports whether the store should collect statistics, either
+// through Metrics or the legacy enable_metrics option.
+func (c StoreConfig) MetricsEnabled() bool {
+	if c.Metrics {
+		return true
+	}
+	enabled, _ := c.Options["enable_metrics"].(bool)
+	return enabled
+}
+
+// LowercaseKeys reports whether the store lowercases keys before prefixing
+// them, set with the lowercase_keys option or its normalize_case alias.
+func (c StoreConfig) LowercaseKeys() bool {
+	if enabled, _ := c.Options["lowercase_keys"].(bool); enabled {
+		return true
+	}
+	enabled, _ := c.Options["normalize_case"].(bool)
+	return enabled
+}
+
 // Decode decodes the store options into the target struct.
+// Duration fields accept duration strings such as "30s".
 func (c StoreConfig) Decode(target interface{}) error {
 	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
-		Metadata: nil,
-		Result:   target,
-		TagName:  "mapstructure",
+		Metadata:   nil,
+		Result:     target,
+		TagName:    "mapstructure",
+		DecodeHook: mapstructure.StringToTimeDurationHookFunc(),
 	})
 	if err != nil {
 		return err
@@ -82,6 +162,59 @@ func (c Config) WithStore(name string, config StoreConfig) Config {
 	return c
 }
 
+// WithMissReturnsError sets whether a cache miss returns ErrKeyNotFound.
+func (c Config) WithMissReturnsError(enabled bool) Config {
+	c.MissReturnsError = &enabled
+	return c
+}
+
+// WithErrorTTL sets how long Remember caches callback errors.
+func (c Config) WithErrorTTL(ttl time.Duration) Config {
+	c.ErrorTTL = ttl
+	return c
+}
+
+// WithFallbackBackfillTTL sets the TTL used to backfill stores in GetWithFallback.
+func (c Config) WithFallbackBackfillTTL(ttl time.Duration) Config {
+	c.FallbackBackfillTTL = ttl
+	return c
+}
+
+// WithRecoverPanics sets whether Remember recovers from callback panics.
+func (c Config) WithRecoverPanics(enabled bool) Config {
+	c.RecoverPanics = enabled
+	return c
+}
+
+// WithMaxConcurrentCallbacks sets how many Remember callbacks may run at once.
+func (c Config) WithMaxConcurrentCallbacks(n int) Config {
+	c.MaxConcurrentCallbacks = n
+	return c
+}
+
+// WithMetrics sets whether every store collects statistics.
+func (c Config) WithMetrics(enabled bool) Config {
+	c.Metrics = enabled
+	return c
+}
+
+// WithFallbackReadRepairRate sets the fraction of GetWithFallback hits verified against the last store.
+func (c Config) WithFallbackReadRepairRate(rate float64) Config {
+	c.FallbackReadRepairRate = rate
+	return c
+}
+
+// WithFallbackToDefault sets whether unknown store names resolve to the default store.
+func (c Config) WithFallbackToDefault(enabled bool) Config {
+	c.FallbackToDefault = enabled
+	return c
+}
+
+// missReturnsError reports whether a miss should be surfaced as ErrKeyNotFound.
+func (c Config) missReturnsError() bool {
+	return c.MissReturnsError == nil || *c.MissReturnsError
+}
+
 // Validate validates the cache configuration.
 func (c Config) Validate() error {
 	if c.DefaultStore == "" {
@@ -96,10 +229,21 @@ func (c Config) Validate() error {
 		return ErrInvalidConfig("default store '%s' is not configured", c.DefaultStore)
 	}
 
+	if c.FallbackReadRepairRate < 0 || c.FallbackReadRepairRate > 1 {
+		return ErrInvalidConfig("fallback_read_repair_rate must be between 0 and 1")
+	}
+
+	if c.MaxConcurrentCallbacks < 0 {
+		return ErrInvalidConfig("max_concurrent_callbacks must not be negative")
+	}
+
 	for name, store := range c.Stores {
 		if store.Driver == "" {
 			return ErrInvalidConfig("driver is required for store '%s'", name)
 		}
+		if rate := store.SampleRate; rate != nil && (*rate < 0 || *rate > 1) {
+			return ErrInvalidConfig("sample_rate for store '%s' must be between 0 and 1", name)
+		}
 	}
 
 	return nil
@@ -117,8 +261,17 @@ type Item struct {
 	// Zero value means the item never expires.
 	ExpiresAt time.Time
 
-	// Tags are the tags associated with this item.
+	// Tags are the tags associated with this item. The memory driver keeps
+	// them in sync with tagging, untagging and tag flushes.
 	Tags []string
+
+	// CreatedAt is when the item was written.
+	// Zero value means unknown.
+	CreatedAt time.Time
+
+	// SlidingTTL is the TTL a read resets ExpiresAt to on stores with
+	// sliding_ttl enabled. Zero value means reads leave the expiry alone.
+	SlidingTTL time.Duration
 }
 
 // IsExpired checks if the item has expired.
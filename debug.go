@@ -0,0 +1,82 @@
+package dgcache
+
+import "github.com/donnigundala/dg-core/contracts/cache"
+
+// DebugInfo is a snapshot of the manager's stores for debugging endpoints.
+type DebugInfo struct {
+	// DefaultStore is the name of the default store.
+	DefaultStore string
+
+	// Stores holds an entry per configured store, keyed by store name.
+	Stores map[string]StoreDebugInfo
+}
+
+// StoreDebugInfo describes the configuration and runtime state of one store.
+type StoreDebugInfo struct {
+	// Driver is the driver name (e.g., "redis", "memory").
+	Driver string
+
+	// Prefix is the key prefix in use.
+	Prefix string
+
+	// Serializer is the serializer name, empty if values are stored as-is.
+	Serializer string
+
+	// Compression is the compression algorithm, empty if disabled.
+	Compression string
+
+	// Stats are the store's statistics; they are zero unless metrics are enabled.
+	Stats cache.Stats
+
+	// HitRate is the fraction of reads that were hits, 0 before any read.
+	HitRate float64
+
+	// Pool is the connection pool state, nil for stores without a pool.
+	Pool *PoolStats
+
+	// Error is set when the store could not be created; the other fields
+	// then come from its configuration only.
+	Error string
+}
+
+// Debug returns a snapshot of every configured store: its driver, prefix,
+// serializer, compression, statistics and, for pooled stores such as Redis,
+// connection pool state. Stores that have not been used yet are created.
+func (m *Manager) Debug() DebugInfo {
+	info := DebugInfo{
+		DefaultStore: m.defaultStore,
+		Stores:       make(map[string]StoreDebugInfo, len(m.config.Stores)),
+	}
+	for name, config := range m.config.Stores {
+		info.Stores[name] = m.debugStore(name, config)
+	}
+	return info
+}
+
+// debugStore describes the named store.
+func (m *Manager) debugStore(name string, config StoreConfig) StoreDebugInfo {
+	store, err := m.Store(name)
+	if err != nil {
+		return StoreDebugInfo{Driver: config.Driver, Prefix: config.Prefix, Error: err.Error()}
+	}
+
+	entry := StoreDebugInfo{Driver: config.Driver, Prefix: store.GetPrefix()}
+	if introspectable, ok := capability[Introspectable](store); ok {
+		driverInfo := introspectable.Info()
+		entry.Driver = driverInfo.Driver
+		entry.Serializer = driverInfo.Serializer
+		entry.Compression = driverInfo.Compression
+	}
+
+	entry.Stats = store.Stats()
+	entry.HitRate = entry.Stats.HitRate
+	if reads := entry.Stats.Hits + entry.Stats.Misses; entry.HitRate == 0 && reads > 0 {
+		entry.HitRate = float64(entry.Stats.Hits) / float64(reads)
+	}
+
+	if reporter, ok := capability[PoolReporter](store); ok {
+		pool := reporter.PoolUsage()
+		entry.Pool = &pool
+	}
+	return entry
+}
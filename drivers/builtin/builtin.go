@@ -0,0 +1,17 @@
+// Package builtin registers every driver that ships with dg-cache.
+//
+// Import it for its side effect instead of importing each driver package:
+//
+//	import _ "github.com/donnigundala/dg-cache/drivers/builtin"
+//
+//	manager, err := dgcache.NewManagerWithDrivers(config)
+package builtin
+
+import (
+	// Each driver registers itself with dgcache.RegisterDriver in init.
+	_ "github.com/donnigundala/dg-cache/drivers/memory"
+	_ "github.com/donnigundala/dg-cache/drivers/redis"
+)
+
+// Drivers lists the names of the drivers registered by this package.
+var Drivers = []string{"memory", "redis"}
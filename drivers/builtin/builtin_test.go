@@ -0,0 +1,47 @@
+package builtin_test
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	dgcache "github.com/donnigundala/dg-cache"
+	"github.com/donnigundala/dg-cache/drivers/builtin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewManagerWithDrivers(t *testing.T) {
+	s := miniredis.RunT(t)
+	parts := strings.Split(s.Addr(), ":")
+	port, _ := strconv.Atoi(parts[1])
+
+	cfg := dgcache.DefaultConfig().WithStore("redis", dgcache.StoreConfig{
+		Driver:  "redis",
+		Options: map[string]interface{}{"host": parts[0], "port": port},
+	})
+	manager, err := dgcache.NewManagerWithDrivers(cfg, builtin.Drivers...)
+	require.NoError(t, err)
+	defer manager.Close()
+
+	ctx := context.Background()
+	for _, name := range []string{"memory", "redis"} {
+		store, err := manager.Store(name)
+		require.NoError(t, err, name)
+		require.NoError(t, store.Put(ctx, "key", "value", time.Minute), name)
+
+		val, err := store.Get(ctx, "key")
+		require.NoError(t, err, name)
+		assert.Equal(t, "value", val, name)
+	}
+}
+
+func TestNewManagerWithDrivers_Unregistered(t *testing.T) {
+	cfg := dgcache.DefaultConfig().WithStore("disk", dgcache.StoreConfig{Driver: "disk"})
+
+	_, err := dgcache.NewManagerWithDrivers(cfg)
+	assert.ErrorIs(t, err, dgcache.ErrDriverNotFound)
+}
@@ -0,0 +1,113 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	dgcache "github.com/donnigundala/dg-cache"
+)
+
+// benchmarkPutGet benchmarks a Put followed by a Get with the given options
+func benchmarkPutGet(b *testing.B, options map[string]interface{}) {
+	d, err := NewDriver(dgcache.StoreConfig{Driver: "memory", Options: options})
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer d.Close()
+
+	ctx := context.Background()
+	keys := make([]string, 1000)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("key:%d", i)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		key := keys[i%len(keys)]
+		_ = d.Put(ctx, key, "value", time.Minute)
+		_, _ = d.Get(ctx, key)
+	}
+}
+
+// BenchmarkPutGet_Unlimited benchmarks without size limits, where LRU tracking is skipped
+func BenchmarkPutGet_Unlimited(b *testing.B) {
+	benchmarkPutGet(b, nil)
+}
+
+// BenchmarkPutGet_MaxItems benchmarks with an item limit, where LRU tracking is required
+func BenchmarkPutGet_MaxItems(b *testing.B) {
+	benchmarkPutGet(b, map[string]interface{}{"max_items": 100000})
+}
+
+// benchmarkParallelGet benchmarks concurrent reads of a warm cache with the given options
+func benchmarkParallelGet(b *testing.B, options map[string]interface{}) {
+	d, err := NewDriver(dgcache.StoreConfig{Driver: "memory", Options: options})
+	if err != nil {
+		b.Fatal(err)
+	}
+	defer d.Close()
+
+	ctx := context.Background()
+	keys := make([]string, 1000)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("key:%d", i)
+		_ = d.Put(ctx, keys[i], "value", time.Minute)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		i := 0
+		for pb.Next() {
+			_, _ = d.Get(ctx, keys[i%len(keys)])
+			i++
+		}
+	})
+}
+
+// BenchmarkParallelGet_Unlimited benchmarks concurrent reads, which share the read lock
+func BenchmarkParallelGet_Unlimited(b *testing.B) {
+	benchmarkParallelGet(b, nil)
+}
+
+// BenchmarkParallelGet_MaxItems benchmarks concurrent reads that also update LRU order
+func BenchmarkParallelGet_MaxItems(b *testing.B) {
+	benchmarkParallelGet(b, map[string]interface{}{"max_items": 100000})
+}
+
+// benchmarkWarmup benchmarks filling an empty driver with a fixed number of entries
+func benchmarkWarmup(b *testing.B, options map[string]interface{}) {
+	const entries = 100000
+
+	ctx := context.Background()
+	keys := make([]string, entries)
+	for i := range keys {
+		keys[i] = fmt.Sprintf("key:%d", i)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		d, err := NewDriver(dgcache.StoreConfig{Driver: "memory", Options: options})
+		if err != nil {
+			b.Fatal(err)
+		}
+		for _, key := range keys {
+			_ = d.Put(ctx, key, "value", time.Minute)
+		}
+		d.Close()
+	}
+}
+
+// BenchmarkWarmup_Default benchmarks warm-up with a map that grows on demand
+func BenchmarkWarmup_Default(b *testing.B) {
+	benchmarkWarmup(b, nil)
+}
+
+// BenchmarkWarmup_InitialCapacity benchmarks warm-up with a presized map
+func BenchmarkWarmup_InitialCapacity(b *testing.B) {
+	benchmarkWarmup(b, map[string]interface{}{"initial_capacity": 100000})
+}
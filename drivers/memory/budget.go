@@ -0,0 +1,125 @@
+package memory
+
+import (
+	"sync"
+)
+
+// Budget is a size limit shared by several memory stores in a process.
+// When the stores together hold more than the item or byte limit, least
+// recently used items are evicted from the largest store until the total
+// fits again, so one busy store cannot starve the others.
+//
+// Stores join a budget through the "budget" option, given either the
+// *Budget itself or the name it was registered under with RegisterBudget.
+// Per-store max_items and max_bytes still apply on top of the budget.
+type Budget struct {
+	maxItems int
+	maxBytes int64
+
+	mu      sync.Mutex
+	drivers map[*Driver]struct{}
+}
+
+// NewBudget creates a budget of maxItems items and maxBytes bytes across all
+// stores that share it. 0 means no limit for that dimension.
+func NewBudget(maxItems int, maxBytes int64) *Budget {
+	return &Budget{
+		maxItems: maxItems,
+		maxBytes: maxBytes,
+		drivers:  make(map[*Driver]struct{}),
+	}
+}
+
+var (
+	budgetsMu sync.RWMutex
+	budgets   = make(map[string]*Budget)
+)
+
+// RegisterBudget makes budget available to stores configured with
+// budget: name, for configurations that cannot hold a *Budget.
+func RegisterBudget(name string, budget *Budget) {
+	budgetsMu.Lock()
+	defer budgetsMu.Unlock()
+	budgets[name] = budget
+}
+
+// lookupBudget returns the budget registered under name.
+func lookupBudget(name string) (*Budget, bool) {
+	budgetsMu.RLock()
+	defer budgetsMu.RUnlock()
+	budget, ok := budgets[name]
+	return budget, ok
+}
+
+// Usage returns the items and bytes held by all stores sharing the budget.
+func (b *Budget) Usage() (items int, bytes int64) {
+	for _, d := range b.members() {
+		n, size := d.usage()
+		items += n
+		bytes += size
+	}
+	return items, bytes
+}
+
+func (b *Budget) join(d *Driver) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.drivers[d] = struct{}{}
+}
+
+func (b *Budget) leave(d *Driver) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.drivers, d)
+}
+
+// members returns the stores sharing the budget.
+func (b *Budget) members() []*Driver {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	drivers := make([]*Driver, 0, len(b.drivers))
+	for d := range b.drivers {
+		drivers = append(drivers, d)
+	}
+	return drivers
+}
+
+// enforce evicts from the largest store until the total fits the budget.
+// The largest store is the one holding the most bytes when the byte limit is
+// exceeded, and the most items otherwise. No store's lock is held while
+// another's is taken, so stores sharing a budget never deadlock.
+func (b *Budget) enforce() {
+	for {
+		members := b.members()
+		items := make([]int, len(members))
+		bytes := make([]int64, len(members))
+		var totalItems int
+		var totalBytes int64
+		for i, d := range members {
+			items[i], bytes[i] = d.usage()
+			totalItems += items[i]
+			totalBytes += bytes[i]
+		}
+
+		overBytes := b.maxBytes > 0 && totalBytes > b.maxBytes
+		overItems := b.maxItems > 0 && totalItems > b.maxItems
+		if !overBytes && !overItems {
+			return
+		}
+
+		victim := -1
+		for i := range members {
+			switch {
+			case victim == -1:
+				victim = i
+			case overBytes && bytes[i] > bytes[victim]:
+				victim = i
+			case !overBytes && items[i] > items[victim]:
+				victim = i
+			}
+		}
+		if victim == -1 || !members[victim].evictForBudget() {
+			return // Nothing left that can be evicted
+		}
+	}
+}
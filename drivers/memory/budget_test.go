@@ -0,0 +1,123 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	dgcache "github.com/donnigundala/dg-cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBudget_SharedItems(t *testing.T) {
+	ctx := context.Background()
+	budget := NewBudget(10, 0)
+	driver, err := NewDriver(dgcache.StoreConfig{Driver: "memory", Options: map[string]interface{}{"budget": budget}})
+	require.NoError(t, err)
+	a := driver.(*Driver)
+	b := newTestDriver(t, map[string]interface{}{"budget": budget})
+
+	for i := 0; i < 8; i++ {
+		require.NoError(t, a.Put(ctx, fmt.Sprintf("a%d", i), i, 0))
+	}
+	items, _ := budget.Usage()
+	assert.Equal(t, 8, items)
+
+	// Going over the shared cap evicts from the largest store, oldest first
+	for i := 0; i < 4; i++ {
+		require.NoError(t, b.Put(ctx, fmt.Sprintf("b%d", i), i, 0))
+	}
+	items, _ = budget.Usage()
+	assert.Equal(t, 10, items)
+	assert.Len(t, a.items, 6)
+	assert.Len(t, b.items, 4)
+	for _, key := range []string{"a0", "a1"} {
+		has, err := a.Has(ctx, key)
+		require.NoError(t, err)
+		assert.False(t, has, key)
+	}
+
+	// A closed store no longer counts against the budget
+	require.NoError(t, a.Close())
+	items, _ = budget.Usage()
+	assert.Equal(t, 4, items)
+}
+
+func TestBudget_SharedBytes(t *testing.T) {
+	ctx := context.Background()
+	budget := NewBudget(0, 100)
+	a := newTestDriver(t, map[string]interface{}{"budget": budget})
+	b := newTestDriver(t, map[string]interface{}{"budget": budget})
+
+	require.NoError(t, a.Put(ctx, "big1", string(make([]byte, 40)), 0))
+	require.NoError(t, a.Put(ctx, "big2", string(make([]byte, 40)), 0))
+	require.NoError(t, b.Put(ctx, "small", string(make([]byte, 30)), 0))
+
+	_, bytes := budget.Usage()
+	assert.LessOrEqual(t, bytes, int64(100))
+	has, _ := a.Has(ctx, "big1")
+	assert.False(t, has)
+	has, _ = b.Has(ctx, "small")
+	assert.True(t, has)
+}
+
+func TestBudget_Registered(t *testing.T) {
+	budget := NewBudget(1, 0)
+	RegisterBudget("test-budget", budget)
+
+	d := newTestDriver(t, map[string]interface{}{"budget": "test-budget"})
+	assert.Same(t, budget, d.config.Budget)
+
+	_, err := NewDriver(dgcache.StoreConfig{
+		Driver:  "memory",
+		Options: map[string]interface{}{"budget": "missing"},
+	})
+	assert.Error(t, err)
+}
+
+func TestBudget_UsageTracksRemovals(t *testing.T) {
+	ctx := context.Background()
+	budget := NewBudget(0, 1000)
+	d := newTestDriver(t, map[string]interface{}{"budget": budget})
+
+	require.NoError(t, d.Put(ctx, "a", "hello", 0))
+	require.NoError(t, d.Put(ctx, "b", "0123456789", 0))
+	require.NoError(t, d.Put(ctx, "b", "01234", 0))
+	items, bytes := budget.Usage()
+	assert.Equal(t, 2, items)
+	assert.Equal(t, int64(10), bytes)
+
+	require.NoError(t, d.Forget(ctx, "a"))
+	require.NoError(t, d.Rename(ctx, "b", "c"))
+	items, bytes = budget.Usage()
+	assert.Equal(t, 1, items)
+	assert.Equal(t, int64(5), bytes)
+
+	require.NoError(t, d.Flush(ctx))
+	items, bytes = budget.Usage()
+	assert.Equal(t, 0, items)
+	assert.Equal(t, int64(0), bytes)
+}
+
+func TestBudget_PutMultiple(t *testing.T) {
+	ctx := context.Background()
+	budget := NewBudget(10, 0)
+	a := newTestDriver(t, map[string]interface{}{"budget": budget})
+	b := newTestDriver(t, map[string]interface{}{"budget": budget})
+
+	bulk := make(map[string]interface{}, 12)
+	for i := 0; i < 12; i++ {
+		bulk[fmt.Sprintf("a%d", i)] = i
+	}
+	require.NoError(t, a.PutMultiple(ctx, bulk, 0))
+	items, _ := budget.Usage()
+	assert.Equal(t, 10, items)
+
+	// Bulk-loaded items are evictable, so the budget keeps applying
+	require.NoError(t, b.Put(ctx, "b0", 0, 0))
+	items, _ = budget.Usage()
+	assert.Equal(t, 10, items)
+	assert.Len(t, a.items, 9)
+	assert.Len(t, a.nodes, 9)
+}
@@ -1,6 +1,11 @@
 package memory
 
-import "time"
+import (
+	"time"
+
+	dgcache "github.com/donnigundala/dg-cache"
+	"github.com/donnigundala/dg-cache/serializer"
+)
 
 // Config represents the configuration for the memory cache driver.
 type Config struct {
@@ -23,6 +28,79 @@ type Config struct {
 	// EnableMetrics enables collection of cache statistics.
 	// Default: false
 	EnableMetrics bool
+
+	// PrefixSeparator joins the prefix with keys.
+	// Default: ":"
+	PrefixSeparator string
+
+	// LowercaseKeys lowercases every key before it is prefixed, so "User:1"
+	// and "user:1" name the same entry.
+	// Default: false
+	LowercaseKeys bool
+
+	// TTLLimits bounds the TTL of written values.
+	// Default: no limits
+	TTLLimits dgcache.TTLLimits
+
+	// TrackHotKeys enables per-key read counting for HotKeys.
+	// Default: false
+	TrackHotKeys bool
+
+	// HotKeysCapacity is the maximum number of keys counted by HotKeys.
+	// Default: 1000
+	HotKeysCapacity int
+
+	// InitialCapacity presizes the item index for the expected number of
+	// entries, avoiding rehashing while a large cache warms up.
+	// 0 means no preallocation (default).
+	InitialCapacity int
+
+	// MaxKeysPerTag caps how many keys a single tag may hold, to surface tags
+	// that grow without bound. 0 means unlimited (default).
+	MaxKeysPerTag int
+
+	// TagLimitPolicy is what happens when a write would exceed MaxKeysPerTag.
+	// Options: "warn" (default) logs a warning once per tag and tags the key
+	// anyway, "reject" fails the write with ErrTagLimitExceeded.
+	TagLimitPolicy string
+
+	// Budget is a size limit shared with other memory stores. When the
+	// stores together exceed it, items are evicted from the largest one.
+	// Default: nil (no shared limit)
+	Budget *Budget
+
+	// ReturnCopies makes Get and GetMultiple return a deep copy of slices,
+	// maps, pointers, and structs, so callers can't mutate the cached value.
+	// Default: false
+	ReturnCopies bool
+
+	// ValidateSerializable makes writes serialize every value with
+	// ValidationSerializer and reject those that fail, so values such as
+	// channels and funcs are caught in development instead of once the store
+	// is switched to Redis. Values are still stored unserialized.
+	// Default: false
+	ValidateSerializable bool
+
+	// ValidationSerializer is the serializer ValidateSerializable checks
+	// against, typically the one the production store uses.
+	// Default: JSON
+	ValidationSerializer serializer.Serializer
+
+	// Serializer, when set, makes the driver store every value encoded with
+	// it and decode it on each read, as the Redis driver does, so a struct
+	// written with JSON reads back as a map. It makes the memory driver a
+	// faithful stand-in for Redis in tests.
+	// Default: nil (values are stored as they are)
+	Serializer serializer.Serializer
+
+	// SlidingTTL makes Get reset the expiry of a key written with a TTL to
+	// that TTL, so keys that keep being read stay cached.
+	// Default: false
+	SlidingTTL bool
+
+	// SlidingTTLMaxLifetime caps how long after its write a key can be kept
+	// alive by reads. 0 means no cap (default).
+	SlidingTTLMaxLifetime time.Duration
 }
 
 // DefaultConfig returns a default memory cache configuration.
@@ -33,6 +111,9 @@ func DefaultConfig() Config {
 		EvictionPolicy:  "lru",
 		CleanupInterval: 1 * time.Minute,
 		EnableMetrics:   false,
+		PrefixSeparator: ":",
+		HotKeysCapacity: 1000,
+		TagLimitPolicy:  "warn",
 	}
 }
 
@@ -65,3 +146,69 @@ func (c Config) WithMetrics(enabled bool) Config {
 	c.EnableMetrics = enabled
 	return c
 }
+
+// WithLowercaseKeys makes keys case-insensitive by lowercasing them.
+func (c Config) WithLowercaseKeys() Config {
+	c.LowercaseKeys = true
+	return c
+}
+
+// WithPrefixSeparator sets the separator between prefix and key.
+func (c Config) WithPrefixSeparator(separator string) Config {
+	c.PrefixSeparator = separator
+	return c
+}
+
+// WithTTLLimits sets the TTL limits applied to writes.
+func (c Config) WithTTLLimits(limits dgcache.TTLLimits) Config {
+	c.TTLLimits = limits
+	return c
+}
+
+// WithInitialCapacity sets the number of entries preallocated in the item index.
+func (c Config) WithInitialCapacity(capacity int) Config {
+	c.InitialCapacity = capacity
+	return c
+}
+
+// WithValidateSerializable enables rejecting writes of values that ser cannot
+// serialize (nil = JSON).
+func (c Config) WithValidateSerializable(ser serializer.Serializer) Config {
+	c.ValidateSerializable = true
+	c.ValidationSerializer = ser
+	return c
+}
+
+// WithSerializer stores values encoded with ser, as the Redis driver does.
+func (c Config) WithSerializer(ser serializer.Serializer) Config {
+	c.Serializer = ser
+	return c
+}
+
+// WithSlidingTTL enables sliding expiration, capped at maxLifetime after the
+// write (0 = no cap).
+func (c Config) WithSlidingTTL(maxLifetime time.Duration) Config {
+	c.SlidingTTL = true
+	c.SlidingTTLMaxLifetime = maxLifetime
+	return c
+}
+
+// WithMaxKeysPerTag sets the per-tag key limit and what happens when it is exceeded.
+func (c Config) WithMaxKeysPerTag(max int, policy string) Config {
+	c.MaxKeysPerTag = max
+	c.TagLimitPolicy = policy
+	return c
+}
+
+// WithReturnCopies sets whether reads return deep copies of cached values.
+func (c Config) WithReturnCopies(enabled bool) Config {
+	c.ReturnCopies = enabled
+	return c
+}
+
+// WithHotKeys enables hot key tracking with the given capacity.
+func (c Config) WithHotKeys(capacity int) Config {
+	c.TrackHotKeys = true
+	c.HotKeysCapacity = capacity
+	return c
+}
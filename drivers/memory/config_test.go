@@ -27,6 +27,10 @@ func TestDefaultConfig(t *testing.T) {
 	if cfg.EnableMetrics {
 		t.Error("Expected EnableMetrics to be false")
 	}
+
+	if cfg.PrefixSeparator != ":" {
+		t.Errorf("Expected PrefixSeparator to be ':', got %s", cfg.PrefixSeparator)
+	}
 }
 
 func TestConfigBuilders(t *testing.T) {
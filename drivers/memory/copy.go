@@ -0,0 +1,80 @@
+package memory
+
+import "reflect"
+
+// copyValue returns a deep copy of value, so callers can't mutate the cached
+// value through shared slices, maps, or pointers. Unexported struct fields are
+// copied shallowly, and channels and functions are shared.
+func copyValue(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
+	return deepCopy(reflect.ValueOf(value), make(map[uintptr]reflect.Value)).Interface()
+}
+
+// deepCopy copies v recursively. seen maps already copied pointers to their
+// copies so that shared and cyclic references are preserved.
+func deepCopy(v reflect.Value, seen map[uintptr]reflect.Value) reflect.Value {
+	switch v.Kind() {
+	case reflect.Slice:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeSlice(v.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopy(v.Index(i), seen))
+		}
+		return c
+
+	case reflect.Array:
+		c := reflect.New(v.Type()).Elem()
+		for i := 0; i < v.Len(); i++ {
+			c.Index(i).Set(deepCopy(v.Index(i), seen))
+		}
+		return c
+
+	case reflect.Map:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.MakeMapWithSize(v.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			c.SetMapIndex(iter.Key(), deepCopy(iter.Value(), seen))
+		}
+		return c
+
+	case reflect.Ptr:
+		if v.IsNil() {
+			return v
+		}
+		if c, ok := seen[v.Pointer()]; ok {
+			return c
+		}
+		c := reflect.New(v.Type().Elem())
+		seen[v.Pointer()] = c
+		c.Elem().Set(deepCopy(v.Elem(), seen))
+		return c
+
+	case reflect.Interface:
+		if v.IsNil() {
+			return v
+		}
+		c := reflect.New(v.Type()).Elem()
+		c.Set(deepCopy(v.Elem(), seen))
+		return c
+
+	case reflect.Struct:
+		c := reflect.New(v.Type()).Elem()
+		c.Set(v)
+		for i := 0; i < v.NumField(); i++ {
+			if field := c.Field(i); field.CanSet() {
+				field.Set(deepCopy(v.Field(i), seen))
+			}
+		}
+		return c
+
+	default:
+		return v
+	}
+}
@@ -0,0 +1,36 @@
+package memory
+
+import (
+	"context"
+
+	dgcache "github.com/donnigundala/dg-cache"
+)
+
+// Export calls fn for every unexpired item with its tags. Items are collected
+// under the read lock first, so fn may safely call back into the driver.
+func (d *Driver) Export(ctx context.Context, fn func(item dgcache.Item) error) error {
+	d.mu.RLock()
+	items := make([]dgcache.Item, 0, len(d.items))
+	for prefixedKey, item := range d.items {
+		if item.IsExpired() {
+			continue
+		}
+		exported := *item
+		exported.Value = d.readValue(item.Value)
+		if tags := d.keyTags[prefixedKey]; len(tags) > 0 {
+			exported.Tags = append([]string(nil), tags...)
+		}
+		items = append(items, exported)
+	}
+	d.mu.RUnlock()
+
+	for _, item := range items {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	dgcache "github.com/donnigundala/dg-cache"
+)
+
+// hashValue is the value stored for a key written with HSet.
+type hashValue map[string]interface{}
+
+// lookupHash returns the live hash at prefixedKey, or nil if there is none.
+// Caller must hold the lock.
+func (d *Driver) lookupHash(prefixedKey string) (hashValue, error) {
+	item, ok := d.items[prefixedKey]
+	if !ok || item.IsExpired() {
+		return nil, nil
+	}
+	hash, ok := item.Value.(hashValue)
+	if !ok {
+		return nil, dgcache.ErrWrongType
+	}
+	return hash, nil
+}
+
+// HGet returns the value of field in the hash at key.
+func (d *Driver) HGet(ctx context.Context, key, field string) (interface{}, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	hash, err := d.lookupHash(d.prefixKey(key))
+	if err != nil {
+		return nil, err
+	}
+	value, ok := hash[field]
+	if !ok {
+		if d.metrics != nil {
+			d.metrics.RecordMiss()
+		}
+		return nil, dgcache.ErrKeyNotFound
+	}
+
+	if d.metrics != nil {
+		d.metrics.RecordHit()
+	}
+	return d.readValue(value), nil
+}
+
+// HSet sets field in the hash at key. A new hash is written like a Put without
+// expiry, so the TTL limits, eviction and metrics apply; an existing one keeps
+// its expiry. Field values are encoded with Config.Serializer if one is set.
+func (d *Driver) HSet(ctx context.Context, key, field string, value interface{}) error {
+	if err := d.checkSerializable(key, value); err != nil {
+		return err
+	}
+	encoded, err := d.encode(key, value)
+	if err != nil {
+		return err
+	}
+
+	defer d.enforceBudget()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	prefixedKey := d.prefixKey(key)
+	hash, err := d.lookupHash(prefixedKey)
+	if err != nil {
+		return err
+	}
+
+	var item dgcache.Item
+	if hash != nil {
+		item = *d.items[prefixedKey]
+	} else {
+		ttl, err := d.config.TTLLimits.Apply(0)
+		if err != nil {
+			return err
+		}
+		item = dgcache.Item{Key: key, CreatedAt: time.Now()}
+		if ttl > 0 {
+			item.ExpiresAt = item.CreatedAt.Add(ttl)
+		}
+	}
+
+	// Replace the hash instead of changing it, so the size change is accounted
+	updated := make(hashValue, len(hash)+1)
+	for f, v := range hash {
+		updated[f] = v
+	}
+	updated[field] = encoded
+	item.Value = updated
+	d.storeItem(prefixedKey, &item)
+	return nil
+}
+
+// HGetAll returns a copy of every field in the hash at key.
+func (d *Driver) HGetAll(ctx context.Context, key string) (map[string]interface{}, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	hash, err := d.lookupHash(d.prefixKey(key))
+	if err != nil {
+		return nil, err
+	}
+	result := make(map[string]interface{}, len(hash))
+	for field, value := range hash {
+		result[field] = d.readValue(value)
+	}
+	return result, nil
+}
+
+// HDel removes fields from the hash at key, deleting the hash once it is empty.
+func (d *Driver) HDel(ctx context.Context, key string, fields ...string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	prefixedKey := d.prefixKey(key)
+	hash, err := d.lookupHash(prefixedKey)
+	if err != nil || hash == nil {
+		return err
+	}
+	for _, field := range fields {
+		delete(hash, field)
+	}
+	if len(hash) == 0 {
+		d.removeItem(prefixedKey)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package memory
+
+import (
+	"container/heap"
+	"sort"
+	"sync"
+)
+
+// KeyCount is a key together with its observed access count.
+type KeyCount struct {
+	Key   string
+	Count int64
+}
+
+// hotKeys counts key accesses in a bounded map.
+//
+// When the map is full, a new key replaces the least counted one and inherits
+// its count (the space-saving algorithm), so heavily read keys are kept while
+// memory stays capped. Counts of recently replaced keys may be overestimated.
+// The counters form a min-heap, so finding the least counted key is O(1) and
+// every access costs O(log capacity).
+type hotKeys struct {
+	mu       sync.Mutex
+	counters map[string]*keyCounter
+	heap     counterHeap
+	capacity int
+}
+
+// keyCounter is the count of one key and its position in the heap.
+type keyCounter struct {
+	key   string
+	count int64
+	index int
+}
+
+// counterHeap orders counters by count, least counted first.
+type counterHeap []*keyCounter
+
+func (h counterHeap) Len() int           { return len(h) }
+func (h counterHeap) Less(i, j int) bool { return h[i].count < h[j].count }
+
+func (h counterHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *counterHeap) Push(x interface{}) {
+	c := x.(*keyCounter)
+	c.index = len(*h)
+	*h = append(*h, c)
+}
+
+func (h *counterHeap) Pop() interface{} {
+	old := *h
+	c := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	return c
+}
+
+// newHotKeys creates a tracker holding at most capacity keys.
+func newHotKeys(capacity int) *hotKeys {
+	return &hotKeys{
+		counters: make(map[string]*keyCounter, capacity),
+		heap:     make(counterHeap, 0, capacity),
+		capacity: capacity,
+	}
+}
+
+// record counts one access to key.
+func (h *hotKeys) record(key string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if c, ok := h.counters[key]; ok {
+		c.count++
+		heap.Fix(&h.heap, c.index)
+		return
+	}
+	if len(h.heap) < h.capacity {
+		c := &keyCounter{key: key, count: 1}
+		h.counters[key] = c
+		heap.Push(&h.heap, c)
+		return
+	}
+
+	// Replace the least counted key
+	c := h.heap[0]
+	delete(h.counters, c.key)
+	c.key = key
+	c.count++
+	h.counters[key] = c
+	heap.Fix(&h.heap, 0)
+}
+
+// top returns the n most counted keys, highest first.
+func (h *hotKeys) top(n int) []KeyCount {
+	h.mu.Lock()
+	result := make([]KeyCount, 0, len(h.heap))
+	for _, c := range h.heap {
+		result = append(result, KeyCount{Key: c.key, Count: c.count})
+	}
+	h.mu.Unlock()
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Count != result[j].Count {
+			return result[i].Count > result[j].Count
+		}
+		return result[i].Key < result[j].Key
+	})
+
+	if n >= 0 && n < len(result) {
+		result = result[:n]
+	}
+	return result
+}
+
+// reset clears all counts.
+func (h *hotKeys) reset() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.counters = make(map[string]*keyCounter, h.capacity)
+	h.heap = make(counterHeap, 0, h.capacity)
+}
+
+// HotKeys returns the n most frequently read keys since the last reset.
+// Only hits are counted, and keys are reported as the store identifies them,
+// lowercased with LowercaseKeys. It returns nil unless the track_hot_keys
+// option is enabled.
+func (d *Driver) HotKeys(n int) []KeyCount {
+	if d.hotKeys == nil {
+		return nil
+	}
+	return d.hotKeys.top(n)
+}
+
+// ResetHotKeys clears the access counts used by HotKeys.
+func (d *Driver) ResetHotKeys() {
+	if d.hotKeys != nil {
+		d.hotKeys.reset()
+	}
+}
@@ -205,6 +205,23 @@ func TestDriver_NoMetrics(t *testing.T) {
 	}
 }
 
+func TestDriver_MetricsFlag(t *testing.T) {
+	driver, err := NewDriver(dgcache.StoreConfig{Driver: "memory", Metrics: true})
+	if err != nil {
+		t.Fatalf("Failed to create driver: %v", err)
+	}
+	defer driver.Close()
+
+	ctx := context.Background()
+	driver.Put(ctx, "key", "value", 0)
+	driver.Get(ctx, "key")
+
+	stats := driver.Stats()
+	if stats.Sets != 1 || stats.Hits != 1 {
+		t.Errorf("Expected 1 set and 1 hit, got %d sets and %d hits", stats.Sets, stats.Hits)
+	}
+}
+
 func TestDriver_ConfigurableCleanup(t *testing.T) {
 	config := dgcache.StoreConfig{
 		Driver: "memory",
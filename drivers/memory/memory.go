@@ -2,10 +2,16 @@ package memory
 
 import (
 	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	dgcache "github.com/donnigundala/dg-cache"
+	"github.com/donnigundala/dg-cache/serializer"
 	"github.com/donnigundala/dg-core/contracts/cache"
 )
 
@@ -23,12 +29,29 @@ type Driver struct {
 	mu      sync.RWMutex
 	prefix  string
 	ticker  *time.Ticker
-	done    chan bool
+	done    chan struct{}
+
+	// background tracks the cleanup goroutine so Close can wait for it
+	background sync.WaitGroup
 
 	config  Config
 	metrics *Metrics
+	hotKeys *hotKeys
+
+	// Estimated size of all items, kept by setItem and deleteItem
+	size int64
+
+	// Tags already warned about by MaxKeysPerTag
+	tagLimitWarned map[string]struct{}
+
+	// Reads record LRU promotions here instead of taking the write lock
+	accessMu sync.Mutex
+	accesses []*lruNode
 }
 
+// accessBufferSize is the number of buffered LRU promotions that forces a flush.
+const accessBufferSize = 64
+
 // NewDriver creates a new in-memory cache driver.
 func NewDriver(storeConfig dgcache.StoreConfig) (cache.Driver, error) {
 	config := DefaultConfig()
@@ -51,27 +74,97 @@ func NewDriver(storeConfig dgcache.StoreConfig) (cache.Driver, error) {
 			config.CleanupInterval = duration
 		}
 	}
-	if val, ok := storeConfig.Options["enable_metrics"].(bool); ok {
-		config.EnableMetrics = val
+	config.EnableMetrics = storeConfig.MetricsEnabled()
+	if val, ok := storeConfig.Options["prefix_separator"].(string); ok {
+		config.PrefixSeparator = val
+	}
+	limits, err := storeConfig.TTLLimits()
+	if err != nil {
+		return nil, err
+	}
+	config.TTLLimits = limits
+	config.LowercaseKeys = storeConfig.LowercaseKeys()
+	if val, ok := storeConfig.Options["track_hot_keys"].(bool); ok {
+		config.TrackHotKeys = val
+	}
+	if val, ok := storeConfig.Options["hot_keys_capacity"].(int); ok {
+		config.HotKeysCapacity = val
+	}
+	if val, ok := storeConfig.Options["initial_capacity"].(int); ok {
+		config.InitialCapacity = val
+	}
+	if val, ok := storeConfig.Options["return_copies"].(bool); ok {
+		config.ReturnCopies = val
+	}
+	if val, ok := storeConfig.Options["sliding_ttl"].(bool); ok {
+		config.SlidingTTL = val
+	}
+	if val, ok := storeConfig.Options["sliding_ttl_max_lifetime"].(time.Duration); ok {
+		config.SlidingTTLMaxLifetime = val
+	}
+	if val, ok := storeConfig.Options["max_keys_per_tag"].(int); ok {
+		config.MaxKeysPerTag = val
+	}
+	if val, ok := storeConfig.Options["tag_limit_policy"].(string); ok {
+		config.TagLimitPolicy = val
+	}
+	if val, ok := storeConfig.Options["validate_serializable"].(bool); ok {
+		config.ValidateSerializable = val
+	}
+	if val, ok := storeConfig.Options["validate_serializer"]; ok {
+		ser, err := namedSerializer("validate_serializer", val)
+		if err != nil {
+			return nil, err
+		}
+		config.ValidationSerializer = ser
+	}
+	if config.ValidateSerializable && config.ValidationSerializer == nil {
+		config.ValidationSerializer = serializer.NewJSONSerializer()
+	}
+	if val, ok := storeConfig.Options["serializer"]; ok {
+		ser, err := namedSerializer("serializer", val)
+		if err != nil {
+			return nil, err
+		}
+		config.Serializer = ser
+	}
+	switch val := storeConfig.Options["budget"].(type) {
+	case *Budget:
+		config.Budget = val
+	case string:
+		budget, ok := lookupBudget(val)
+		if !ok {
+			return nil, dgcache.ErrInvalidConfig("unknown budget '%s'", val)
+		}
+		config.Budget = budget
 	}
 
 	d := &Driver{
-		items:   make(map[string]*dgcache.Item),
 		lru:     newLRUList(),
-		nodes:   make(map[string]*lruNode),
 		tags:    make(map[string]map[string]struct{}),
 		keyTags: make(map[string][]string),
 		prefix:  "",
-		done:    make(chan bool),
+		done:    make(chan struct{}),
 		config:  config,
+
+		tagLimitWarned: make(map[string]struct{}),
 	}
 
+	d.items, d.nodes = d.newIndex()
 	if config.EnableMetrics {
 		d.metrics = newMetrics()
 	}
+	if config.TrackHotKeys && config.HotKeysCapacity > 0 {
+		d.hotKeys = newHotKeys(config.HotKeysCapacity)
+	}
+
+	if config.Budget != nil {
+		config.Budget.join(d)
+	}
 
 	// Start cleanup goroutine
 	d.ticker = time.NewTicker(config.CleanupInterval)
+	d.background.Add(1)
 	go d.cleanup()
 
 	return d, nil
@@ -79,6 +172,7 @@ func NewDriver(storeConfig dgcache.StoreConfig) (cache.Driver, error) {
 
 // cleanup removes expired items periodically.
 func (d *Driver) cleanup() {
+	defer d.background.Done()
 	for {
 		select {
 		case <-d.ticker.C:
@@ -97,19 +191,37 @@ func (d *Driver) removeExpired() {
 	now := time.Now()
 	for key, item := range d.items {
 		if !item.ExpiresAt.IsZero() && item.ExpiresAt.Before(now) {
-			d.removeKeyTags(key)
-			delete(d.items, key)
-			delete(d.nodes, key)
+			if d.metrics != nil {
+				d.metrics.RecordExpiration(d.estimateSize(item.Value))
+			}
+			d.removeItem(key)
 		}
 	}
 }
 
-// prefixKey adds the prefix to the key.
+// prefixKey adds the prefix to the key, lowercasing the key first with
+// LowercaseKeys.
 func (d *Driver) prefixKey(key string) string {
+	key = d.normalizeKey(key)
 	if d.prefix == "" {
 		return key
 	}
-	return d.prefix + ":" + key
+	return d.prefix + d.config.PrefixSeparator + key
+}
+
+// normalizeKey returns key as the store identifies it, lowercased with
+// LowercaseKeys.
+func (d *Driver) normalizeKey(key string) string {
+	if d.config.LowercaseKeys {
+		return strings.ToLower(key)
+	}
+	return key
+}
+
+// tracksLRU reports whether access order is tracked.
+// Without size limits nothing is ever evicted, so the LRU bookkeeping is skipped.
+func (d *Driver) tracksLRU() bool {
+	return d.config.MaxItems > 0 || d.config.MaxBytes > 0 || d.config.Budget != nil
 }
 
 // estimateSize estimates the size of a value in bytes.
@@ -119,6 +231,14 @@ func (d *Driver) estimateSize(value interface{}) int64 {
 		return int64(len(v))
 	case []byte:
 		return int64(len(v))
+	case encodedValue:
+		return int64(len(v))
+	case hashValue:
+		var size int64
+		for field, value := range v {
+			size += int64(len(field)) + d.estimateSize(value)
+		}
+		return size
 	case int, int8, int16, int32, int64:
 		return 8
 	case uint, uint8, uint16, uint32, uint64:
@@ -142,34 +262,62 @@ func (d *Driver) evictIfNeeded(newItemSize int64) {
 
 	// Check bytes limit - evict until we have room for the new item
 	if d.config.MaxBytes > 0 {
-		// Calculate current size
-		currentBytes := int64(0)
-		if d.metrics != nil {
-			currentBytes = d.metrics.bytesUsed
-		} else {
-			// Calculate on the fly if metrics disabled
-			for _, item := range d.items {
-				currentBytes += d.estimateSize(item.Value)
-			}
-		}
-
-		for currentBytes+newItemSize > d.config.MaxBytes {
+		for d.bytesUsed()+newItemSize > d.config.MaxBytes {
 			if !d.evictOne() {
 				break // No more items to evict
 			}
-			// Recalculate current size after eviction
-			if d.metrics != nil {
-				currentBytes = d.metrics.bytesUsed
-			} else {
-				currentBytes = 0
-				for _, item := range d.items {
-					currentBytes += d.estimateSize(item.Value)
-				}
-			}
 		}
 	}
 }
 
+// bytesUsed returns the estimated size of all items.
+// Caller must hold the lock.
+func (d *Driver) bytesUsed() int64 {
+	return d.size
+}
+
+// setItem stores item under prefixedKey and keeps the size current.
+// Caller must hold the lock.
+func (d *Driver) setItem(prefixedKey string, item *dgcache.Item) {
+	if old, ok := d.items[prefixedKey]; ok {
+		d.size -= d.estimateSize(old.Value)
+	}
+	d.size += d.estimateSize(item.Value)
+	d.items[prefixedKey] = item
+}
+
+// deleteItem removes the item under prefixedKey and keeps the size current.
+// Caller must hold the lock.
+func (d *Driver) deleteItem(prefixedKey string) {
+	if old, ok := d.items[prefixedKey]; ok {
+		d.size -= d.estimateSize(old.Value)
+		delete(d.items, prefixedKey)
+	}
+}
+
+// usage returns the number of items and their estimated size.
+func (d *Driver) usage() (int, int64) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	return len(d.items), d.bytesUsed()
+}
+
+// enforceBudget evicts across the stores sharing the budget, if any, until
+// they fit it again. Caller must not hold the lock.
+func (d *Driver) enforceBudget() {
+	if d.config.Budget != nil {
+		d.config.Budget.enforce()
+	}
+}
+
+// evictForBudget evicts the least recently used item on behalf of the budget.
+func (d *Driver) evictForBudget() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.applyAccesses()
+	return d.evictOne()
+}
+
 // evictOne evicts a single item based on the eviction policy.
 // Returns true if an item was evicted, false if cache is empty.
 func (d *Driver) evictOne() bool {
@@ -185,7 +333,7 @@ func (d *Driver) evictOne() bool {
 				d.metrics.RecordEviction(size)
 			}
 			d.removeKeyTags(key)
-			delete(d.items, key)
+			d.deleteItem(key)
 			delete(d.nodes, key)
 			return true
 		}
@@ -194,30 +342,120 @@ func (d *Driver) evictOne() bool {
 }
 
 // Get retrieves a value from the cache.
+//
+// Reads share the read lock. LRU promotions are buffered and applied in
+// batches under the write lock, and always before anything is evicted.
 func (d *Driver) Get(ctx context.Context, key string) (interface{}, error) {
-	d.mu.Lock()
-	defer d.mu.Unlock()
-
 	prefixedKey := d.prefixKey(key)
-	item, ok := d.items[prefixedKey]
 
+	d.mu.RLock()
+	item, ok := d.items[prefixedKey]
 	if !ok || item.IsExpired() {
+		d.mu.RUnlock()
 		if d.metrics != nil {
 			d.metrics.RecordMiss()
 		}
 		return nil, dgcache.ErrKeyNotFound
 	}
+	value := d.readValue(item.Value)
+	sliding := item.SlidingTTL > 0
 
-	// Update LRU
-	if node, ok := d.nodes[prefixedKey]; ok {
-		d.lru.moveToFront(node)
+	var node *lruNode
+	if d.tracksLRU() {
+		if n, ok := d.nodes[prefixedKey]; ok && n != d.lru.head {
+			node = n
+		}
+	}
+	d.mu.RUnlock()
+
+	if node != nil {
+		d.recordAccess(node)
+	}
+	if sliding {
+		d.slide(prefixedKey, item)
 	}
 
 	if d.metrics != nil {
 		d.metrics.RecordHit()
 	}
+	if d.hotKeys != nil {
+		d.hotKeys.record(d.normalizeKey(key))
+	}
+
+	return value, nil
+}
+
+// slide resets the expiry of a sliding item after a read, bounded by the
+// configured max lifetime. It does nothing if the item was replaced or has
+// expired since it was read, or if Expire has since made it persistent.
+// Caller must not hold the lock.
+func (d *Driver) slide(prefixedKey string, item *dgcache.Item) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.items[prefixedKey] != item || item.IsExpired() || item.ExpiresAt.IsZero() {
+		return
+	}
 
-	return item.Value, nil
+	expiresAt := time.Now().Add(item.SlidingTTL)
+	if lifetime := d.config.SlidingTTLMaxLifetime; lifetime > 0 {
+		if deadline := item.CreatedAt.Add(lifetime); expiresAt.After(deadline) {
+			expiresAt = deadline
+		}
+	}
+	if expiresAt.After(item.ExpiresAt) {
+		item.ExpiresAt = expiresAt
+	}
+}
+
+// recordAccess buffers an LRU promotion, applying the batch once it is full.
+// Caller must not hold the lock.
+func (d *Driver) recordAccess(node *lruNode) {
+	d.accessMu.Lock()
+	d.accesses = append(d.accesses, node)
+	full := len(d.accesses) >= accessBufferSize
+	d.accessMu.Unlock()
+
+	if full {
+		d.mu.Lock()
+		d.applyAccesses()
+		d.mu.Unlock()
+	}
+}
+
+// applyAccesses moves buffered nodes to the front of the LRU list in access
+// order, skipping entries removed or replaced since they were read.
+// Caller must hold the lock.
+func (d *Driver) applyAccesses() {
+	d.accessMu.Lock()
+	defer d.accessMu.Unlock()
+
+	for i, node := range d.accesses {
+		if d.nodes[node.key] == node {
+			d.lru.moveToFront(node)
+		}
+		d.accesses[i] = nil
+	}
+	d.accesses = d.accesses[:0]
+}
+
+// readValue returns a cached value to a caller, decoded if it was stored with
+// Serializer, or else copied if ReturnCopies is set.
+func (d *Driver) readValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case encodedValue:
+		return d.decode(v)
+	case hashValue:
+		// Never hand out the live hash, which HSet replaces under the lock
+		fields := make(map[string]interface{}, len(v))
+		for field, fieldValue := range v {
+			fields[field] = d.readValue(fieldValue)
+		}
+		return fields
+	}
+	if d.config.ReturnCopies {
+		return copyValue(value)
+	}
+	return value
 }
 
 // GetMultiple retrieves multiple values from the cache.
@@ -229,15 +467,101 @@ func (d *Driver) GetMultiple(ctx context.Context, keys []string) (map[string]int
 	for _, key := range keys {
 		item, ok := d.items[d.prefixKey(key)]
 		if ok && !item.IsExpired() {
-			result[key] = item.Value
+			result[key] = d.readValue(item.Value)
+			if d.hotKeys != nil {
+				d.hotKeys.record(d.normalizeKey(key))
+			}
 		}
 	}
 
 	return result, nil
 }
 
+// GetMultipleWithTTL retrieves multiple values with their remaining TTL.
+func (d *Driver) GetMultipleWithTTL(ctx context.Context, keys []string) (map[string]dgcache.ValueTTL, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	now := time.Now()
+	result := make(map[string]dgcache.ValueTTL)
+	for _, key := range keys {
+		item, ok := d.items[d.prefixKey(key)]
+		if !ok || item.IsExpired() {
+			continue
+		}
+
+		ttl := dgcache.NoExpiry
+		if !item.ExpiresAt.IsZero() {
+			ttl = item.ExpiresAt.Sub(now)
+		}
+		result[key] = dgcache.ValueTTL{Value: d.readValue(item.Value), TTL: ttl}
+	}
+
+	return result, nil
+}
+
+// GetWithMeta retrieves a value with its remaining TTL, tags, and write time.
+// Unlike Get, it does not count as an access for eviction or sliding expiration.
+func (d *Driver) GetWithMeta(ctx context.Context, key string) (interface{}, dgcache.ItemMeta, error) {
+	prefixedKey := d.prefixKey(key)
+
+	d.mu.RLock()
+	item, ok := d.items[prefixedKey]
+	if !ok || item.IsExpired() {
+		d.mu.RUnlock()
+		if d.metrics != nil {
+			d.metrics.RecordMiss()
+		}
+		return nil, dgcache.ItemMeta{}, dgcache.ErrKeyNotFound
+	}
+
+	meta := dgcache.ItemMeta{TTL: dgcache.NoExpiry, CreatedAt: item.CreatedAt}
+	if !item.ExpiresAt.IsZero() {
+		meta.TTL = time.Until(item.ExpiresAt)
+	}
+	if tags := d.keyTags[prefixedKey]; len(tags) > 0 {
+		meta.Tags = append([]string(nil), tags...)
+		sort.Strings(meta.Tags)
+	}
+	value := d.readValue(item.Value)
+	d.mu.RUnlock()
+
+	if d.metrics != nil {
+		d.metrics.RecordHit()
+	}
+	return value, meta, nil
+}
+
+// GetItem returns a copy of the cache entry for key, with its value, expiry,
+// tags, and write time. Like GetWithMeta, it does not count as an access for
+// eviction or sliding expiration.
+func (d *Driver) GetItem(ctx context.Context, key string) (*dgcache.Item, error) {
+	prefixedKey := d.prefixKey(key)
+
+	d.mu.RLock()
+	item, ok := d.items[prefixedKey]
+	if !ok || item.IsExpired() {
+		d.mu.RUnlock()
+		if d.metrics != nil {
+			d.metrics.RecordMiss()
+		}
+		return nil, dgcache.ErrKeyNotFound
+	}
+
+	copied := *item
+	copied.Value = d.readValue(item.Value)
+	copied.Tags = append([]string(nil), item.Tags...)
+	d.mu.RUnlock()
+
+	if d.metrics != nil {
+		d.metrics.RecordHit()
+	}
+	return &copied, nil
+}
+
 // Put stores a value in the cache with the given TTL.
 func (d *Driver) Put(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	defer d.enforceBudget()
 	d.mu.Lock()
 	defer d.mu.Unlock()
 	return d.put(key, value, ttl)
@@ -245,8 +569,43 @@ func (d *Driver) Put(ctx context.Context, key string, value interface{}, ttl tim
 
 // put is the internal unlocked implementation of Put.
 func (d *Driver) put(key string, value interface{}, ttl time.Duration) error {
-	prefixedKey := d.prefixKey(key)
-	newSize := d.estimateSize(value)
+	ttl, err := d.config.TTLLimits.Apply(ttl)
+	if err != nil {
+		return err
+	}
+	if err := d.checkSerializable(key, value); err != nil {
+		return err
+	}
+	value, err = d.encode(key, value)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	item := &dgcache.Item{
+		Key:       key,
+		Value:     value,
+		CreatedAt: now,
+	}
+	if ttl > 0 {
+		item.ExpiresAt = now.Add(ttl)
+		if d.config.SlidingTTL {
+			item.SlidingTTL = ttl
+		}
+	}
+	d.storeItem(d.prefixKey(key), item)
+	return nil
+}
+
+// storeItem writes item, whose value is already encoded, at prefixedKey with
+// the bookkeeping of a write: eviction to make room, metrics, the key's tags
+// and its LRU position. Caller must hold the lock.
+func (d *Driver) storeItem(prefixedKey string, item *dgcache.Item) {
+	if d.tracksLRU() {
+		d.applyAccesses()
+	}
+
+	newSize := d.estimateSize(item.Value)
 
 	// Calculate net size change (for replacements)
 	netSizeChange := newSize
@@ -260,18 +619,13 @@ func (d *Driver) put(key string, value interface{}, ttl time.Duration) error {
 		d.evictIfNeeded(netSizeChange)
 	}
 
-	item := &dgcache.Item{
-		Key:   key,
-		Value: value,
-	}
-
-	if ttl > 0 {
-		item.ExpiresAt = time.Now().Add(ttl)
-	}
-
 	// Update metrics
 	if d.metrics != nil {
-		if oldItem, ok := d.items[prefixedKey]; ok {
+		if oldItem, ok := d.items[prefixedKey]; ok && oldItem.IsExpired() {
+			// The old item expired before the cleanup got to it
+			d.metrics.RecordExpiration(d.estimateSize(oldItem.Value))
+			d.metrics.RecordSet(newSize)
+		} else if ok {
 			// Replacing existing item
 			oldSize := d.estimateSize(oldItem.Value)
 			d.metrics.RecordUpdate(oldSize, newSize)
@@ -280,35 +634,71 @@ func (d *Driver) put(key string, value interface{}, ttl time.Duration) error {
 		}
 	}
 
-	d.items[prefixedKey] = item
+	item.Tags = d.keyTags[prefixedKey]
+	d.setItem(prefixedKey, item)
 
 	// Update LRU
-	if node, ok := d.nodes[prefixedKey]; ok {
-		d.lru.moveToFront(node)
-	} else {
-		d.nodes[prefixedKey] = d.lru.addToFront(prefixedKey)
+	if d.tracksLRU() {
+		if node, ok := d.nodes[prefixedKey]; ok {
+			d.lru.moveToFront(node)
+		} else {
+			d.nodes[prefixedKey] = d.lru.addToFront(prefixedKey)
+		}
 	}
+}
 
+// checkSerializable returns an error wrapping ErrInvalidValue if
+// ValidateSerializable is set and value of key cannot be serialized.
+func (d *Driver) checkSerializable(key string, value interface{}) error {
+	if !d.config.ValidateSerializable {
+		return nil
+	}
+	ser := d.config.ValidationSerializer
+	if _, err := ser.Marshal(value); err != nil {
+		return fmt.Errorf("%w: %q cannot be serialized with %s: %w", dgcache.ErrInvalidValue, key, ser.Name(), err)
+	}
 	return nil
 }
 
 // PutMultiple stores multiple values in the cache.
 func (d *Driver) PutMultiple(ctx context.Context, items map[string]interface{}, ttl time.Duration) error {
+	ttl, err := d.config.TTLLimits.Apply(ttl)
+	if err != nil {
+		return err
+	}
+	// Check and encode every value first so a failure writes nothing
+	values := make(map[string]interface{}, len(items))
+	for key, value := range items {
+		if err := d.checkSerializable(key, value); err != nil {
+			return err
+		}
+		if values[key], err = d.encode(key, value); err != nil {
+			return err
+		}
+	}
+
+	defer d.enforceBudget()
 	d.mu.Lock()
 	defer d.mu.Unlock()
 
-	expiresAt := time.Time{}
+	now := time.Now()
+	var expiresAt time.Time
+	var slidingTTL time.Duration
 	if ttl > 0 {
-		expiresAt = time.Now().Add(ttl)
+		expiresAt = now.Add(ttl)
+		if d.config.SlidingTTL {
+			slidingTTL = ttl
+		}
 	}
 
-	for key, value := range items {
-		item := &dgcache.Item{
-			Key:       key,
-			Value:     value,
-			ExpiresAt: expiresAt,
-		}
-		d.items[d.prefixKey(key)] = item
+	for key, value := range values {
+		d.storeItem(d.prefixKey(key), &dgcache.Item{
+			Key:        key,
+			Value:      value,
+			ExpiresAt:  expiresAt,
+			CreatedAt:  now,
+			SlidingTTL: slidingTTL,
+		})
 	}
 
 	return nil
@@ -316,30 +706,125 @@ func (d *Driver) PutMultiple(ctx context.Context, items map[string]interface{},
 
 // Increment increments the value of a key.
 func (d *Driver) Increment(ctx context.Context, key string, value int64) (int64, error) {
+	defer d.enforceBudget()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.increment(key, value, 0)
+}
+
+// IncrementWithTTL increments the value of a key, setting ttl only when the
+// increment creates it.
+func (d *Driver) IncrementWithTTL(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	defer d.enforceBudget()
 	d.mu.Lock()
 	defer d.mu.Unlock()
+	return d.increment(key, delta, ttl)
+}
 
+// increment is the internal unlocked implementation of Increment. An existing
+// counter keeps its expiry, creation time and LRU position, like Redis INCRBY.
+// A new one is written like a Put with ttl, so the TTL limits, eviction and
+// metrics apply.
+func (d *Driver) increment(key string, delta int64, ttl time.Duration) (int64, error) {
 	prefixedKey := d.prefixKey(key)
 	item, ok := d.items[prefixedKey]
-
-	var current int64
-	if ok && !item.IsExpired() {
-		if v, ok := item.Value.(int64); ok {
-			current = v
+	if !ok || item.IsExpired() {
+		if err := d.put(key, delta, ttl); err != nil {
+			return 0, err
 		}
+		return delta, nil
 	}
 
-	newValue := current + value
-	d.items[prefixedKey] = &dgcache.Item{
-		Key:   key,
-		Value: newValue,
+	stored := d.readValue(item.Value)
+	current, ok := toInt64(stored)
+	if !ok {
+		return 0, notANumber(key, stored)
 	}
-
+	newValue, err := addInt64(key, current, delta)
+	if err != nil {
+		return 0, err
+	}
+	encoded, err := d.encode(key, newValue)
+	if err != nil {
+		return 0, err
+	}
+	updated := *item
+	updated.Value = encoded
+	d.setItem(prefixedKey, &updated)
 	return newValue, nil
 }
 
+// checkIncrement returns the error increment would return for key without
+// changing it. The caller must hold the lock.
+func (d *Driver) checkIncrement(key string, delta int64) error {
+	item, ok := d.items[d.prefixKey(key)]
+	if !ok || item.IsExpired() {
+		_, err := d.config.TTLLimits.Apply(0)
+		return err
+	}
+	stored := d.readValue(item.Value)
+	current, ok := toInt64(stored)
+	if !ok {
+		return notANumber(key, stored)
+	}
+	_, err := addInt64(key, current, delta)
+	return err
+}
+
+// toInt64 converts a stored value to a counter. Like Redis, it accepts integer
+// strings; any other non-integer value is rejected.
+func toInt64(value interface{}) (int64, bool) {
+	switch v := value.(type) {
+	case int64:
+		return v, true
+	case int:
+		return int64(v), true
+	case int8:
+		return int64(v), true
+	case int16:
+		return int64(v), true
+	case int32:
+		return int64(v), true
+	case uint8:
+		return int64(v), true
+	case uint16:
+		return int64(v), true
+	case uint32:
+		return int64(v), true
+	case string:
+		n, err := strconv.ParseInt(v, 10, 64)
+		return n, err == nil
+	}
+	return 0, false
+}
+
+// notANumber reports an increment of key, which holds the non-integer value.
+func notANumber(key string, value interface{}) error {
+	return fmt.Errorf("%w: %q holds %T", dgcache.ErrNotANumber, key, value)
+}
+
+// addInt64 adds delta to the counter at key, rejecting a sum that wraps
+// around instead of storing it. Like Redis INCRBY, the counter is unchanged.
+func addInt64(key string, current, delta int64) (int64, error) {
+	sum := current + delta
+	// Overflow flips the sign of a sum whose operands share one
+	if (current >= 0) == (delta >= 0) && (sum >= 0) != (current >= 0) {
+		return 0, fmt.Errorf("%w: %q holds %d, adding %d", dgcache.ErrOverflow, key, current, delta)
+	}
+	return sum, nil
+}
+
+// decrementOverflow reports a decrement by math.MinInt64, which cannot be
+// negated into an increment.
+func decrementOverflow(key string) error {
+	return fmt.Errorf("%w: %q decremented by %d", dgcache.ErrOverflow, key, int64(math.MinInt64))
+}
+
 // Decrement decrements the value of a key.
 func (d *Driver) Decrement(ctx context.Context, key string, value int64) (int64, error) {
+	if value == math.MinInt64 {
+		return 0, decrementOverflow(key)
+	}
 	return d.Increment(ctx, key, -value)
 }
 
@@ -348,6 +833,95 @@ func (d *Driver) Forever(ctx context.Context, key string, value interface{}) err
 	return d.Put(ctx, key, value, 0)
 }
 
+// ExtendTTL sets the key to expire after ttl only if that is later than its current expiry.
+// ttl is bounded by the TTL limits first.
+func (d *Driver) ExtendTTL(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	ttl, err := d.config.TTLLimits.Apply(ttl)
+	if err != nil {
+		return false, err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	item, ok := d.items[d.prefixKey(key)]
+	if !ok || item.IsExpired() || item.ExpiresAt.IsZero() {
+		return false, nil
+	}
+
+	expiresAt := time.Now().Add(ttl)
+	if !expiresAt.After(item.ExpiresAt) {
+		return false, nil
+	}
+
+	item.ExpiresAt = expiresAt
+	return true, nil
+}
+
+// Expire sets the TTL of key, replacing any existing expiry.
+// The TTL limits apply as for a write, so a non-positive ttl removes the
+// expiry only when no max_ttl is set.
+func (d *Driver) Expire(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	ttl, err := d.config.TTLLimits.Apply(ttl)
+	if err != nil {
+		return false, err
+	}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	item, ok := d.items[d.prefixKey(key)]
+	if !ok || item.IsExpired() {
+		return false, nil
+	}
+
+	if ttl > 0 {
+		item.ExpiresAt = time.Now().Add(ttl)
+	} else {
+		item.ExpiresAt = time.Time{}
+	}
+	return true, nil
+}
+
+// Rename moves the value at oldKey to newKey, preserving its expiry and tags.
+func (d *Driver) Rename(ctx context.Context, oldKey, newKey string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	oldPrefixed := d.prefixKey(oldKey)
+	newPrefixed := d.prefixKey(newKey)
+
+	item, ok := d.items[oldPrefixed]
+	if !ok || item.IsExpired() {
+		return dgcache.ErrKeyNotFound
+	}
+	if oldPrefixed == newPrefixed {
+		return nil
+	}
+
+	// Overwrite any existing destination
+	if _, exists := d.items[newPrefixed]; exists {
+		d.removeItem(newPrefixed)
+	}
+
+	if node, ok := d.nodes[oldPrefixed]; ok {
+		node.key = newPrefixed
+		d.nodes[newPrefixed] = node
+		delete(d.nodes, oldPrefixed)
+	}
+
+	item.Key = newKey
+	d.deleteItem(oldPrefixed)
+	d.setItem(newPrefixed, item)
+
+	// Move the tags once the item has moved, so it keeps them
+	tags := d.keyTags[oldPrefixed]
+	d.removeKeyTags(oldPrefixed)
+	d.addKeyTags(newPrefixed, tags)
+
+	return nil
+}
+
 // Forget removes a value from the cache.
 func (d *Driver) Forget(ctx context.Context, key string) error {
 	d.mu.Lock()
@@ -357,10 +931,7 @@ func (d *Driver) Forget(ctx context.Context, key string) error {
 
 // forget is the internal unlocked implementation of Forget.
 func (d *Driver) forget(key string) error {
-	prefixedKey := d.prefixKey(key)
-	d.removeKeyTags(prefixedKey)
-	delete(d.items, prefixedKey)
-	delete(d.nodes, prefixedKey)
+	d.removeItem(d.prefixKey(key))
 	return nil
 }
 
@@ -370,10 +941,7 @@ func (d *Driver) ForgetMultiple(ctx context.Context, keys []string) error {
 	defer d.mu.Unlock()
 
 	for _, key := range keys {
-		prefixedKey := d.prefixKey(key)
-		d.removeKeyTags(prefixedKey)
-		delete(d.items, prefixedKey)
-		delete(d.nodes, prefixedKey)
+		d.removeItem(d.prefixKey(key))
 	}
 	return nil
 }
@@ -382,16 +950,80 @@ func (d *Driver) ForgetMultiple(ctx context.Context, keys []string) error {
 func (d *Driver) Flush(ctx context.Context) error {
 	d.mu.Lock()
 	defer d.mu.Unlock()
+	d.flush()
+	return nil
+}
+
+// SwapAll replaces the contents of the cache with items under a single write
+// lock, so readers see either the old or the new dataset.
+func (d *Driver) SwapAll(ctx context.Context, items map[string]interface{}, ttl time.Duration) error {
+	// Fail before anything is removed
+	if _, err := d.config.TTLLimits.Apply(ttl); err != nil {
+		return err
+	}
+
+	defer d.enforceBudget()
+	d.mu.Lock()
+	defer d.mu.Unlock()
 
+	d.flush()
+	for key, value := range items {
+		if err := d.put(key, value, ttl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// newIndex creates the item and LRU node maps, presized by InitialCapacity.
+// The node map is only presized when LRU tracking is on.
+func (d *Driver) newIndex() (map[string]*dgcache.Item, map[string]*lruNode) {
+	capacity := d.config.InitialCapacity
+	if capacity < 0 {
+		capacity = 0
+	}
+	nodeCapacity := 0
+	if d.tracksLRU() {
+		nodeCapacity = capacity
+	}
+	return make(map[string]*dgcache.Item, capacity), make(map[string]*lruNode, nodeCapacity)
+}
+
+// flush is the internal unlocked implementation of Flush.
+func (d *Driver) flush() {
 	// Clear everything
-	d.items = make(map[string]*dgcache.Item)
-	d.nodes = make(map[string]*lruNode)
+	d.items, d.nodes = d.newIndex()
+	d.size = 0
 	d.lru = newLRUList()
 	d.tags = make(map[string]map[string]struct{})
 	d.keyTags = make(map[string][]string)
+	d.tagLimitWarned = make(map[string]struct{})
+}
+
+// FlushExcept removes all items whose key does not match any of the patterns.
+func (d *Driver) FlushExcept(ctx context.Context, patterns ...string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for prefixedKey, item := range d.items {
+		if !dgcache.MatchAny(patterns, item.Key) {
+			d.removeItem(prefixedKey)
+		}
+	}
 	return nil
 }
 
+// removeItem removes an item and all its bookkeeping by prefixed key.
+// Caller must hold the lock.
+func (d *Driver) removeItem(prefixedKey string) {
+	d.removeKeyTags(prefixedKey)
+	if node, ok := d.nodes[prefixedKey]; ok {
+		d.lru.remove(node)
+		delete(d.nodes, prefixedKey)
+	}
+	d.deleteItem(prefixedKey)
+}
+
 // Has checks if a key exists in the cache.
 func (d *Driver) Has(ctx context.Context, key string) (bool, error) {
 	d.mu.RLock()
@@ -405,6 +1037,19 @@ func (d *Driver) Has(ctx context.Context, key string) (bool, error) {
 	return !item.IsExpired(), nil
 }
 
+// HasMultiple checks under one read lock whether each key exists.
+func (d *Driver) HasMultiple(ctx context.Context, keys []string) (map[string]bool, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	result := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		item, ok := d.items[d.prefixKey(key)]
+		result[key] = ok && !item.IsExpired()
+	}
+	return result, nil
+}
+
 // Missing checks if a key does not exist in the cache.
 func (d *Driver) Missing(ctx context.Context, key string) (bool, error) {
 	has, err := d.Has(ctx, key)
@@ -426,6 +1071,20 @@ func (d *Driver) Name() string {
 	return "memory"
 }
 
+// Info returns the driver's effective configuration. The serializer is
+// reported only when Config.Serializer is set; otherwise values are stored
+// as-is. Compression is never used.
+func (d *Driver) Info() dgcache.DriverInfo {
+	info := dgcache.DriverInfo{
+		Driver: d.Name(),
+		Prefix: d.prefix,
+	}
+	if d.config.Serializer != nil {
+		info.Serializer = d.config.Serializer.Name()
+	}
+	return info
+}
+
 // Stats returns a snapshot of current cache statistics.
 func (d *Driver) Stats() cache.Stats {
 	if d.metrics == nil {
@@ -434,10 +1093,28 @@ func (d *Driver) Stats() cache.Stats {
 	return d.metrics.Stats()
 }
 
-// Close closes the driver and releases resources.
+// Expirations returns the number of items removed because their TTL passed,
+// or 0 when metrics are disabled. It implements dgcache.ExpirationCounter.
+func (d *Driver) Expirations() int64 {
+	if d.metrics == nil {
+		return 0
+	}
+	return d.metrics.Expirations()
+}
+
+// Ping always succeeds for the in-memory driver.
+func (d *Driver) Ping(ctx context.Context) error {
+	return nil
+}
+
+// Close closes the driver and releases resources. It returns once the
+// cleanup goroutine has exited.
 func (d *Driver) Close() error {
+	if d.config.Budget != nil {
+		d.config.Budget.leave(d)
+	}
 	d.ticker.Stop()
-	d.done <- true
 	close(d.done)
+	d.background.Wait()
 	return nil
 }
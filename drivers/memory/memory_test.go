@@ -0,0 +1,1200 @@
+package memory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	dgcache "github.com/donnigundala/dg-cache"
+	"github.com/donnigundala/dg-core/contracts/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+)
+
+func newTestDriver(t *testing.T, options map[string]interface{}) *Driver {
+	t.Helper()
+	d, err := NewDriver(dgcache.StoreConfig{
+		Driver:  "memory",
+		Options: options,
+	})
+	require.NoError(t, err)
+	t.Cleanup(func() { d.Close() })
+	return d.(*Driver)
+}
+
+func TestDriver_ExtendTTL(t *testing.T) {
+	d := newTestDriver(t, nil)
+	ctx := context.Background()
+
+	require.NoError(t, d.Put(ctx, "key", "value", time.Minute))
+	original := d.items["key"].ExpiresAt
+
+	// A shorter TTL must not shorten the expiry
+	extended, err := d.ExtendTTL(ctx, "key", time.Second)
+	assert.NoError(t, err)
+	assert.False(t, extended)
+	assert.Equal(t, original, d.items["key"].ExpiresAt)
+
+	// A longer TTL extends it
+	extended, err = d.ExtendTTL(ctx, "key", time.Hour)
+	assert.NoError(t, err)
+	assert.True(t, extended)
+	assert.True(t, d.items["key"].ExpiresAt.After(original))
+
+	// Keys without expiry are left alone
+	require.NoError(t, d.Forever(ctx, "forever", "value"))
+	extended, err = d.ExtendTTL(ctx, "forever", time.Hour)
+	assert.NoError(t, err)
+	assert.False(t, extended)
+	assert.True(t, d.items["forever"].ExpiresAt.IsZero())
+
+	// Missing keys are reported as not extended
+	extended, err = d.ExtendTTL(ctx, "missing", time.Hour)
+	assert.NoError(t, err)
+	assert.False(t, extended)
+}
+
+func TestDriver_Expire(t *testing.T) {
+	d := newTestDriver(t, nil)
+	ctx := context.Background()
+
+	_, err := d.Increment(ctx, "counter", 1)
+	require.NoError(t, err)
+
+	set, err := d.Expire(ctx, "counter", time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, set)
+	expiresAt := d.items["counter"].ExpiresAt
+	assert.False(t, expiresAt.IsZero())
+
+	// Incrementing keeps the expiry
+	_, err = d.Increment(ctx, "counter", 1)
+	require.NoError(t, err)
+	assert.Equal(t, expiresAt, d.items["counter"].ExpiresAt)
+
+	// A non-positive TTL removes the expiry
+	set, err = d.Expire(ctx, "counter", 0)
+	assert.NoError(t, err)
+	assert.True(t, set)
+	assert.True(t, d.items["counter"].ExpiresAt.IsZero())
+
+	set, err = d.Expire(ctx, "missing", time.Minute)
+	assert.NoError(t, err)
+	assert.False(t, set)
+}
+
+func TestDriver_IncrementWithTTL(t *testing.T) {
+	d := newTestDriver(t, nil)
+	ctx := context.Background()
+
+	n, err := d.IncrementWithTTL(ctx, "hits", 1, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	expiresAt := d.items["hits"].ExpiresAt
+	assert.False(t, expiresAt.IsZero())
+
+	// Later increments keep the original expiry, even with a different TTL
+	time.Sleep(5 * time.Millisecond)
+	n, err = d.IncrementWithTTL(ctx, "hits", 2, time.Hour)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+	assert.Equal(t, expiresAt, d.items["hits"].ExpiresAt)
+
+	// An expired counter starts over with a fresh window
+	n, err = d.IncrementWithTTL(ctx, "short", 1, 20*time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	time.Sleep(30 * time.Millisecond)
+	n, err = d.IncrementWithTTL(ctx, "short", 1, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	assert.True(t, d.items["short"].ExpiresAt.After(time.Now().Add(30*time.Second)))
+}
+
+func TestDriver_IncrementOverflow(t *testing.T) {
+	d := newTestDriver(t, nil)
+	ctx := context.Background()
+
+	require.NoError(t, d.Put(ctx, "balance", int64(math.MaxInt64-1), 0))
+	n, err := d.Increment(ctx, "balance", 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(math.MaxInt64), n)
+
+	// The counter is rejected and kept rather than wrapped negative
+	_, err = d.Increment(ctx, "balance", 1)
+	assert.ErrorIs(t, err, dgcache.ErrOverflow)
+	_, err = d.IncrementWithTTL(ctx, "balance", math.MaxInt64, time.Minute)
+	assert.ErrorIs(t, err, dgcache.ErrOverflow)
+	value, err := d.Get(ctx, "balance")
+	require.NoError(t, err)
+	assert.Equal(t, int64(math.MaxInt64), value)
+
+	require.NoError(t, d.Put(ctx, "debt", int64(math.MinInt64+1), 0))
+	_, err = d.Decrement(ctx, "debt", 2)
+	assert.ErrorIs(t, err, dgcache.ErrOverflow)
+	_, err = d.Decrement(ctx, "zero", math.MinInt64)
+	assert.ErrorIs(t, err, dgcache.ErrOverflow)
+
+	// Operands of opposite signs never overflow
+	n, err = d.Increment(ctx, "debt", math.MaxInt64)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), n)
+
+	err = d.Transaction(ctx, func(tx cache.Store) error {
+		_, err := tx.Increment(ctx, "balance", 1)
+		return err
+	})
+	assert.ErrorIs(t, err, dgcache.ErrOverflow)
+}
+
+func TestDriver_InitialCapacity(t *testing.T) {
+	d := newTestDriver(t, map[string]interface{}{"initial_capacity": 1000, "max_items": 2000})
+	ctx := context.Background()
+	assert.Equal(t, 1000, d.config.InitialCapacity)
+
+	for i := 0; i < 1500; i++ {
+		require.NoError(t, d.Put(ctx, fmt.Sprintf("key:%d", i), i, time.Minute))
+	}
+	val, err := d.Get(ctx, "key:1499")
+	require.NoError(t, err)
+	assert.Equal(t, 1499, val)
+	assert.Len(t, d.items, 1500)
+
+	require.NoError(t, d.Flush(ctx))
+	require.NoError(t, d.Put(ctx, "key", "value", time.Minute))
+	val, err = d.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, "value", val)
+}
+
+func TestDriver_FlushExcept(t *testing.T) {
+	// A limit enables LRU tracking, so removal from the LRU list is checked too
+	d := newTestDriver(t, map[string]interface{}{"max_items": 100})
+	d.SetPrefix("app")
+	ctx := context.Background()
+
+	require.NoError(t, d.Put(ctx, "config:flags", "on", 0))
+	require.NoError(t, d.Put(ctx, "config:limits", "10", 0))
+	require.NoError(t, d.Put(ctx, "user:1", "john", 0))
+	require.NoError(t, d.Put(ctx, "session:abc", "data", 0))
+
+	err := d.FlushExcept(ctx, "config:*")
+	assert.NoError(t, err)
+
+	for key, expected := range map[string]bool{
+		"config:flags":  true,
+		"config:limits": true,
+		"user:1":        false,
+		"session:abc":   false,
+	} {
+		has, err := d.Has(ctx, key)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, has, key)
+	}
+	assert.Equal(t, 2, d.lru.len())
+}
+
+func TestDriver_PrefixSeparator(t *testing.T) {
+	d := newTestDriver(t, map[string]interface{}{
+		"prefix_separator": "/",
+	})
+	d.SetPrefix("app")
+	ctx := context.Background()
+
+	require.NoError(t, d.Put(ctx, "key", "value", 0))
+	assert.Contains(t, d.items, "app/key")
+
+	val, err := d.Get(ctx, "key")
+	assert.NoError(t, err)
+	assert.Equal(t, "value", val)
+}
+
+func TestDriver_Rename(t *testing.T) {
+	d := newTestDriver(t, nil)
+	ctx := context.Background()
+
+	require.NoError(t, d.Tags("indexes").Put(ctx, "index:building", "data", time.Minute))
+	expiresAt := d.items["index:building"].ExpiresAt
+
+	err := d.Rename(ctx, "index:building", "index:live")
+	require.NoError(t, err)
+
+	val, err := d.Get(ctx, "index:live")
+	assert.NoError(t, err)
+	assert.Equal(t, "data", val)
+	assert.Equal(t, expiresAt, d.items["index:live"].ExpiresAt)
+
+	has, _ := d.Has(ctx, "index:building")
+	assert.False(t, has)
+
+	// Tag associations follow the key
+	require.NoError(t, d.FlushTags(ctx, "indexes"))
+	has, _ = d.Has(ctx, "index:live")
+	assert.False(t, has)
+
+	err = d.Rename(ctx, "missing", "other")
+	assert.ErrorIs(t, err, dgcache.ErrKeyNotFound)
+}
+
+func TestDriver_MaxTTL(t *testing.T) {
+	d := newTestDriver(t, map[string]interface{}{
+		"max_ttl": "24h",
+	})
+	ctx := context.Background()
+
+	require.NoError(t, d.Forever(ctx, "forever", "value"))
+	expiresAt := d.items["forever"].ExpiresAt
+	require.False(t, expiresAt.IsZero())
+	assert.WithinDuration(t, time.Now().Add(24*time.Hour), expiresAt, time.Second)
+
+	require.NoError(t, d.Put(ctx, "long", "value", 48*time.Hour))
+	assert.WithinDuration(t, time.Now().Add(24*time.Hour), d.items["long"].ExpiresAt, time.Second)
+
+	require.NoError(t, d.Put(ctx, "short", "value", time.Minute))
+	assert.WithinDuration(t, time.Now().Add(time.Minute), d.items["short"].ExpiresAt, time.Second)
+}
+
+func TestDriver_MaxTTLReject(t *testing.T) {
+	d := newTestDriver(t, map[string]interface{}{
+		"max_ttl":    time.Hour,
+		"ttl_policy": "reject",
+	})
+	ctx := context.Background()
+
+	err := d.Put(ctx, "long", "value", 2*time.Hour)
+	assert.ErrorIs(t, err, dgcache.ErrTTLOutOfRange)
+	err = d.Forever(ctx, "forever", "value")
+	assert.ErrorIs(t, err, dgcache.ErrTTLOutOfRange)
+
+	has, _ := d.Has(ctx, "long")
+	assert.False(t, has)
+	assert.NoError(t, d.Put(ctx, "short", "value", time.Minute))
+}
+
+func TestDriver_TTLLimitsOnExpiryChanges(t *testing.T) {
+	d := newTestDriver(t, map[string]interface{}{
+		"max_ttl": "1h",
+	})
+	ctx := context.Background()
+	tagged := d.Tags("counters")
+
+	// Counters created without a TTL get max_ttl and keep it when incremented
+	for name, incr := range map[string]func(string) (int64, error){
+		"increment": func(key string) (int64, error) { return d.Increment(ctx, key, 1) },
+		"decrement": func(key string) (int64, error) { return d.Decrement(ctx, key, 1) },
+		"with_ttl":  func(key string) (int64, error) { return d.IncrementWithTTL(ctx, key, 1, 48*time.Hour) },
+		"tagged":    func(key string) (int64, error) { return tagged.Increment(ctx, key, 1) },
+	} {
+		_, err := incr(name)
+		require.NoError(t, err, name)
+		assert.WithinDuration(t, time.Now().Add(time.Hour), d.items[name].ExpiresAt, time.Second, name)
+	}
+
+	require.NoError(t, d.Put(ctx, "key", "value", time.Minute))
+	ok, err := d.ExtendTTL(ctx, "key", 48*time.Hour)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), d.items["key"].ExpiresAt, time.Second)
+
+	ok, err = d.Expire(ctx, "key", 48*time.Hour)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), d.items["key"].ExpiresAt, time.Second)
+
+	// Removing the expiry would exceed max_ttl too
+	ok, err = d.Expire(ctx, "key", 0)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), d.items["key"].ExpiresAt, time.Second)
+
+	rejecting := newTestDriver(t, map[string]interface{}{
+		"max_ttl":    time.Hour,
+		"ttl_policy": "reject",
+	})
+	_, err = rejecting.Increment(ctx, "counter", 1)
+	assert.ErrorIs(t, err, dgcache.ErrTTLOutOfRange)
+	_, err = rejecting.IncrementWithTTL(ctx, "counter", 1, 2*time.Hour)
+	assert.ErrorIs(t, err, dgcache.ErrTTLOutOfRange)
+	n, err := rejecting.IncrementWithTTL(ctx, "counter", 1, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	// Existing counters keep their TTL, so nothing is rejected
+	n, err = rejecting.Increment(ctx, "counter", 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	_, err = rejecting.Expire(ctx, "counter", 0)
+	assert.ErrorIs(t, err, dgcache.ErrTTLOutOfRange)
+	_, err = rejecting.ExtendTTL(ctx, "counter", 2*time.Hour)
+	assert.ErrorIs(t, err, dgcache.ErrTTLOutOfRange)
+}
+
+func TestDriver_TTLLimitsInvalid(t *testing.T) {
+	for name, value := range map[string]interface{}{
+		"unparsable": "one hour",
+		"int":        3600,
+		"float":      1.5,
+	} {
+		_, err := NewDriver(dgcache.StoreConfig{Options: map[string]interface{}{"max_ttl": value}})
+		assert.ErrorContains(t, err, "invalid config", name)
+	}
+
+	_, err := NewDriver(dgcache.StoreConfig{Options: map[string]interface{}{"ttl_policy": "drop"}})
+	assert.ErrorContains(t, err, "unknown ttl_policy")
+
+	for name, options := range map[string]map[string]interface{}{
+		"negative min": {"min_ttl": -time.Second},
+		"negative max": {"max_ttl": "-1m"},
+		"min over max": {"min_ttl": time.Hour, "max_ttl": time.Minute, "ttl_policy": "reject"},
+	} {
+		_, err := NewDriver(dgcache.StoreConfig{Options: options})
+		assert.ErrorContains(t, err, "invalid config", name)
+	}
+}
+
+func TestDriver_MinTTL(t *testing.T) {
+	d := newTestDriver(t, map[string]interface{}{
+		"min_ttl": time.Second,
+	})
+	ctx := context.Background()
+
+	require.NoError(t, d.Put(ctx, "short", "value", time.Millisecond))
+	time.Sleep(5 * time.Millisecond)
+	val, err := d.Get(ctx, "short")
+	require.NoError(t, err)
+	assert.Equal(t, "value", val)
+	assert.WithinDuration(t, time.Now().Add(time.Second), d.items["short"].ExpiresAt, 50*time.Millisecond)
+
+	require.NoError(t, d.Forever(ctx, "forever", "value"))
+	assert.True(t, d.items["forever"].ExpiresAt.IsZero())
+}
+
+func TestDriver_HotKeys(t *testing.T) {
+	d := newTestDriver(t, map[string]interface{}{
+		"track_hot_keys":    true,
+		"hot_keys_capacity": 10,
+	})
+	ctx := context.Background()
+
+	for i := 0; i < 50; i++ {
+		require.NoError(t, d.Put(ctx, fmt.Sprintf("key:%d", i), i, 0))
+	}
+
+	// Skewed pattern: two hot keys amid a long tail of single reads
+	for i := 0; i < 100; i++ {
+		_, _ = d.Get(ctx, "key:1")
+		if i%2 == 0 {
+			_, _ = d.Get(ctx, "key:2")
+		}
+	}
+	for i := 0; i < 50; i++ {
+		_, _ = d.Get(ctx, fmt.Sprintf("key:%d", i))
+	}
+
+	hot := d.HotKeys(2)
+	require.Len(t, hot, 2)
+	assert.Equal(t, "key:1", hot[0].Key)
+	assert.Equal(t, "key:2", hot[1].Key)
+	assert.GreaterOrEqual(t, hot[0].Count, int64(100))
+
+	d.ResetHotKeys()
+	assert.Empty(t, d.HotKeys(10))
+
+	// Disabled by default
+	assert.Nil(t, newTestDriver(t, nil).HotKeys(10))
+}
+
+func TestDriver_HotKeysNormalizedHits(t *testing.T) {
+	d := newTestDriver(t, map[string]interface{}{
+		"track_hot_keys": true,
+		"lowercase_keys": true,
+	})
+	ctx := context.Background()
+
+	require.NoError(t, d.Put(ctx, "User:1", "jane", 0))
+	_, _ = d.Get(ctx, "User:1")
+	_, _ = d.Get(ctx, "user:1")
+	_, _ = d.GetMultiple(ctx, []string{"USER:1", "missing"})
+	_, _ = d.Get(ctx, "missing")
+
+	assert.Equal(t, []KeyCount{{Key: "user:1", Count: 3}}, d.HotKeys(10))
+}
+
+func TestHotKeys_ReplacesLeastCounted(t *testing.T) {
+	h := newHotKeys(2)
+	for _, key := range []string{"a", "a", "a", "b", "b", "c", "c"} {
+		h.record(key)
+	}
+
+	// c replaced b, the least counted key, and inherited its count
+	assert.Equal(t, []KeyCount{{Key: "c", Count: 4}, {Key: "a", Count: 3}}, h.top(-1))
+
+	h.record("d")
+	assert.Equal(t, []KeyCount{{Key: "c", Count: 4}, {Key: "d", Count: 4}}, h.top(-1))
+}
+
+func TestDriver_Transaction(t *testing.T) {
+	d := newTestDriver(t, nil)
+	ctx := context.Background()
+
+	require.NoError(t, d.Put(ctx, "balance:a", int64(100), 0))
+	require.NoError(t, d.Put(ctx, "balance:b", int64(0), 0))
+
+	err := d.Transaction(ctx, func(tx cache.Store) error {
+		if _, err := tx.Decrement(ctx, "balance:a", 30); err != nil {
+			return err
+		}
+		n, err := tx.Increment(ctx, "balance:b", 30)
+		if err != nil {
+			return err
+		}
+		assert.Equal(t, int64(30), n)
+
+		// Staged writes are visible inside but not outside the transaction
+		val, _ := tx.Get(ctx, "balance:a")
+		assert.Equal(t, int64(70), val)
+		val, _ = d.Get(ctx, "balance:a")
+		assert.Equal(t, int64(100), val)
+		return nil
+	})
+	require.NoError(t, err)
+
+	val, _ := d.Get(ctx, "balance:a")
+	assert.Equal(t, int64(70), val)
+	val, _ = d.Get(ctx, "balance:b")
+	assert.Equal(t, int64(30), val)
+}
+
+func TestDriver_TransactionConcurrentIncrements(t *testing.T) {
+	d := newTestDriver(t, map[string]interface{}{"max_items": 10})
+	ctx := context.Background()
+
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.NoError(t, d.Transaction(ctx, func(tx cache.Store) error {
+				_, err := tx.Increment(ctx, "counter", 1)
+				return err
+			}))
+		}()
+	}
+	wg.Wait()
+
+	// No increment is lost, and the counter created by the first commit is
+	// tracked for eviction like any other key
+	val, err := d.Get(ctx, "counter")
+	require.NoError(t, err)
+	assert.Equal(t, int64(50), val)
+	assert.Contains(t, d.nodes, "counter")
+}
+
+func TestDriver_TransactionIncrementKeepsTTL(t *testing.T) {
+	d := newTestDriver(t, nil)
+	ctx := context.Background()
+
+	n, err := d.IncrementWithTTL(ctx, "hits", 1, time.Minute)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), n)
+	before := *d.items["hits"]
+
+	require.NoError(t, d.Transaction(ctx, func(tx cache.Store) error {
+		_, err := tx.Increment(ctx, "hits", 2)
+		return err
+	}))
+
+	after := d.items["hits"]
+	assert.Equal(t, int64(3), d.readValue(after.Value))
+	assert.Equal(t, before.ExpiresAt, after.ExpiresAt)
+	assert.Equal(t, before.CreatedAt, after.CreatedAt)
+
+	// A counter that can no longer take the delta aborts the whole commit
+	require.NoError(t, d.Put(ctx, "other", int64(1), 0))
+	err = d.Transaction(ctx, func(tx cache.Store) error {
+		require.NoError(t, tx.Put(ctx, "other", int64(2), 0))
+		_, err := tx.Increment(ctx, "hits", 1)
+		require.NoError(t, err)
+		// A concurrent writer replaces the counter before the commit
+		require.NoError(t, d.Put(ctx, "hits", "reset", 0))
+		return nil
+	})
+	assert.ErrorIs(t, err, dgcache.ErrNotANumber)
+	val, _ := d.Get(ctx, "other")
+	assert.Equal(t, int64(1), val)
+}
+
+func TestDriver_TransactionRollback(t *testing.T) {
+	d := newTestDriver(t, nil)
+	ctx := context.Background()
+
+	require.NoError(t, d.Put(ctx, "keep", "original", 0))
+
+	errAbort := errors.New("abort")
+	err := d.Transaction(ctx, func(tx cache.Store) error {
+		require.NoError(t, tx.Put(ctx, "new", "value", time.Minute))
+		require.NoError(t, tx.Put(ctx, "keep", "changed", 0))
+		require.NoError(t, tx.Forget(ctx, "keep"))
+		_, err := tx.Increment(ctx, "counter", 1)
+		require.NoError(t, err)
+		require.NoError(t, tx.Flush(ctx))
+		return errAbort
+	})
+	assert.ErrorIs(t, err, errAbort)
+
+	val, err := d.Get(ctx, "keep")
+	require.NoError(t, err)
+	assert.Equal(t, "original", val)
+	has, _ := d.Has(ctx, "new")
+	assert.False(t, has)
+	has, _ = d.Has(ctx, "counter")
+	assert.False(t, has)
+}
+
+func TestDriver_LRUTrackingOnlyWithLimits(t *testing.T) {
+	ctx := context.Background()
+
+	unlimited := newTestDriver(t, nil)
+	require.NoError(t, unlimited.Put(ctx, "key", "value", 0))
+	_, _ = unlimited.Get(ctx, "key")
+	assert.Empty(t, unlimited.nodes)
+	assert.Equal(t, 0, unlimited.lru.len())
+
+	// Eviction still follows access order when a limit is set
+	limited := newTestDriver(t, map[string]interface{}{"max_items": 2})
+	require.NoError(t, limited.Put(ctx, "a", 1, 0))
+	require.NoError(t, limited.Put(ctx, "b", 2, 0))
+	_, _ = limited.Get(ctx, "a")
+	require.NoError(t, limited.Put(ctx, "c", 3, 0))
+
+	has, _ := limited.Has(ctx, "a")
+	assert.True(t, has)
+	has, _ = limited.Has(ctx, "b")
+	assert.False(t, has)
+}
+
+func TestDriver_GetKeepsLRUOrder(t *testing.T) {
+	ctx := context.Background()
+	d := newTestDriver(t, map[string]interface{}{"max_items": 3})
+
+	require.NoError(t, d.Put(ctx, "a", 1, 0))
+	require.NoError(t, d.Put(ctx, "b", 2, 0))
+	require.NoError(t, d.Put(ctx, "c", 3, 0))
+
+	// Reads only buffer their promotions; eviction must still see them,
+	// so "c" is least recently used and "b" is next
+	for _, key := range []string{"b", "a", "a"} {
+		_, err := d.Get(ctx, key)
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, d.Put(ctx, "d", 4, 0))
+	has, _ := d.Has(ctx, "c")
+	assert.False(t, has)
+
+	require.NoError(t, d.Put(ctx, "e", 5, 0))
+	has, _ = d.Has(ctx, "b")
+	assert.False(t, has)
+
+	for _, key := range []string{"a", "d", "e"} {
+		has, _ = d.Has(ctx, key)
+		assert.True(t, has, key)
+	}
+}
+
+func TestDriver_GetAppliesFullAccessBuffer(t *testing.T) {
+	ctx := context.Background()
+	d := newTestDriver(t, map[string]interface{}{"max_items": 10})
+
+	require.NoError(t, d.Put(ctx, "a", 1, 0))
+	require.NoError(t, d.Put(ctx, "b", 2, 0))
+
+	for i := 0; i < accessBufferSize; i++ {
+		_, err := d.Get(ctx, "a")
+		require.NoError(t, err)
+	}
+
+	// The full buffer was applied without waiting for a write
+	assert.Equal(t, "a", d.lru.head.key)
+	assert.Empty(t, d.accesses)
+}
+
+func TestDriver_KeysForTag(t *testing.T) {
+	d := newTestDriver(t, nil)
+	d.SetPrefix("app")
+	ctx := context.Background()
+
+	require.NoError(t, d.Tags("users").Put(ctx, "user:2", "jane", 0))
+	require.NoError(t, d.Tags("users", "admins").Put(ctx, "user:1", "john", 0))
+	require.NoError(t, d.Put(ctx, "other", "value", 0))
+
+	keys, err := d.KeysForTag(ctx, "users")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user:1", "user:2"}, keys)
+
+	keys, err = d.KeysForTag(ctx, "missing")
+	require.NoError(t, err)
+	assert.Empty(t, keys)
+
+	require.NoError(t, d.FlushTags(ctx, "admins"))
+	keys, _ = d.KeysForTag(ctx, "users")
+	assert.Equal(t, []string{"user:2"}, keys)
+}
+
+func TestDriver_ReturnCopies(t *testing.T) {
+	ctx := context.Background()
+
+	type profile struct {
+		Name  string
+		Roles []string
+	}
+
+	t.Run("enabled", func(t *testing.T) {
+		d := newTestDriver(t, map[string]interface{}{"return_copies": true})
+
+		require.NoError(t, d.Put(ctx, "slice", []int{1, 2, 3}, time.Minute))
+		require.NoError(t, d.Put(ctx, "map", map[string]int{"a": 1}, time.Minute))
+		require.NoError(t, d.Put(ctx, "struct", &profile{Name: "john", Roles: []string{"admin"}}, time.Minute))
+
+		val, err := d.Get(ctx, "slice")
+		require.NoError(t, err)
+		val.([]int)[0] = 100
+
+		val, err = d.Get(ctx, "map")
+		require.NoError(t, err)
+		val.(map[string]int)["a"] = 100
+
+		val, err = d.Get(ctx, "struct")
+		require.NoError(t, err)
+		val.(*profile).Roles[0] = "guest"
+
+		values, err := d.GetMultiple(ctx, []string{"slice", "map", "struct"})
+		require.NoError(t, err)
+		values["slice"].([]int)[1] = 200
+
+		val, err = d.Get(ctx, "slice")
+		require.NoError(t, err)
+		assert.Equal(t, []int{1, 2, 3}, val)
+		val, err = d.Get(ctx, "map")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"a": 1}, val)
+		val, err = d.Get(ctx, "struct")
+		require.NoError(t, err)
+		assert.Equal(t, &profile{Name: "john", Roles: []string{"admin"}}, val)
+	})
+
+	t.Run("disabled shares the cached value", func(t *testing.T) {
+		d := newTestDriver(t, nil)
+
+		require.NoError(t, d.Put(ctx, "slice", []int{1, 2, 3}, time.Minute))
+		val, err := d.Get(ctx, "slice")
+		require.NoError(t, err)
+		val.([]int)[0] = 100
+
+		val, err = d.Get(ctx, "slice")
+		require.NoError(t, err)
+		assert.Equal(t, []int{100, 2, 3}, val)
+	})
+}
+
+func TestCopyValue_Cycle(t *testing.T) {
+	type node struct {
+		Next *node
+	}
+	n := &node{}
+	n.Next = n
+
+	c := copyValue(n).(*node)
+	assert.NotSame(t, n, c)
+	assert.Same(t, c, c.Next)
+}
+
+func TestDriver_HasMultiple(t *testing.T) {
+	d := newTestDriver(t, nil)
+	ctx := context.Background()
+
+	require.NoError(t, d.Put(ctx, "a", 1, 0))
+	require.NoError(t, d.Put(ctx, "b", 2, 10*time.Millisecond))
+	time.Sleep(20 * time.Millisecond)
+
+	result, err := d.HasMultiple(ctx, []string{"a", "b", "c"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"a": true, "b": false, "c": false}, result)
+}
+
+func TestDriver_Hash(t *testing.T) {
+	d := newTestDriver(t, nil)
+	ctx := context.Background()
+
+	require.NoError(t, d.HSet(ctx, "user:1", "name", "john"))
+	require.NoError(t, d.HSet(ctx, "user:1", "age", 30))
+
+	val, err := d.HGet(ctx, "user:1", "name")
+	require.NoError(t, err)
+	assert.Equal(t, "john", val)
+
+	_, err = d.HGet(ctx, "user:1", "missing")
+	assert.ErrorIs(t, err, dgcache.ErrKeyNotFound)
+	_, err = d.HGet(ctx, "user:2", "name")
+	assert.ErrorIs(t, err, dgcache.ErrKeyNotFound)
+
+	all, err := d.HGetAll(ctx, "user:1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"name": "john", "age": 30}, all)
+
+	// The result is a copy
+	all["name"] = "jane"
+	val, _ = d.HGet(ctx, "user:1", "name")
+	assert.Equal(t, "john", val)
+
+	all, err = d.HGetAll(ctx, "user:2")
+	require.NoError(t, err)
+	assert.Empty(t, all)
+
+	// Removing the last field removes the hash
+	require.NoError(t, d.HDel(ctx, "user:1", "name", "age"))
+	has, _ := d.Has(ctx, "user:1")
+	assert.False(t, has)
+
+	// Plain values are not hashes
+	require.NoError(t, d.Put(ctx, "plain", "value", 0))
+	assert.ErrorIs(t, d.HSet(ctx, "plain", "field", 1), dgcache.ErrWrongType)
+}
+
+func TestDriver_HashBookkeeping(t *testing.T) {
+	d := newTestDriver(t, map[string]interface{}{
+		"enable_metrics": true,
+		"max_items":      2,
+		"serializer":     "json",
+	})
+	ctx := context.Background()
+
+	type address struct {
+		City string `json:"city"`
+	}
+	require.NoError(t, d.HSet(ctx, "user:1", "address", address{City: "Jakarta"}))
+	require.NoError(t, d.HSet(ctx, "user:1", "name", "john"))
+
+	// Fields are encoded like values written with Put
+	val, err := d.HGet(ctx, "user:1", "address")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"city": "Jakarta"}, val)
+
+	// Get returns a copy of the hash, which concurrent HSets don't touch
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for i := range 100 {
+			assert.NoError(t, d.HSet(ctx, "user:1", fmt.Sprint("field", i), i))
+		}
+	}()
+	for range 100 {
+		all, err := d.Get(ctx, "user:1")
+		require.NoError(t, err)
+		for range all.(map[string]interface{}) {
+		}
+	}
+	wg.Wait()
+
+	// HSet is a write: it is counted and takes part in eviction
+	assert.Contains(t, d.nodes, "user:1")
+	assert.Equal(t, int64(102), d.Stats().Sets)
+	require.NoError(t, d.Put(ctx, "a", 1, 0))
+	require.NoError(t, d.Put(ctx, "b", 2, 0))
+	assert.Len(t, d.items, 2)
+	has, _ := d.Has(ctx, "user:1")
+	assert.False(t, has)
+}
+
+func TestDriver_Expirations(t *testing.T) {
+	d := newTestDriver(t, map[string]interface{}{
+		"enable_metrics": true,
+		"max_items":      10,
+	})
+	ctx := context.Background()
+
+	for _, key := range []string{"a", "b", "c"} {
+		require.NoError(t, d.Put(ctx, key, "value", time.Millisecond))
+	}
+	require.NoError(t, d.Put(ctx, "kept", "value", time.Minute))
+	time.Sleep(5 * time.Millisecond)
+	d.removeExpired()
+	assert.Equal(t, int64(3), d.Expirations())
+
+	// Overwriting an expired item the cleanup has not reached also counts
+	require.NoError(t, d.Put(ctx, "d", "value", time.Millisecond))
+	time.Sleep(5 * time.Millisecond)
+	require.NoError(t, d.Put(ctx, "d", "fresh", time.Minute))
+
+	assert.Equal(t, int64(4), d.Expirations())
+	stats := d.Stats()
+	assert.Equal(t, int64(0), stats.Evictions)
+	assert.Equal(t, 2, stats.ItemCount)
+}
+
+func TestDriver_IncrementNonNumeric(t *testing.T) {
+	d := newTestDriver(t, nil)
+	ctx := context.Background()
+
+	require.NoError(t, d.Put(ctx, "name", "john", time.Minute))
+
+	_, err := d.Increment(ctx, "name", 1)
+	assert.ErrorIs(t, err, dgcache.ErrNotANumber)
+	_, err = d.IncrementWithTTL(ctx, "name", 1, time.Minute)
+	assert.ErrorIs(t, err, dgcache.ErrNotANumber)
+
+	val, err := d.Get(ctx, "name")
+	require.NoError(t, err)
+	assert.Equal(t, "john", val)
+
+	// Integers of any width and integer strings count, as in Redis
+	require.NoError(t, d.Put(ctx, "count", 41, time.Minute))
+	n, err := d.Increment(ctx, "count", 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), n)
+
+	require.NoError(t, d.Put(ctx, "text", "9", time.Minute))
+	n, err = d.Increment(ctx, "text", 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(10), n)
+}
+
+func TestDriver_GetMultipleWithTTL(t *testing.T) {
+	d := newTestDriver(t, nil)
+	ctx := context.Background()
+
+	require.NoError(t, d.Put(ctx, "short", "a", time.Minute))
+	require.NoError(t, d.Put(ctx, "long", "b", time.Hour))
+	require.NoError(t, d.Forever(ctx, "forever", "c"))
+
+	values, err := d.GetMultipleWithTTL(ctx, []string{"short", "long", "forever", "missing"})
+	require.NoError(t, err)
+	require.Len(t, values, 3)
+
+	assert.Equal(t, "a", values["short"].Value)
+	assert.InDelta(t, time.Minute, values["short"].TTL, float64(time.Second))
+	assert.InDelta(t, time.Hour, values["long"].TTL, float64(time.Second))
+	assert.Equal(t, "c", values["forever"].Value)
+	assert.Equal(t, dgcache.NoExpiry, values["forever"].TTL)
+}
+
+func TestDriver_SwapAll(t *testing.T) {
+	d := newTestDriver(t, nil)
+	ctx := context.Background()
+
+	dataset := func(prefix string) (map[string]interface{}, []string) {
+		items := make(map[string]interface{}, 50)
+		keys := make([]string, 0, 50)
+		for i := 0; i < 50; i++ {
+			key := fmt.Sprintf("%s:%d", prefix, i)
+			items[key] = i
+			keys = append(keys, key)
+		}
+		return items, keys
+	}
+	oldItems, oldKeys := dataset("old")
+	newItems, newKeys := dataset("new")
+	require.NoError(t, d.PutMultiple(ctx, oldItems, time.Minute))
+	require.NoError(t, d.Tags("lookup").Put(ctx, "tagged", 1, time.Minute))
+
+	// Readers see one full dataset at every point of the swap
+	allKeys := append(append([]string{}, oldKeys...), newKeys...)
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-stop:
+				return
+			default:
+			}
+			values, err := d.GetMultiple(ctx, allKeys)
+			assert.NoError(t, err)
+			if len(values) != 50 {
+				t.Errorf("reader saw %d keys", len(values))
+				return
+			}
+		}
+	}()
+
+	require.NoError(t, d.SwapAll(ctx, newItems, time.Minute))
+	close(stop)
+	<-done
+
+	values, err := d.GetMultiple(ctx, allKeys)
+	require.NoError(t, err)
+	assert.Len(t, values, 50)
+	for _, key := range newKeys {
+		assert.Contains(t, values, key)
+	}
+	has, err := d.Has(ctx, "tagged")
+	require.NoError(t, err)
+	assert.False(t, has)
+	assert.Empty(t, d.TagSizes())
+}
+
+func TestDriver_CloseStopsCleanup(t *testing.T) {
+	defer goleak.VerifyNone(t, goleak.IgnoreCurrent())
+
+	for i := 0; i < 100; i++ {
+		d, err := NewDriver(dgcache.StoreConfig{
+			Driver:  "memory",
+			Options: map[string]interface{}{"cleanup_interval": time.Millisecond},
+		})
+		require.NoError(t, err)
+		require.NoError(t, d.Put(context.Background(), "key", "value", time.Millisecond))
+		require.NoError(t, d.Close())
+	}
+}
+
+func TestDriver_SlidingTTL(t *testing.T) {
+	d := newTestDriver(t, map[string]interface{}{"sliding_ttl": true})
+	ctx := context.Background()
+
+	require.NoError(t, d.Put(ctx, "read", "value", 100*time.Millisecond))
+	require.NoError(t, d.Put(ctx, "untouched", "value", 100*time.Millisecond))
+
+	// Reading keeps the key alive well past its original expiry
+	for i := 0; i < 6; i++ {
+		time.Sleep(40 * time.Millisecond)
+		_, err := d.Get(ctx, "read")
+		require.NoError(t, err)
+	}
+
+	_, err := d.Get(ctx, "untouched")
+	assert.ErrorIs(t, err, dgcache.ErrKeyNotFound)
+
+	// Without reads it expires after the TTL
+	time.Sleep(150 * time.Millisecond)
+	_, err = d.Get(ctx, "read")
+	assert.ErrorIs(t, err, dgcache.ErrKeyNotFound)
+}
+
+func TestDriver_SlidingTTLMaxLifetime(t *testing.T) {
+	d := newTestDriver(t, map[string]interface{}{
+		"sliding_ttl":              true,
+		"sliding_ttl_max_lifetime": 150 * time.Millisecond,
+	})
+	ctx := context.Background()
+
+	require.NoError(t, d.Put(ctx, "key", "value", 100*time.Millisecond))
+	created := d.items["key"].CreatedAt
+
+	for i := 0; i < 3; i++ {
+		time.Sleep(40 * time.Millisecond)
+		_, err := d.Get(ctx, "key")
+		require.NoError(t, err)
+	}
+
+	// The expiry never moves past the max lifetime
+	d.mu.RLock()
+	expiresAt := d.items["key"].ExpiresAt
+	d.mu.RUnlock()
+	assert.Equal(t, created.Add(150*time.Millisecond), expiresAt)
+
+	// Persistent keys do not slide
+	require.NoError(t, d.Forever(ctx, "forever", "value"))
+	_, err := d.Get(ctx, "forever")
+	require.NoError(t, err)
+	assert.True(t, d.items["forever"].ExpiresAt.IsZero())
+}
+
+func TestDriver_GetWithMeta(t *testing.T) {
+	d := newTestDriver(t, map[string]interface{}{"enable_metrics": true})
+	ctx := context.Background()
+
+	before := time.Now()
+	require.NoError(t, d.Tags("users", "admins").Put(ctx, "user:1", "john", time.Minute))
+	require.NoError(t, d.Forever(ctx, "config", "value"))
+
+	value, meta, err := d.GetWithMeta(ctx, "user:1")
+	require.NoError(t, err)
+	assert.Equal(t, "john", value)
+	assert.InDelta(t, time.Minute, meta.TTL, float64(time.Second))
+	assert.Equal(t, []string{"admins", "users"}, meta.Tags)
+	assert.False(t, meta.CreatedAt.Before(before))
+	assert.False(t, meta.ExpiresWithin(30*time.Second))
+	assert.True(t, meta.ExpiresWithin(2*time.Minute))
+
+	_, meta, err = d.GetWithMeta(ctx, "config")
+	require.NoError(t, err)
+	assert.Equal(t, dgcache.NoExpiry, meta.TTL)
+	assert.Nil(t, meta.Tags)
+	assert.False(t, meta.ExpiresWithin(time.Hour))
+
+	_, _, err = d.GetWithMeta(ctx, "missing")
+	assert.ErrorIs(t, err, dgcache.ErrKeyNotFound)
+	assert.Equal(t, int64(2), d.Stats().Hits)
+	assert.Equal(t, int64(1), d.Stats().Misses)
+}
+
+func TestDriver_GetItem(t *testing.T) {
+	d := newTestDriver(t, nil)
+	ctx := context.Background()
+
+	before := time.Now()
+	require.NoError(t, d.Tags("users").Put(ctx, "user:1", "john", time.Minute))
+
+	item, err := d.GetItem(ctx, "user:1")
+	require.NoError(t, err)
+	assert.Equal(t, "user:1", item.Key)
+	assert.Equal(t, "john", item.Value)
+	assert.WithinDuration(t, before.Add(time.Minute), item.ExpiresAt, time.Second)
+	assert.Equal(t, []string{"users"}, item.Tags)
+
+	// The item is a copy
+	item.Value = "changed"
+	item.ExpiresAt = time.Time{}
+	item.Tags[0] = "changed"
+	again, err := d.GetItem(ctx, "user:1")
+	require.NoError(t, err)
+	assert.Equal(t, "john", again.Value)
+	assert.False(t, again.ExpiresAt.IsZero())
+	assert.Equal(t, []string{"users"}, again.Tags)
+	keys, err := d.KeysForTag(ctx, "users")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user:1"}, keys)
+
+	require.NoError(t, d.Forever(ctx, "config", "value"))
+	item, err = d.GetItem(ctx, "config")
+	require.NoError(t, err)
+	assert.True(t, item.ExpiresAt.IsZero())
+
+	_, err = d.GetItem(ctx, "missing")
+	assert.ErrorIs(t, err, dgcache.ErrKeyNotFound)
+}
+
+func TestDriver_Serializer(t *testing.T) {
+	type user struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+	ctx := context.Background()
+
+	t.Run("json reads structs back as maps", func(t *testing.T) {
+		d := newTestDriver(t, map[string]interface{}{"serializer": "json"})
+		assert.Equal(t, "json", d.Info().Serializer)
+
+		require.NoError(t, d.Put(ctx, "user:1", user{Name: "john", Age: 30}, time.Minute))
+		val, err := d.Get(ctx, "user:1")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]interface{}{"name": "john", "age": float64(30)}, val)
+
+		// Every read decodes a fresh value
+		val.(map[string]interface{})["name"] = "changed"
+		again, err := d.Get(ctx, "user:1")
+		require.NoError(t, err)
+		assert.Equal(t, "john", again.(map[string]interface{})["name"])
+
+		values, err := d.GetMultiple(ctx, []string{"user:1"})
+		require.NoError(t, err)
+		assert.IsType(t, map[string]interface{}{}, values["user:1"])
+
+		// Remember returns the same type on a miss as on a hit
+		normalized, err := d.Normalize(user{Name: "jane"})
+		require.NoError(t, err)
+		assert.IsType(t, map[string]interface{}{}, normalized)
+	})
+
+	t.Run("counters", func(t *testing.T) {
+		d := newTestDriver(t, map[string]interface{}{"serializer": "msgpack"})
+
+		require.NoError(t, d.Put(ctx, "count", 5, time.Minute))
+		n, err := d.Increment(ctx, "count", 2)
+		require.NoError(t, err)
+		assert.Equal(t, int64(7), n)
+		val, err := d.Get(ctx, "count")
+		require.NoError(t, err)
+		assert.EqualValues(t, 7, val)
+	})
+
+	t.Run("unserializable values are rejected", func(t *testing.T) {
+		d := newTestDriver(t, map[string]interface{}{"serializer": "json"})
+
+		err := d.Put(ctx, "key", map[string]interface{}{"ch": make(chan int)}, time.Minute)
+		assert.ErrorIs(t, err, dgcache.ErrInvalidValue)
+		has, err := d.Has(ctx, "key")
+		require.NoError(t, err)
+		assert.False(t, has)
+	})
+
+	t.Run("unknown serializer", func(t *testing.T) {
+		_, err := NewDriver(dgcache.StoreConfig{
+			Driver:  "memory",
+			Options: map[string]interface{}{"serializer": "xml"},
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestDriver_ValidateSerializable(t *testing.T) {
+	type withChannel struct {
+		Name    string
+		Updates chan int
+	}
+	ctx := context.Background()
+
+	t.Run("rejects unserializable values", func(t *testing.T) {
+		d := newTestDriver(t, map[string]interface{}{"validate_serializable": true})
+
+		err := d.Put(ctx, "key", withChannel{Name: "john", Updates: make(chan int)}, time.Minute)
+		assert.ErrorIs(t, err, dgcache.ErrInvalidValue)
+		assert.Contains(t, err.Error(), "json")
+		has, _ := d.Has(ctx, "key")
+		assert.False(t, has)
+
+		err = d.PutMultiple(ctx, map[string]interface{}{"ok": "value", "fn": func() {}}, time.Minute)
+		assert.ErrorIs(t, err, dgcache.ErrInvalidValue)
+		has, _ = d.Has(ctx, "ok")
+		assert.False(t, has)
+
+		require.NoError(t, d.Put(ctx, "key", map[string]interface{}{"name": "john"}, time.Minute))
+	})
+
+	t.Run("msgpack", func(t *testing.T) {
+		d := newTestDriver(t, map[string]interface{}{"validate_serializable": true, "validate_serializer": "msgpack"})
+		err := d.Put(ctx, "key", withChannel{Updates: make(chan int)}, time.Minute)
+		assert.ErrorIs(t, err, dgcache.ErrInvalidValue)
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		d := newTestDriver(t, nil)
+		require.NoError(t, d.Put(ctx, "key", withChannel{Updates: make(chan int)}, time.Minute))
+	})
+}
+
+func TestDriver_LowercaseKeys(t *testing.T) {
+	ctx := context.Background()
+
+	for _, option := range []string{"lowercase_keys", "normalize_case"} {
+		t.Run(option, func(t *testing.T) {
+			d := newTestDriver(t, map[string]interface{}{option: true})
+
+			require.NoError(t, d.Put(ctx, "User:1", "john", time.Minute))
+			val, err := d.Get(ctx, "user:1")
+			require.NoError(t, err)
+			assert.Equal(t, "john", val)
+			has, err := d.Has(ctx, "USER:1")
+			require.NoError(t, err)
+			assert.True(t, has)
+
+			require.NoError(t, d.Forget(ctx, "uSeR:1"))
+			_, err = d.Get(ctx, "User:1")
+			assert.ErrorIs(t, err, dgcache.ErrKeyNotFound)
+		})
+	}
+
+	// Keys stay case-sensitive by default
+	d := newTestDriver(t, nil)
+	require.NoError(t, d.Put(ctx, "User:1", "john", time.Minute))
+	_, err := d.Get(ctx, "user:1")
+	assert.ErrorIs(t, err, dgcache.ErrKeyNotFound)
+}
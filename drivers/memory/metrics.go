@@ -17,6 +17,9 @@ type Metrics struct {
 	deletes   int64
 	evictions int64
 
+	// expirations counts items removed because their TTL passed
+	expirations int64
+
 	// Size tracking
 	itemCount int
 	bytesUsed int64
@@ -76,6 +79,22 @@ func (m *Metrics) RecordEviction(bytes int64) {
 	m.itemCount--
 }
 
+// RecordExpiration increments the expiration counter and updates size tracking.
+func (m *Metrics) RecordExpiration(bytes int64) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.expirations++
+	m.bytesUsed -= bytes
+	m.itemCount--
+}
+
+// Expirations returns the number of items removed on expiry.
+func (m *Metrics) Expirations() int64 {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.expirations
+}
+
 // Stats returns a snapshot of current cache statistics.
 func (m *Metrics) Stats() cache.Stats {
 	m.mu.RLock()
@@ -109,6 +128,7 @@ func (m *Metrics) Reset() {
 	m.sets = 0
 	m.deletes = 0
 	m.evictions = 0
+	m.expirations = 0
 	m.itemCount = 0
 	m.bytesUsed = 0
 }
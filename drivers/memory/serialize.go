@@ -0,0 +1,59 @@
+package memory
+
+import (
+	"fmt"
+
+	dgcache "github.com/donnigundala/dg-cache"
+	"github.com/donnigundala/dg-cache/serializer"
+)
+
+// encodedValue is a value stored encoded with Config.Serializer.
+type encodedValue []byte
+
+// namedSerializer returns the serializer named by a store option, as the
+// Redis driver names them: "json" or "msgpack", both with the type envelope.
+func namedSerializer(option string, name interface{}) (serializer.Serializer, error) {
+	switch name {
+	case "json":
+		return serializer.NewJSONSerializer(), nil
+	case "msgpack":
+		return serializer.NewMsgpackSerializer(), nil
+	default:
+		return nil, dgcache.ErrInvalidConfig("unknown %s '%v'", option, name)
+	}
+}
+
+// encode returns value as it is stored: encoded with Serializer if one is
+// set, or else unchanged. A value that fails to encode is rejected like
+// ValidateSerializable rejects it.
+func (d *Driver) encode(key string, value interface{}) (interface{}, error) {
+	ser := d.config.Serializer
+	if ser == nil {
+		return value, nil
+	}
+	data, err := ser.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q cannot be serialized with %s: %w", dgcache.ErrInvalidValue, key, ser.Name(), err)
+	}
+	return encodedValue(data), nil
+}
+
+// decode returns a fresh copy of an encoded value. Like the Redis driver, it
+// falls back to the raw string if the data does not decode.
+func (d *Driver) decode(data encodedValue) interface{} {
+	var value interface{}
+	if err := d.config.Serializer.Unmarshal(data, &value); err != nil {
+		return string(data)
+	}
+	return value
+}
+
+// Normalize returns value as Get would read it back, so Remember yields the
+// same type on a miss as on a later hit. Without Serializer it is unchanged.
+func (d *Driver) Normalize(value interface{}) (interface{}, error) {
+	encoded, err := d.encode("", value)
+	if err != nil {
+		return nil, err
+	}
+	return d.readValue(encoded), nil
+}
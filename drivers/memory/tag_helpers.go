@@ -1,5 +1,12 @@
 package memory
 
+import (
+	"fmt"
+	"log/slog"
+
+	dgcache "github.com/donnigundala/dg-cache"
+)
+
 // removeKeyTags removes tag associations for a key.
 // Caller must hold the lock.
 func (d *Driver) removeKeyTags(key string) {
@@ -8,14 +15,83 @@ func (d *Driver) removeKeyTags(key string) {
 			if keys, ok := d.tags[tag]; ok {
 				delete(keys, key)
 				if len(keys) == 0 {
-					delete(d.tags, tag)
+					d.deleteTag(tag)
 				}
 			}
 		}
 		delete(d.keyTags, key)
+		d.syncItemTags(key)
+	}
+}
+
+// syncItemTags copies the tags of key from the index to its item, so
+// Item.Tags always reflects them. keyTags slices are never modified in place,
+// so the item can share them.
+// Caller must hold the lock.
+func (d *Driver) syncItemTags(key string) {
+	if item, ok := d.items[key]; ok {
+		item.Tags = d.keyTags[key]
 	}
 }
 
+// deleteTag drops an empty tag from the index, so single-use tags do not
+// accumulate once their keys are gone.
+// Caller must hold the lock.
+func (d *Driver) deleteTag(tag string) {
+	delete(d.tags, tag)
+	delete(d.tagLimitWarned, tag)
+}
+
+// addKeyTag associates a key with one more tag, keeping its existing tags.
+// Caller must hold the lock.
+func (d *Driver) addKeyTag(key, tag string) {
+	if _, ok := d.tags[tag][key]; ok {
+		return
+	}
+
+	if _, ok := d.tags[tag]; !ok {
+		d.tags[tag] = make(map[string]struct{})
+	}
+	d.tags[tag][key] = struct{}{}
+
+	// Copy rather than append: the slice may be shared with a taggedCache
+	existing := d.keyTags[key]
+	tags := make([]string, len(existing), len(existing)+1)
+	copy(tags, existing)
+	d.keyTags[key] = append(tags, tag)
+	d.syncItemTags(key)
+}
+
+// removeKeyTag removes a single tag association for a key, keeping its other tags.
+// Caller must hold the lock.
+func (d *Driver) removeKeyTag(key, tag string) {
+	keys, ok := d.tags[tag]
+	if !ok {
+		return
+	}
+	if _, ok := keys[key]; !ok {
+		return
+	}
+	delete(keys, key)
+	if len(keys) == 0 {
+		d.deleteTag(tag)
+	}
+
+	// Build a new slice: the existing one may be shared with a taggedCache
+	remaining := make([]string, 0, len(d.keyTags[key]))
+	for _, t := range d.keyTags[key] {
+		if t != tag {
+			remaining = append(remaining, t)
+		}
+	}
+	if len(remaining) == 0 {
+		delete(d.keyTags, key)
+	} else {
+		d.keyTags[key] = remaining
+	}
+	d.syncItemTags(key)
+}
+
 // addKeyTags adds tag associations for a key.
 // Caller must hold the lock.
 func (d *Driver) addKeyTags(key string, tags []string) {
@@ -33,4 +109,32 @@ func (d *Driver) addKeyTags(key string, tags []string) {
 		}
 		d.tags[tag][key] = struct{}{}
 	}
+	d.syncItemTags(key)
+}
+
+// checkTagLimit enforces MaxKeysPerTag before key is associated with tags.
+// Under the "reject" policy it returns ErrTagLimitExceeded for the first tag
+// that is full; otherwise it logs a warning the first time a tag overflows.
+// Caller must hold the lock.
+func (d *Driver) checkTagLimit(key string, tags []string) error {
+	if d.config.MaxKeysPerTag <= 0 {
+		return nil
+	}
+
+	for _, tag := range tags {
+		keys := d.tags[tag]
+		if _, ok := keys[key]; ok || len(keys) < d.config.MaxKeysPerTag {
+			continue
+		}
+
+		if d.config.TagLimitPolicy == "reject" {
+			return fmt.Errorf("%w: tag '%s' already has %d keys", dgcache.ErrTagLimitExceeded, tag, len(keys))
+		}
+		if _, warned := d.tagLimitWarned[tag]; !warned {
+			d.tagLimitWarned[tag] = struct{}{}
+			slog.Warn("cache: tag exceeds max_keys_per_tag",
+				"tag", tag, "keys", len(keys), "max_keys_per_tag", d.config.MaxKeysPerTag)
+		}
+	}
+	return nil
 }
@@ -2,6 +2,7 @@ package memory
 
 import (
 	"context"
+	"sort"
 	"time"
 
 	cache "github.com/donnigundala/dg-core/contracts/cache"
@@ -31,9 +32,14 @@ func (t *taggedCache) Tags(tags ...string) cache.TaggedStore {
 
 // Put stores a value in the cache with tags.
 func (t *taggedCache) Put(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	defer t.Driver.enforceBudget()
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
+	if err := t.Driver.checkTagLimit(t.Driver.prefixKey(key), t.tags); err != nil {
+		return err
+	}
+
 	err := t.Driver.put(key, value, ttl)
 	if err != nil {
 		return err
@@ -45,6 +51,7 @@ func (t *taggedCache) Put(ctx context.Context, key string, value interface{}, tt
 
 // PutMultiple stores multiple values in the cache with tags.
 func (t *taggedCache) PutMultiple(ctx context.Context, items map[string]interface{}, ttl time.Duration) error {
+	defer t.Driver.enforceBudget()
 	t.mu.Lock()
 	defer t.mu.Unlock()
 
@@ -59,6 +66,9 @@ func (t *taggedCache) PutMultiple(ctx context.Context, items map[string]interfac
 		// Or replicate PutMultiple logic to avoid overhead?
 		// Replicating logic for batch efficiency (avoiding repeated eviction checks/metrics update if possible, but internal Put handles it)
 		// For simplicity/correctness, let's just reuse D.put if we don't have putMultiple
+		if err := t.Driver.checkTagLimit(t.Driver.prefixKey(key), t.tags); err != nil {
+			return err
+		}
 		err := t.Driver.put(key, value, ttl)
 		if err != nil {
 			return err
@@ -80,6 +90,66 @@ func (t *taggedCache) Flush(ctx context.Context) error {
 	return t.Driver.FlushTags(ctx, t.tags...)
 }
 
+// KeysForTag returns the keys associated with tag, excluding expired items.
+func (d *Driver) KeysForTag(ctx context.Context, tag string) ([]string, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	keys := make([]string, 0, len(d.tags[tag]))
+	for prefixedKey := range d.tags[tag] {
+		if item, ok := d.items[prefixedKey]; ok && !item.IsExpired() {
+			keys = append(keys, item.Key)
+		}
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// TagExisting associates the existing keys with tag, keeping their other tags.
+// Absent and expired keys are skipped. Under the "reject" tag limit policy it
+// stops at the first key that would exceed MaxKeysPerTag.
+func (d *Driver) TagExisting(ctx context.Context, tag string, keys ...string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, key := range keys {
+		prefixedKey := d.prefixKey(key)
+		if item, ok := d.items[prefixedKey]; ok && !item.IsExpired() {
+			if err := d.checkTagLimit(prefixedKey, []string{tag}); err != nil {
+				return err
+			}
+			d.addKeyTag(prefixedKey, tag)
+		}
+	}
+	return nil
+}
+
+// Untag removes key from the given tags without deleting it.
+func (d *Driver) Untag(ctx context.Context, key string, tags ...string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	prefixedKey := d.prefixKey(key)
+	for _, tag := range tags {
+		d.removeKeyTag(prefixedKey, tag)
+	}
+	return nil
+}
+
+// TagSizes returns the number of keys currently associated with each tag, for
+// spotting tags that grow without bound. Expired keys not yet cleaned up are
+// included.
+func (d *Driver) TagSizes() map[string]int {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	sizes := make(map[string]int, len(d.tags))
+	for tag, keys := range d.tags {
+		sizes[tag] = len(keys)
+	}
+	return sizes
+}
+
 // FlushTags removes all items associated with the given tags.
 func (d *Driver) FlushTags(ctx context.Context, tags ...string) error {
 	d.mu.Lock()
@@ -110,9 +180,36 @@ func (d *Driver) FlushTags(ctx context.Context, tags ...string) error {
 		// We need an internal method `forgetPrefixed(prefixedKey)` or `removeItem(prefixedKey)`.
 		// Or we can just do the deletion logic here since we are inside the package.
 
-		d.removeKeyTags(key) // key is prefixed
-		delete(d.items, key)
-		delete(d.nodes, key)
+		d.removeItem(key) // key is prefixed
+	}
+
+	// Drop tags kept empty by FlushTagKeysOnly
+	for _, tag := range tags {
+		d.deleteTag(tag)
+	}
+
+	return nil
+}
+
+// FlushTagKeysOnly removes all items associated with the given tags, like
+// FlushTags, but keeps each tag in the index as an empty set instead of
+// dropping it with its last key. A kept tag stays until FlushTags removes it
+// or its keys are tagged again and removed.
+func (d *Driver) FlushTagKeysOnly(ctx context.Context, tags ...string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	for _, tag := range tags {
+		keys, ok := d.tags[tag]
+		if !ok {
+			continue
+		}
+		for key := range keys {
+			d.removeItem(key) // key is prefixed
+		}
+		if _, ok := d.tags[tag]; !ok {
+			d.tags[tag] = make(map[string]struct{})
+		}
 	}
 
 	return nil
@@ -1,13 +1,18 @@
 package memory
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"log/slog"
+	"strings"
 	"testing"
 	"time"
 
 	dgcache "github.com/donnigundala/dg-cache"
 	"github.com/donnigundala/dg-core/contracts/cache"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestTaggedCache(t *testing.T) {
@@ -74,3 +79,212 @@ func TestTaggedCache_Cleanup(t *testing.T) {
 	// Verify cleanup
 	assert.NotContains(t, memDriver.tags, "tag1")
 }
+
+func TestDriver_FlushTagKeysOnly(t *testing.T) {
+	d := newTestDriver(t, nil)
+	ctx := context.Background()
+
+	require.NoError(t, d.Tags("users").Put(ctx, "user:1", "john", time.Minute))
+	require.NoError(t, d.Tags("users", "admins").Put(ctx, "user:2", "jane", time.Minute))
+	require.NoError(t, d.Put(ctx, "untagged", "value", time.Minute))
+
+	require.NoError(t, d.FlushTagKeysOnly(ctx, "users"))
+
+	// The keys are gone but the tag is kept, empty
+	_, err := d.Get(ctx, "user:1")
+	assert.ErrorIs(t, err, dgcache.ErrKeyNotFound)
+	_, err = d.Get(ctx, "user:2")
+	assert.ErrorIs(t, err, dgcache.ErrKeyNotFound)
+	_, err = d.Get(ctx, "untagged")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]int{"users": 0}, d.TagSizes())
+
+	// FlushTags still drops the tag
+	require.NoError(t, d.FlushTags(ctx, "users"))
+	assert.Empty(t, d.TagSizes())
+}
+
+func TestDriver_TagExisting(t *testing.T) {
+	driver, err := NewDriver(dgcache.StoreConfig{Driver: "memory"})
+	assert.NoError(t, err)
+	defer driver.Close()
+
+	d := driver.(*Driver)
+	ctx := context.Background()
+
+	assert.NoError(t, d.Tags("users").Put(ctx, "user:1", "john", time.Minute))
+	assert.NoError(t, d.Tags("users").Put(ctx, "user:2", "jane", time.Minute))
+	assert.NoError(t, d.Put(ctx, "plain", "value", time.Minute))
+
+	assert.NoError(t, d.TagExisting(ctx, "review", "user:1", "plain", "missing"))
+
+	keys, err := d.KeysForTag(ctx, "review")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"plain", "user:1"}, keys)
+
+	// Existing tags are kept
+	keys, err = d.KeysForTag(ctx, "users")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"user:1", "user:2"}, keys)
+
+	assert.NoError(t, d.FlushTags(ctx, "review"))
+	for key, want := range map[string]bool{"user:1": false, "plain": false, "user:2": true} {
+		has, _ := d.Has(ctx, key)
+		assert.Equal(t, want, has, key)
+	}
+	has, _ := d.Has(ctx, "missing")
+	assert.False(t, has)
+}
+
+func TestDriver_Untag(t *testing.T) {
+	driver, err := NewDriver(dgcache.StoreConfig{Driver: "memory"})
+	assert.NoError(t, err)
+	defer driver.Close()
+
+	d := driver.(*Driver)
+	ctx := context.Background()
+
+	assert.NoError(t, d.Tags("users", "active").Put(ctx, "user:1", "john", time.Minute))
+	assert.NoError(t, d.Tags("users", "active").Put(ctx, "user:2", "jane", time.Minute))
+
+	assert.NoError(t, d.Untag(ctx, "user:1", "active"))
+
+	// The untagged key survives a flush of the removed tag
+	assert.NoError(t, d.FlushTags(ctx, "active"))
+	has, _ := d.Has(ctx, "user:1")
+	assert.True(t, has)
+	has, _ = d.Has(ctx, "user:2")
+	assert.False(t, has)
+
+	// The remaining tag still flushes it
+	assert.Equal(t, []string{"users"}, d.keyTags[d.prefixKey("user:1")])
+	assert.NoError(t, d.FlushTags(ctx, "users"))
+	has, _ = d.Has(ctx, "user:1")
+	assert.False(t, has)
+	assert.Empty(t, d.tags)
+	assert.Empty(t, d.keyTags)
+}
+
+func TestDriver_ItemTags(t *testing.T) {
+	d := newTestDriver(t, nil)
+	ctx := context.Background()
+
+	require.NoError(t, d.Tags("users", "admins").Put(ctx, "user:1", "john", time.Minute))
+	item, err := d.GetItem(ctx, "user:1")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"users", "admins"}, item.Tags)
+
+	// Tagging and untagging keep the item in sync
+	require.NoError(t, d.TagExisting(ctx, "review", "user:1"))
+	require.NoError(t, d.Untag(ctx, "user:1", "admins"))
+	item, err = d.GetItem(ctx, "user:1")
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"users", "review"}, item.Tags)
+
+	// An untagged overwrite keeps the tags, as the tag index does, and so
+	// does a rename
+	require.NoError(t, d.Put(ctx, "user:1", "johnny", time.Minute))
+	require.NoError(t, d.Rename(ctx, "user:1", "user:2"))
+	item, err = d.GetItem(ctx, "user:2")
+	require.NoError(t, err)
+	assert.Equal(t, "johnny", item.Value)
+	assert.ElementsMatch(t, []string{"users", "review"}, item.Tags)
+
+	// Flushing a tag removes the item
+	require.NoError(t, d.FlushTags(ctx, "review"))
+	_, err = d.GetItem(ctx, "user:2")
+	assert.ErrorIs(t, err, dgcache.ErrKeyNotFound)
+
+	require.NoError(t, d.Put(ctx, "plain", "value", time.Minute))
+	item, err = d.GetItem(ctx, "plain")
+	require.NoError(t, err)
+	assert.Empty(t, item.Tags)
+}
+
+func TestDriver_MaxKeysPerTag(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("reject", func(t *testing.T) {
+		d := newTestDriver(t, map[string]interface{}{
+			"max_keys_per_tag": 2,
+			"tag_limit_policy": "reject",
+		})
+		all := d.Tags("all")
+
+		require.NoError(t, all.Put(ctx, "a", 1, 0))
+		require.NoError(t, all.Put(ctx, "b", 2, 0))
+
+		// Rewriting a key already in the tag is fine
+		require.NoError(t, all.Put(ctx, "a", 10, 0))
+
+		err := all.Put(ctx, "c", 3, 0)
+		assert.ErrorIs(t, err, dgcache.ErrTagLimitExceeded)
+		has, _ := d.Has(ctx, "c")
+		assert.False(t, has, "rejected writes are not stored")
+
+		require.NoError(t, d.Put(ctx, "d", 4, 0))
+		assert.ErrorIs(t, d.TagExisting(ctx, "all", "d"), dgcache.ErrTagLimitExceeded)
+
+		assert.Equal(t, map[string]int{"all": 2}, d.TagSizes())
+	})
+
+	t.Run("warn", func(t *testing.T) {
+		var logs bytes.Buffer
+		previous := slog.Default()
+		slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+		defer slog.SetDefault(previous)
+
+		d := newTestDriver(t, map[string]interface{}{"max_keys_per_tag": 2})
+		all := d.Tags("all")
+
+		for _, key := range []string{"a", "b", "c", "d"} {
+			require.NoError(t, all.Put(ctx, key, 1, 0))
+		}
+
+		// Keys are still tagged, with a single warning for the tag
+		assert.Equal(t, map[string]int{"all": 4}, d.TagSizes())
+		assert.Equal(t, 1, strings.Count(logs.String(), "tag=all"))
+	})
+}
+
+func TestDriver_TagChurn(t *testing.T) {
+	d := newTestDriver(t, map[string]interface{}{
+		"max_items":        100,
+		"max_keys_per_tag": 1,
+	})
+	ctx := context.Background()
+
+	// Single-use tags, removed by every path that drops a key
+	for i := 0; i < 1000; i++ {
+		tag := fmt.Sprintf("request:%d", i)
+		key := fmt.Sprintf("key:%d", i)
+		tagged := d.Tags(tag, "shared")
+
+		switch i % 4 {
+		case 0:
+			require.NoError(t, tagged.Put(ctx, key, i, time.Minute))
+			require.NoError(t, d.Forget(ctx, key))
+		case 1:
+			require.NoError(t, tagged.Put(ctx, key, i, time.Minute))
+			require.NoError(t, d.FlushTags(ctx, tag))
+		case 2:
+			require.NoError(t, tagged.Put(ctx, key, i, time.Millisecond))
+			time.Sleep(2 * time.Millisecond)
+			d.removeExpired()
+		case 3:
+			// Evicted once the cache is full
+			require.NoError(t, tagged.Put(ctx, key, i, time.Minute))
+		}
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	// At most the 100 live keys keep their tag, plus "shared"
+	assert.LessOrEqual(t, len(d.tags), 101)
+	assert.LessOrEqual(t, len(d.keyTags), 100)
+	assert.LessOrEqual(t, len(d.tagLimitWarned), 101)
+	assert.Equal(t, len(d.items), len(d.keyTags))
+	for tag, keys := range d.tags {
+		assert.NotEmpty(t, keys, tag)
+	}
+}
@@ -0,0 +1,218 @@
+package memory
+
+import (
+	"context"
+	"math"
+	"time"
+
+	dgcache "github.com/donnigundala/dg-cache"
+	"github.com/donnigundala/dg-core/contracts/cache"
+)
+
+// stagedValue is the state of a key written inside a transaction.
+type stagedValue struct {
+	value   interface{}
+	deleted bool
+}
+
+// transaction stages writes against the driver and applies them on commit.
+// Reads see the transaction's own writes layered over the committed data.
+type transaction struct {
+	d       *Driver
+	ops     []func()
+	staged  map[string]stagedValue
+	flushed bool
+
+	// increments sums the deltas of counters whose starting value is read
+	// from the store at commit rather than written by the transaction
+	increments map[string]int64
+}
+
+// Transaction runs fn against a staging copy of the cache. The staged writes
+// are committed under a single lock if fn returns nil, and discarded otherwise.
+// Increments apply their delta to the value the key holds at commit, so
+// concurrent increments are never lost; if a counter can no longer take its
+// delta, nothing is committed and its error is returned.
+func (d *Driver) Transaction(ctx context.Context, fn func(tx cache.Store) error) error {
+	tx := &transaction{
+		d:          d,
+		staged:     make(map[string]stagedValue),
+		increments: make(map[string]int64),
+	}
+	if err := fn(tx); err != nil {
+		return err
+	}
+
+	defer d.enforceBudget()
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	for key, delta := range tx.increments {
+		if err := d.checkIncrement(key, delta); err != nil {
+			return err
+		}
+	}
+	for _, op := range tx.ops {
+		op()
+	}
+	return nil
+}
+
+// lookup returns the value of key as seen by the transaction.
+func (t *transaction) lookup(ctx context.Context, key string) (interface{}, bool) {
+	if staged, ok := t.staged[key]; ok {
+		return staged.value, !staged.deleted
+	}
+	if t.flushed {
+		return nil, false
+	}
+
+	t.d.mu.RLock()
+	defer t.d.mu.RUnlock()
+	item, ok := t.d.items[t.d.prefixKey(key)]
+	if !ok || item.IsExpired() {
+		return nil, false
+	}
+	return t.d.readValue(item.Value), true
+}
+
+// Get retrieves a value, including values staged in the transaction.
+func (t *transaction) Get(ctx context.Context, key string) (interface{}, error) {
+	if value, ok := t.lookup(ctx, key); ok {
+		return value, nil
+	}
+	return nil, dgcache.ErrKeyNotFound
+}
+
+// GetMultiple retrieves multiple values, including values staged in the transaction.
+func (t *transaction) GetMultiple(ctx context.Context, keys []string) (map[string]interface{}, error) {
+	result := make(map[string]interface{})
+	for _, key := range keys {
+		if value, ok := t.lookup(ctx, key); ok {
+			result[key] = value
+		}
+	}
+	return result, nil
+}
+
+// Put stages a value with the given TTL.
+func (t *transaction) Put(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	// Validate now so the commit cannot fail halfway
+	ttl, err := t.d.config.TTLLimits.Apply(ttl)
+	if err != nil {
+		return err
+	}
+	if err := t.d.checkSerializable(key, value); err != nil {
+		return err
+	}
+	encoded, err := t.d.encode(key, value)
+	if err != nil {
+		return err
+	}
+
+	// Reads within the transaction see the value as a later Get would
+	t.staged[key] = stagedValue{value: t.d.readValue(encoded)}
+	t.ops = append(t.ops, func() { _ = t.d.put(key, value, ttl) })
+	return nil
+}
+
+// PutMultiple stages multiple values with the given TTL.
+func (t *transaction) PutMultiple(ctx context.Context, items map[string]interface{}, ttl time.Duration) error {
+	for key, value := range items {
+		if err := t.Put(ctx, key, value, ttl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Increment stages an increment of the value of a key and returns the value
+// as the transaction sees it. The commit re-reads the key and applies the
+// delta, keeping the counter's expiry like Driver.Increment.
+func (t *transaction) Increment(ctx context.Context, key string, value int64) (int64, error) {
+	var current int64
+	if v, ok := t.lookup(ctx, key); ok {
+		n, ok := toInt64(v)
+		if !ok {
+			return 0, notANumber(key, v)
+		}
+		current = n
+	} else if _, err := t.d.config.TTLLimits.Apply(0); err != nil {
+		// A new counter is a write without expiry, bounded by max_ttl
+		return 0, err
+	}
+
+	newValue, err := addInt64(key, current, value)
+	if err != nil {
+		return 0, err
+	}
+	_, staged := t.staged[key]
+	if _, pending := t.increments[key]; pending || (!staged && !t.flushed) {
+		if t.increments[key], err = addInt64(key, t.increments[key], value); err != nil {
+			return 0, err
+		}
+	}
+	t.staged[key] = stagedValue{value: newValue}
+	t.ops = append(t.ops, func() { _, _ = t.d.increment(key, value, 0) })
+	return newValue, nil
+}
+
+// Decrement stages a decrement of the value of a key.
+func (t *transaction) Decrement(ctx context.Context, key string, value int64) (int64, error) {
+	if value == math.MinInt64 {
+		return 0, decrementOverflow(key)
+	}
+	return t.Increment(ctx, key, -value)
+}
+
+// Forever stages a value without expiry.
+func (t *transaction) Forever(ctx context.Context, key string, value interface{}) error {
+	return t.Put(ctx, key, value, 0)
+}
+
+// Forget stages the removal of a key.
+func (t *transaction) Forget(ctx context.Context, key string) error {
+	t.staged[key] = stagedValue{deleted: true}
+	t.ops = append(t.ops, func() { _ = t.d.forget(key) })
+	return nil
+}
+
+// ForgetMultiple stages the removal of multiple keys.
+func (t *transaction) ForgetMultiple(ctx context.Context, keys []string) error {
+	for _, key := range keys {
+		_ = t.Forget(ctx, key)
+	}
+	return nil
+}
+
+// Flush stages the removal of all items.
+func (t *transaction) Flush(ctx context.Context) error {
+	t.staged = make(map[string]stagedValue)
+	t.flushed = true
+	t.ops = append(t.ops, t.d.flush)
+	return nil
+}
+
+// Has checks if a key exists, including values staged in the transaction.
+func (t *transaction) Has(ctx context.Context, key string) (bool, error) {
+	_, ok := t.lookup(ctx, key)
+	return ok, nil
+}
+
+// Missing checks if a key does not exist, including values staged in the transaction.
+func (t *transaction) Missing(ctx context.Context, key string) (bool, error) {
+	has, err := t.Has(ctx, key)
+	return !has, err
+}
+
+// GetPrefix returns the driver's key prefix.
+func (t *transaction) GetPrefix() string {
+	return t.d.GetPrefix()
+}
+
+// SetPrefix is a no-op; the prefix cannot be changed inside a transaction.
+func (t *transaction) SetPrefix(prefix string) {}
+
+// Stats returns the driver's statistics.
+func (t *transaction) Stats() cache.Stats {
+	return t.d.Stats()
+}
@@ -2,6 +2,7 @@ package redis
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -269,3 +270,46 @@ func BenchmarkTaggedCache_Put(b *testing.B) {
 		_ = tagged.Put(ctx, "product:1", product, 1*time.Minute)
 	}
 }
+
+// benchmarkPutMultipleLarge benchmarks a large PutMultiple with the given pipeline cap
+func benchmarkPutMultipleLarge(b *testing.B, maxPipelineSize int) {
+	config := dgcache.StoreConfig{
+		Driver: "redis",
+		Options: map[string]interface{}{
+			"host":              "localhost",
+			"port":              6379,
+			"database":          15,
+			"serializer":        "msgpack",
+			"max_pipeline_size": maxPipelineSize,
+		},
+	}
+
+	driver, err := NewDriver(config)
+	if err != nil {
+		b.Skipf("Skipping benchmark: Redis not available: %v", err)
+	}
+	defer driver.Close()
+
+	redisDriver := driver.(*Driver)
+	ctx := context.Background()
+
+	items := make(map[string]interface{}, 10000)
+	for i := 0; i < 10000; i++ {
+		items[fmt.Sprintf("bulk:%d", i)] = i
+	}
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = redisDriver.PutMultiple(ctx, items, 1*time.Minute)
+	}
+}
+
+// BenchmarkPutMultiple_SinglePipeline benchmarks 10k items in one pipeline
+func BenchmarkPutMultiple_SinglePipeline(b *testing.B) {
+	benchmarkPutMultipleLarge(b, 0)
+}
+
+// BenchmarkPutMultiple_Chunked benchmarks 10k items in pipelines of 500
+func BenchmarkPutMultiple_Chunked(b *testing.B) {
+	benchmarkPutMultipleLarge(b, 500)
+}
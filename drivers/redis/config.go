@@ -22,10 +22,31 @@ type Config struct {
 	Prefix string
 
 	// PoolSize is the maximum number of socket connections.
-	PoolSize int
+	PoolSize int `mapstructure:"pool_size"`
 
 	// MinIdleConns is the minimum number of idle connections.
-	MinIdleConns int
+	MinIdleConns int `mapstructure:"min_idle_conns"`
+
+	// ConnMaxIdleTime is how long a connection may stay idle before it is closed.
+	// Set it below the server or proxy idle timeout on managed Redis.
+	// 0 uses the go-redis default (30 minutes).
+	ConnMaxIdleTime time.Duration `mapstructure:"conn_max_idle_time"`
+
+	// ConnMaxLifetime is the maximum age of a connection before it is closed.
+	// 0 means connections are not closed due to age.
+	ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime"`
+
+	// PoolTimeout is how long to wait for a free connection when the pool is exhausted.
+	// 0 uses the go-redis default (ReadTimeout + 1 second).
+	PoolTimeout time.Duration `mapstructure:"pool_timeout"`
+
+	// ReadTimeout is the timeout for socket reads.
+	// 0 uses the go-redis default (3 seconds).
+	ReadTimeout time.Duration `mapstructure:"read_timeout"`
+
+	// WriteTimeout is the timeout for socket writes.
+	// 0 uses the go-redis default (ReadTimeout).
+	WriteTimeout time.Duration `mapstructure:"write_timeout"`
 
 	// MaxRetries is the maximum number of retries before giving up.
 	MaxRetries int
@@ -38,19 +59,134 @@ type Config struct {
 
 	// MaxRetryBackoff is the maximum backoff between retries.
 	MaxRetryBackoff time.Duration
+
+	// MaxPipelineSize caps the number of commands sent in a single pipeline
+	// by batch operations. Larger batches are split and executed sequentially.
+	// 0 means unlimited (default).
+	MaxPipelineSize int `mapstructure:"max_pipeline_size"`
+
+	// PrefixSeparator joins the prefix with keys and tag names.
+	// Default: ":"
+	PrefixSeparator string `mapstructure:"prefix_separator"`
+
+	// SerializationErrorPolicy controls PutMultiple when a value cannot be serialized.
+	// "fail_fast" (default) aborts the batch before anything is written.
+	// "skip_errors" writes the other items and returns a *dgcache.BatchError
+	// listing the skipped keys.
+	SerializationErrorPolicy string `mapstructure:"serialization_error_policy"`
+
+	// LogSerializationErrors logs the key (never the value) of every value
+	// that fails to encode or decode, so incompatible data left behind by a
+	// schema change can be found and removed. Failures are counted by
+	// SerializationErrors either way.
+	// Default: true
+	LogSerializationErrors bool `mapstructure:"log_serialization_errors"`
+
+	// FlushTagsMode selects how tag flushes delete keys.
+	// "script" (default) deletes all members atomically in one Lua script.
+	// "incremental" walks each tag set with SSCAN and deletes members in
+	// batches from the client, so huge tags do not block the server; it
+	// stops between batches when the context is cancelled.
+	FlushTagsMode string `mapstructure:"flush_tags_mode"`
+
+	// FlushTagsBatchSize is the number of members scanned and deleted per
+	// batch in incremental mode.
+	// Default: 1000
+	FlushTagsBatchSize int `mapstructure:"flush_tags_batch_size"`
+
+	// FlushScope selects what Flush removes.
+	// "prefix" (default) deletes only keys under this store's prefix, found
+	// with SCAN, so stores and applications sharing a database are left alone.
+	// "db" runs FLUSHDB and clears the whole database; use it only when the
+	// database is dedicated to this store. Without a prefix, both clear the
+	// whole database.
+	FlushScope string `mapstructure:"flush_scope"`
+
+	// SchemaVersion stamps every serialized value with this version. A value
+	// written under another version, or before versioning was enabled, reads
+	// as a miss instead of decoding into a struct that has since changed, so
+	// bumping it after a schema change invalidates old values without a flush.
+	// Counters written by Increment are plain integers and stay readable.
+	// 0 disables versioning (default).
+	SchemaVersion int `mapstructure:"schema_version"`
+
+	// SlidingTTL makes Get reset the expiry of a key written by Put or
+	// PutMultiple with a TTL to that TTL, so keys that keep being read stay
+	// cached. The TTL is kept in a companion key under the "slide" namespace.
+	// Default: false
+	SlidingTTL bool `mapstructure:"sliding_ttl"`
+
+	// SlidingTTLMaxLifetime caps how long after its write a key can be kept
+	// alive by reads. 0 means no cap (default).
+	SlidingTTLMaxLifetime time.Duration `mapstructure:"sliding_ttl_max_lifetime"`
+
+	// MetaTags makes GetWithMeta report the tags of the key. Redis keeps no
+	// reverse index, so this scans every tag set of the store on each call.
+	// Default: false
+	MetaTags bool `mapstructure:"meta_tags"`
+
+	// ReadReplica marks the store as connected to a read replica. Every
+	// operation that writes is rejected with dgcache.ErrReadOnly before it
+	// reaches Redis, and only reads are sent. Pair it with a store on the
+	// primary for writes. It cannot be combined with write_behind,
+	// tag_prune_interval or sliding_ttl.
+	ReadReplica bool `mapstructure:"read_replica"`
+
+	// TagPruneInterval starts a background task that removes members of
+	// expired keys from every tag set at this interval.
+	// 0 disables it (default); expired members are then only pruned lazily
+	// by KeysForTag and explicitly by PruneTags.
+	TagPruneInterval time.Duration `mapstructure:"tag_prune_interval"`
+
+	// WriteBehind makes Put and PutMultiple return once their values are
+	// serialized and queued; a background flusher writes them to Redis in
+	// pipelined batches. Queued writes are lost if the process dies before
+	// they are flushed, and a Get right after a Put can miss until the flush.
+	// Close flushes the queue, and so does every other write before it runs,
+	// including removals, counters, expiry changes, tagged and hash writes
+	// and transactions, so a queued write never lands after them.
+	WriteBehind bool `mapstructure:"write_behind"`
+
+	// WriteBehindBufferSize is the number of writes the queue holds.
+	// Default: 10000
+	WriteBehindBufferSize int `mapstructure:"write_behind_buffer_size"`
+
+	// WriteBehindBatchSize is the number of writes flushed per pipeline.
+	// Default: 100
+	WriteBehindBatchSize int `mapstructure:"write_behind_batch_size"`
+
+	// WriteBehindFlushInterval is the longest a queued write waits for its
+	// batch to fill before it is flushed.
+	// Default: 100ms
+	WriteBehindFlushInterval time.Duration `mapstructure:"write_behind_flush_interval"`
+
+	// WriteBehindFullPolicy controls writes when the queue is full.
+	// "block" (default) waits for room or the caller's context to end.
+	// "drop" discards the write and returns ErrWriteBehindFull.
+	WriteBehindFullPolicy string `mapstructure:"write_behind_full_policy"`
 }
 
 // DefaultConfig returns a default Redis configuration.
 func DefaultConfig() Config {
 	return Config{
-		Host:            "localhost",
-		Port:            6379,
-		Database:        0,
-		PoolSize:        10,
-		MinIdleConns:    2,
-		MaxRetries:      3,
-		Timeout:         5 * time.Second,
-		MinRetryBackoff: 8 * time.Millisecond,
-		MaxRetryBackoff: 512 * time.Millisecond,
+		Host:                     "localhost",
+		Port:                     6379,
+		Database:                 0,
+		PoolSize:                 10,
+		MinIdleConns:             2,
+		MaxRetries:               3,
+		Timeout:                  5 * time.Second,
+		MinRetryBackoff:          8 * time.Millisecond,
+		MaxRetryBackoff:          512 * time.Millisecond,
+		PrefixSeparator:          ":",
+		SerializationErrorPolicy: "fail_fast",
+		LogSerializationErrors:   true,
+		FlushTagsMode:            "script",
+		FlushTagsBatchSize:       1000,
+		FlushScope:               "prefix",
+		WriteBehindBufferSize:    10000,
+		WriteBehindBatchSize:     100,
+		WriteBehindFlushInterval: 100 * time.Millisecond,
+		WriteBehindFullPolicy:    "block",
 	}
 }
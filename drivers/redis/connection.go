@@ -9,17 +9,7 @@ import (
 
 // NewClient creates a new Redis client.
 func NewClient(config Config) (*redis.Client, error) {
-	client := redis.NewClient(&redis.Options{
-		Addr:            fmt.Sprintf("%s:%d", config.Host, config.Port),
-		Password:        config.Password,
-		DB:              config.Database,
-		PoolSize:        config.PoolSize,
-		MinIdleConns:    config.MinIdleConns,
-		MaxRetries:      config.MaxRetries,
-		MinRetryBackoff: config.MinRetryBackoff,
-		MaxRetryBackoff: config.MaxRetryBackoff,
-		DialTimeout:     config.Timeout,
-	})
+	client := redis.NewClient(clientOptions(config))
 
 	// Ping to verify connection
 	ctx, cancel := context.WithTimeout(context.Background(), config.Timeout)
@@ -31,3 +21,23 @@ func NewClient(config Config) (*redis.Client, error) {
 
 	return client, nil
 }
+
+// clientOptions maps the driver configuration to go-redis client options.
+func clientOptions(config Config) *redis.Options {
+	return &redis.Options{
+		Addr:            fmt.Sprintf("%s:%d", config.Host, config.Port),
+		Password:        config.Password,
+		DB:              config.Database,
+		PoolSize:        config.PoolSize,
+		MinIdleConns:    config.MinIdleConns,
+		MaxRetries:      config.MaxRetries,
+		MinRetryBackoff: config.MinRetryBackoff,
+		MaxRetryBackoff: config.MaxRetryBackoff,
+		DialTimeout:     config.Timeout,
+		ReadTimeout:     config.ReadTimeout,
+		WriteTimeout:    config.WriteTimeout,
+		PoolTimeout:     config.PoolTimeout,
+		ConnMaxIdleTime: config.ConnMaxIdleTime,
+		ConnMaxLifetime: config.ConnMaxLifetime,
+	}
+}
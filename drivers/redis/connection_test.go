@@ -0,0 +1,41 @@
+package redis
+
+import (
+	"testing"
+	"time"
+
+	dgcache "github.com/donnigundala/dg-cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClientOptions_PoolTuning(t *testing.T) {
+	storeConfig := dgcache.StoreConfig{
+		Driver: "redis",
+		Options: map[string]interface{}{
+			"host":               "redis.internal",
+			"port":               6380,
+			"pool_size":          50,
+			"min_idle_conns":     5,
+			"conn_max_idle_time": "4m",
+			"conn_max_lifetime":  "1h",
+			"pool_timeout":       2 * time.Second,
+			"read_timeout":       "500ms",
+			"write_timeout":      "750ms",
+		},
+	}
+
+	config := DefaultConfig()
+	require.NoError(t, storeConfig.Decode(&config))
+
+	opts := clientOptions(config)
+	assert.Equal(t, "redis.internal:6380", opts.Addr)
+	assert.Equal(t, 50, opts.PoolSize)
+	assert.Equal(t, 5, opts.MinIdleConns)
+	assert.Equal(t, 4*time.Minute, opts.ConnMaxIdleTime)
+	assert.Equal(t, time.Hour, opts.ConnMaxLifetime)
+	assert.Equal(t, 2*time.Second, opts.PoolTimeout)
+	assert.Equal(t, 500*time.Millisecond, opts.ReadTimeout)
+	assert.Equal(t, 750*time.Millisecond, opts.WriteTimeout)
+	assert.Equal(t, 5*time.Second, opts.DialTimeout)
+}
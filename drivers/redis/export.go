@@ -0,0 +1,121 @@
+package redis
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	dgcache "github.com/donnigundala/dg-cache"
+	"github.com/redis/go-redis/v9"
+)
+
+// exportBatchSize is the number of keys read per pipeline during Export.
+const exportBatchSize = 100
+
+// Export calls fn for every value under the driver's prefix, found with SCAN
+// and read with GET and PTTL. Tags are resolved from the tag sets first.
+// Keys that are not plain values, such as tag sets and sliding TTL records,
+// are skipped.
+func (d *Driver) Export(ctx context.Context, fn func(item dgcache.Item) error) error {
+	keyTags, err := d.exportTags(ctx)
+	if err != nil {
+		return err
+	}
+
+	tagPrefix, slidePrefix := d.tagKey(""), d.slideKey("")
+	batch := make([]string, 0, exportBatchSize)
+	iter := d.client.Scan(ctx, 0, d.prefixKey("*"), 1000).Iterator()
+	for iter.Next(ctx) {
+		key := iter.Val()
+		if strings.HasPrefix(key, tagPrefix) || strings.HasPrefix(key, slidePrefix) {
+			continue
+		}
+		batch = append(batch, key)
+		if len(batch) == exportBatchSize {
+			if err := d.exportBatch(ctx, batch, keyTags, fn); err != nil {
+				return err
+			}
+			batch = batch[:0]
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+	return d.exportBatch(ctx, batch, keyTags, fn)
+}
+
+// exportTags maps each prefixed key to the names of the tags it belongs to.
+func (d *Driver) exportTags(ctx context.Context) (map[string][]string, error) {
+	tagPrefix := d.tagKey("")
+	keyTags := make(map[string][]string)
+
+	iter := d.client.Scan(ctx, 0, d.tagKey("*"), 1000).Iterator()
+	for iter.Next(ctx) {
+		tagKey := iter.Val()
+		members, err := d.client.SMembers(ctx, tagKey).Result()
+		if err != nil {
+			return nil, err
+		}
+		tag := strings.TrimPrefix(tagKey, tagPrefix)
+		for _, member := range members {
+			keyTags[member] = append(keyTags[member], tag)
+		}
+	}
+	return keyTags, iter.Err()
+}
+
+// exportBatch reads a batch of prefixed keys and passes the values to fn.
+func (d *Driver) exportBatch(ctx context.Context, keys []string, keyTags map[string][]string, fn func(item dgcache.Item) error) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	pipe := d.client.Pipeline()
+	gets := make([]*redis.StringCmd, len(keys))
+	ttls := make([]*redis.DurationCmd, len(keys))
+	for i, key := range keys {
+		gets[i] = pipe.Get(ctx, key)
+		ttls[i] = pipe.PTTL(ctx, key)
+	}
+	// Per-command errors are checked below
+	_, _ = pipe.Exec(ctx)
+
+	now := time.Now()
+	for i, key := range keys {
+		data, err := gets[i].Bytes()
+		if err == redis.Nil || isWrongType(err) {
+			// Expired since the scan, or not a plain value
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		ttl, err := ttls[i].Result()
+		if err != nil {
+			return err
+		}
+
+		value, ok := d.decodeValue(d.unprefixKey(key), data)
+		if !ok {
+			// Written under another schema version
+			continue
+		}
+		item := dgcache.Item{
+			Key:   d.unprefixKey(key),
+			Value: value,
+			Tags:  keyTags[key],
+		}
+		if ttl > 0 {
+			item.ExpiresAt = now.Add(ttl)
+		}
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isWrongType reports whether err is Redis' WRONGTYPE error.
+func isWrongType(err error) bool {
+	return err != nil && strings.HasPrefix(err.Error(), "WRONGTYPE")
+}
@@ -0,0 +1,60 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	dgcache "github.com/donnigundala/dg-cache"
+)
+
+// GetField retrieves a single field of a cached object using a dotted path
+// such as "address.city" or "$.items.0.name". The whole value is fetched and
+// decoded, and the path is walked through maps and slices.
+//
+// RedisJSON's JSON.GET is not used, even when the module is loaded: it only
+// reads keys written with JSON.SET, and the driver stores every value as a
+// serialized string with SET (possibly enveloped, compressed or msgpack), so
+// JSON.GET would fail with WRONGTYPE. Reading a field server-side would need
+// values stored as native JSON documents, which this driver does not do.
+func (d *Driver) GetField(ctx context.Context, key, path string) (interface{}, error) {
+	value, err := d.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	return extractField(value, path)
+}
+
+// splitPath splits a dotted path into segments, dropping a leading "$".
+func splitPath(path string) []string {
+	path = strings.TrimPrefix(path, "$")
+	path = strings.TrimPrefix(path, ".")
+	if path == "" {
+		return nil
+	}
+	return strings.Split(path, ".")
+}
+
+// extractField walks a decoded value along a dotted path.
+func extractField(value interface{}, path string) (interface{}, error) {
+	current := value
+	for _, segment := range splitPath(path) {
+		switch v := current.(type) {
+		case map[string]interface{}:
+			next, ok := v[segment]
+			if !ok {
+				return nil, dgcache.ErrFieldNotFound
+			}
+			current = next
+		case []interface{}:
+			index, err := strconv.Atoi(segment)
+			if err != nil || index < 0 || index >= len(v) {
+				return nil, dgcache.ErrFieldNotFound
+			}
+			current = v[index]
+		default:
+			return nil, dgcache.ErrFieldNotFound
+		}
+	}
+	return current, nil
+}
@@ -0,0 +1,78 @@
+package redis
+
+import (
+	"context"
+
+	dgcache "github.com/donnigundala/dg-cache"
+	"github.com/redis/go-redis/v9"
+)
+
+// HGet returns the value of field in the Redis hash at key.
+func (d *Driver) HGet(ctx context.Context, key, field string) (interface{}, error) {
+	data, err := d.client.HGet(ctx, d.prefixKey(key), field).Bytes()
+	if err == redis.Nil {
+		d.recordMiss()
+		return nil, dgcache.ErrKeyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	value, ok := d.decodeValue(key, data)
+	if !ok {
+		d.recordMiss()
+		return nil, dgcache.ErrKeyNotFound
+	}
+	d.recordHit()
+	return value, nil
+}
+
+// HSet serializes value and stores it as field of the Redis hash at key.
+// The hash keeps any expiry it already has.
+func (d *Driver) HSet(ctx context.Context, key, field string, value interface{}) error {
+	if err := d.writable("hset"); err != nil {
+		return err
+	}
+	if err := d.drainWrites(ctx); err != nil {
+		return err
+	}
+	data, err := d.marshal(key, value)
+	if err != nil {
+		return err
+	}
+	err = d.client.HSet(ctx, d.prefixKey(key), field, data).Err()
+	if err == nil {
+		d.recordSet()
+	}
+	return err
+}
+
+// HGetAll returns every field of the Redis hash at key.
+func (d *Driver) HGetAll(ctx context.Context, key string) (map[string]interface{}, error) {
+	fields, err := d.client.HGetAll(ctx, d.prefixKey(key)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string]interface{}, len(fields))
+	for field, data := range fields {
+		if value, ok := d.decodeValue(key, data); ok {
+			result[field] = value
+		}
+	}
+	return result, nil
+}
+
+// HDel removes fields from the Redis hash at key.
+func (d *Driver) HDel(ctx context.Context, key string, fields ...string) error {
+	if err := d.writable("hdel"); err != nil {
+		return err
+	}
+	if err := d.drainWrites(ctx); err != nil {
+		return err
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	return d.client.HDel(ctx, d.prefixKey(key), fields...).Err()
+}
@@ -0,0 +1,100 @@
+package redis
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"sync"
+
+	dgcache "github.com/donnigundala/dg-cache"
+	"github.com/redis/go-redis/v9"
+)
+
+// Invalidator broadcasts dgcache invalidations over a Redis pub/sub channel.
+// Every manager sharing the channel evicts published keys and tags from its
+// memory stores; see dgcache.Manager.SetInvalidator.
+type Invalidator struct {
+	client  *redis.Client
+	channel string
+
+	mu     sync.Mutex
+	pubsub *redis.PubSub
+	done   chan struct{}
+}
+
+// NewInvalidator creates an invalidator publishing on channel with client.
+func NewInvalidator(client *redis.Client, channel string) *Invalidator {
+	return &Invalidator{client: client, channel: channel}
+}
+
+// Invalidator creates an invalidator publishing on channel with the driver's
+// client. Closing it does not close the driver.
+func (d *Driver) Invalidator(channel string) *Invalidator {
+	return NewInvalidator(d.client, channel)
+}
+
+// Publish sends inv to every subscriber of the channel.
+func (i *Invalidator) Publish(ctx context.Context, inv dgcache.Invalidation) error {
+	payload, err := json.Marshal(inv)
+	if err != nil {
+		return err
+	}
+	return i.client.Publish(ctx, i.channel, payload).Err()
+}
+
+// Subscribe subscribes to the channel and calls handler for every message in
+// the background until Close. Malformed messages are logged and skipped.
+func (i *Invalidator) Subscribe(ctx context.Context, handler func(dgcache.Invalidation)) error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.pubsub != nil {
+		return errors.New("cache: invalidator already subscribed")
+	}
+
+	pubsub := i.client.Subscribe(ctx, i.channel)
+	// Wait for the confirmation so no invalidation published after Subscribe
+	// returns is missed
+	if _, err := pubsub.Receive(ctx); err != nil {
+		_ = pubsub.Close()
+		return err
+	}
+
+	i.pubsub = pubsub
+	i.done = make(chan struct{})
+	go i.run(pubsub.Channel(), handler)
+	return nil
+}
+
+// run delivers messages until the subscription is closed.
+func (i *Invalidator) run(messages <-chan *redis.Message, handler func(dgcache.Invalidation)) {
+	defer close(i.done)
+	for msg := range messages {
+		var inv dgcache.Invalidation
+		if err := json.Unmarshal([]byte(msg.Payload), &inv); err != nil {
+			slog.Warn("cache: ignoring malformed invalidation",
+				"channel", msg.Channel,
+				"error", err,
+			)
+			continue
+		}
+		handler(inv)
+	}
+}
+
+// Close unsubscribes and waits for the handler to return. The client stays open.
+func (i *Invalidator) Close() error {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	if i.pubsub == nil {
+		return nil
+	}
+
+	err := i.pubsub.Close()
+	<-i.done
+	i.pubsub = nil
+	return err
+}
+
+// Verify Invalidator implements dgcache.Invalidator
+var _ dgcache.Invalidator = (*Invalidator)(nil)
@@ -2,6 +2,11 @@ package redis
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	dgcache "github.com/donnigundala/dg-cache"
@@ -26,10 +31,67 @@ type Metrics struct {
 
 // Driver is a Redis cache driver.
 type Driver struct {
-	client     *redis.Client
-	prefix     string
-	serializer serializer.Serializer
-	metrics    Metrics // Simple atomic counters manually managed
+	client      *redis.Client
+	prefix      string
+	separator   string
+	serializer  serializer.Serializer
+	compression string
+	metrics     Metrics // Simple atomic counters manually managed
+
+	// baseSerializer and compressor are the parts serializer is built from.
+	baseSerializer serializer.Serializer
+	compressor     compression.Compressor
+
+	// metricsEnabled turns on the hit/miss/set/delete counters reported by Stats.
+	metricsEnabled bool
+
+	// maxPipelineSize caps commands per pipeline in batch operations (0 = unlimited).
+	maxPipelineSize int
+
+	// noExpireGT is set once the server rejects EXPIRE with the GT flag.
+	noExpireGT atomic.Bool
+
+	// ttlLimits bounds the TTL of written values.
+	ttlLimits dgcache.TTLLimits
+
+	// skipSerializationErrors makes PutMultiple skip values that fail to serialize.
+	skipSerializationErrors bool
+
+	// flushTagsBatchSize enables incremental tag flushes with the given batch size (0 = Lua script).
+	flushTagsBatchSize int
+
+	// flushDB makes Flush clear the whole database instead of the prefix (flush_scope "db").
+	flushDB bool
+
+	// readReplica rejects every write with ErrReadOnly (read_replica).
+	readReplica bool
+
+	// schemaVersion stamps serialized values with a version (0 = unversioned).
+	schemaVersion int
+
+	// slidingTTL makes Get reset the expiry of keys written with a TTL (sliding_ttl).
+	slidingTTL bool
+
+	// slidingMaxLifetime caps how long reads keep a sliding key alive (0 = no cap).
+	slidingMaxLifetime time.Duration
+
+	// metaTags makes GetWithMeta look up the tags of the key (meta_tags).
+	metaTags bool
+
+	// lowercaseKeys makes prefixKey lowercase keys (lowercase_keys).
+	lowercaseKeys bool
+
+	// stopPruner stops the background tag pruner, if one was started.
+	stopPruner func()
+
+	// writeBehind queues Put and PutMultiple writes when write_behind is set.
+	writeBehind *writeBehind
+
+	// serializationErrors counts values that failed to encode or decode.
+	serializationErrors atomic.Int64
+
+	// logSerializationErrors logs the key of every value counted in serializationErrors.
+	logSerializationErrors bool
 }
 
 // NewDriver creates a new Redis cache driver.
@@ -40,80 +102,263 @@ func NewDriver(config dgcache.StoreConfig) (cache.Driver, error) {
 		return nil, err
 	}
 
+	switch redisConfig.SerializationErrorPolicy {
+	case "fail_fast", "skip_errors":
+	default:
+		return nil, dgcache.ErrInvalidConfig("unknown serialization_error_policy '%s'", redisConfig.SerializationErrorPolicy)
+	}
+	var flushTagsBatchSize int
+	switch redisConfig.FlushTagsMode {
+	case "script":
+	case "incremental":
+		flushTagsBatchSize = redisConfig.FlushTagsBatchSize
+		if flushTagsBatchSize <= 0 {
+			flushTagsBatchSize = 1000
+		}
+	default:
+		return nil, dgcache.ErrInvalidConfig("unknown flush_tags_mode '%s'", redisConfig.FlushTagsMode)
+	}
+	switch redisConfig.FlushScope {
+	case "prefix", "db":
+	default:
+		return nil, dgcache.ErrInvalidConfig("unknown flush_scope '%s'", redisConfig.FlushScope)
+	}
+	switch redisConfig.WriteBehindFullPolicy {
+	case "block", "drop":
+	default:
+		return nil, dgcache.ErrInvalidConfig("unknown write_behind_full_policy '%s'", redisConfig.WriteBehindFullPolicy)
+	}
+	if redisConfig.SchemaVersion < 0 {
+		return nil, dgcache.ErrInvalidConfig("schema_version must not be negative")
+	}
+	if redisConfig.SlidingTTLMaxLifetime < 0 {
+		return nil, dgcache.ErrInvalidConfig("sliding_ttl_max_lifetime must not be negative")
+	}
+	if redisConfig.ReadReplica && (redisConfig.WriteBehind || redisConfig.TagPruneInterval > 0 || redisConfig.SlidingTTL) {
+		return nil, dgcache.ErrInvalidConfig("read_replica cannot be combined with write_behind, tag_prune_interval or sliding_ttl")
+	}
+
+	ttlLimits, err := config.TTLLimits()
+	if err != nil {
+		return nil, err
+	}
+
 	client, err := NewClient(redisConfig)
 	if err != nil {
 		return nil, err
 	}
 
 	// Initialize serializer (default to JSON)
-	var ser serializer.Serializer = serializer.NewJSONSerializer()
-	if val, ok := config.Options["serializer"].(string); ok {
-		switch val {
-		case "msgpack":
-			ser = serializer.NewMsgpackSerializer()
-		case "json":
-			ser = serializer.NewJSONSerializer()
+	serializerName, _ := config.Options["serializer"].(string)
+	envelope := true
+	if val, ok := config.Options["serializer_envelope"].(bool); ok {
+		envelope = val
+	}
+	useNumber, _ := config.Options["json_use_number"].(bool)
+	ser := newSerializer(serializerName, envelope, useNumber)
+
+	// Values in older formats stay readable during a serializer migration
+	if names := readSerializers(config.Options["read_serializers"]); len(names) > 0 {
+		readers := make([]serializer.Serializer, len(names))
+		for i, name := range names {
+			readers[i] = newSerializer(name, envelope, useNumber)
 		}
+		ser = serializer.NewFallbackSerializer(ser, readers...)
+	}
+
+	rd := &Driver{
+		client:                  client,
+		prefix:                  config.Prefix,
+		separator:               redisConfig.PrefixSeparator,
+		baseSerializer:          ser,
+		maxPipelineSize:         redisConfig.MaxPipelineSize,
+		ttlLimits:               ttlLimits,
+		skipSerializationErrors: redisConfig.SerializationErrorPolicy == "skip_errors",
+		flushTagsBatchSize:      flushTagsBatchSize,
+		flushDB:                 redisConfig.FlushScope == "db",
+		readReplica:             redisConfig.ReadReplica,
+		schemaVersion:           redisConfig.SchemaVersion,
+		slidingTTL:              redisConfig.SlidingTTL,
+		slidingMaxLifetime:      redisConfig.SlidingTTLMaxLifetime,
+		metaTags:                redisConfig.MetaTags,
+		lowercaseKeys:           config.LowercaseKeys(),
+		metricsEnabled:          config.MetricsEnabled(),
+		logSerializationErrors:  redisConfig.LogSerializationErrors,
 	}
 
 	// Wrap with compression if enabled
-	if val, ok := config.Options["compression"].(string); ok {
-		switch val {
-		case "gzip":
-			comp := compression.NewGzipCompressor(compression.DefaultCompression) // Use default or config
-			ser = serializer.NewCompressedSerializer(ser, comp)
-		}
+	if val, ok := config.Options["compression"].(string); ok && val == "gzip" {
+		rd.compressor = compression.NewGzipCompressor(compression.DefaultCompression) // Use default or config
 	}
+	rd.buildSerializer()
 
-	var d cache.Driver = &Driver{
-		client:     client,
-		prefix:     config.Prefix,
-		serializer: ser,
+	if redisConfig.TagPruneInterval > 0 {
+		rd.startTagPruner(redisConfig.TagPruneInterval)
 	}
+	if redisConfig.WriteBehind {
+		rd.startWriteBehind(redisConfig)
+	}
+
+	var d cache.Driver = rd
 
 	// Wrap with circuit breaker if enabled
 	if cbConfig, ok := config.Options["circuit_breaker"].(map[string]interface{}); ok {
 		enabled, _ := cbConfig["enabled"].(bool)
 		if enabled {
-			threshold, _ := cbConfig["threshold"].(int)
-			if threshold == 0 {
-				threshold = 5 // Default
-			}
+			d = reliability.NewCircuitBreakerDriver(d, newBreaker(cbConfig))
+		}
+	}
 
-			timeoutStr, _ := cbConfig["timeout"].(string)
-			timeout, err := time.ParseDuration(timeoutStr)
-			if err != nil {
-				timeout = 1 * time.Minute // Default
-			}
+	return d, nil
+}
+
+// newBreaker builds the breaker described by the circuit_breaker options.
+// breaker_type "rolling" selects a failure-rate breaker; anything else gets
+// the consecutive-failure threshold breaker.
+func newBreaker(cbConfig map[string]interface{}) reliability.Breaker {
+	timeoutStr, _ := cbConfig["timeout"].(string)
+	timeout, err := time.ParseDuration(timeoutStr)
+	if err != nil {
+		timeout = 1 * time.Minute // Default
+	}
 
-			breaker := reliability.NewThresholdBreaker(threshold, timeout)
-			d = reliability.NewCircuitBreakerDriver(d, breaker)
+	if breakerType, _ := cbConfig["breaker_type"].(string); breakerType == "rolling" {
+		windowSize, _ := cbConfig["window_size"].(int)
+		if windowSize == 0 {
+			windowSize = 100 // Default
+		}
+		failureRate, _ := cbConfig["failure_rate"].(float64)
+		if failureRate == 0 {
+			failureRate = 0.5 // Default
 		}
+		minRequests, _ := cbConfig["min_requests"].(int)
+		if minRequests == 0 {
+			minRequests = 20 // Default
+		}
+		return reliability.NewRollingBreaker(windowSize, failureRate, minRequests, timeout)
 	}
 
-	return d, nil
+	threshold, _ := cbConfig["threshold"].(int)
+	if threshold == 0 {
+		threshold = 5 // Default
+	}
+	breaker := reliability.NewThresholdBreaker(threshold, timeout)
+	if multiplier, _ := cbConfig["probe_backoff_multiplier"].(float64); multiplier > 1 {
+		maxTimeoutStr, _ := cbConfig["probe_backoff_max"].(string)
+		maxTimeout, _ := time.ParseDuration(maxTimeoutStr)
+		breaker.SetProbeBackoff(multiplier, maxTimeout)
+	}
+	return breaker
+}
+
+// newSerializer creates the named serializer, defaulting to JSON.
+// Without the envelope, values are stored as plain JSON or msgpack.
+// useNumber makes JSON decode numbers as json.Number.
+func newSerializer(name string, envelope, useNumber bool) serializer.Serializer {
+	switch {
+	case name == "msgpack" && envelope:
+		return serializer.NewMsgpackSerializer()
+	case name == "msgpack":
+		return serializer.NewRawMsgpackSerializer()
+	}
+
+	ser := serializer.NewJSONSerializer()
+	if !envelope {
+		ser = serializer.NewRawJSONSerializer()
+	}
+	if useNumber {
+		ser = ser.UseNumber()
+	}
+	return ser
+}
+
+// readSerializers reads the read_serializers option, a list of serializer names.
+func readSerializers(option interface{}) []string {
+	switch v := option.(type) {
+	case []string:
+		return v
+	case []interface{}:
+		names := make([]string, 0, len(v))
+		for _, name := range v {
+			if name, ok := name.(string); ok {
+				names = append(names, name)
+			}
+		}
+		return names
+	}
+	return nil
 }
 
 // NewDriverWithClient creates a new Redis cache driver with an existing client.
+// It serializes with JSON; use SetSerializer and SetCompressor to change that.
 func NewDriverWithClient(client *redis.Client, prefix string) *Driver {
-	return &Driver{
-		client:     client,
-		prefix:     prefix,
-		serializer: serializer.NewJSONSerializer(), // Default to JSON
+	d := &Driver{
+		client:         client,
+		prefix:         prefix,
+		separator:      ":",
+		baseSerializer: serializer.NewJSONSerializer(), // Default to JSON
 	}
+	d.buildSerializer()
+	return d
 }
 
-// prefixKey adds the prefix to the key.
+// SetSerializer replaces the serializer used to encode values.
+//
+// It is not safe to call concurrently with other operations: configure the
+// driver before first use. Values already stored with a different serializer
+// can no longer be read.
+func (d *Driver) SetSerializer(ser serializer.Serializer) {
+	d.baseSerializer = ser
+	d.buildSerializer()
+}
+
+// SetCompressor compresses encoded values with comp, or disables compression
+// when comp is nil. Like SetSerializer, it must be called before first use.
+func (d *Driver) SetCompressor(comp compression.Compressor) {
+	d.compressor = comp
+	d.buildSerializer()
+}
+
+// buildSerializer combines the base serializer with the compressor, if any,
+// and stamps the result with the schema version, if set.
+func (d *Driver) buildSerializer() {
+	d.serializer = d.baseSerializer
+	d.compression = ""
+	if d.compressor != nil {
+		d.serializer = serializer.NewCompressedSerializer(d.baseSerializer, d.compressor)
+		d.compression = "custom"
+		if _, ok := d.compressor.(*compression.GzipCompressor); ok {
+			d.compression = "gzip"
+		}
+	}
+
+	// The version stays outside compression, so a mismatch is found without decompressing
+	if d.schemaVersion > 0 {
+		d.serializer = serializer.NewVersionedSerializer(d.serializer, uint64(d.schemaVersion))
+	}
+}
+
+// prefixKey adds the prefix to the key, lowercasing the key first with
+// lowercase_keys.
 func (d *Driver) prefixKey(key string) string {
+	if d.lowercaseKeys {
+		key = strings.ToLower(key)
+	}
 	if d.prefix == "" {
 		return key
 	}
-	return d.prefix + ":" + key
+	return d.prefix + d.separator + key
 }
 
 // Get retrieves a value from the cache.
 func (d *Driver) Get(ctx context.Context, key string) (interface{}, error) {
-	data, err := d.client.Get(ctx, d.prefixKey(key)).Bytes()
+	var data []byte
+	var err error
+	if d.slidingTTL {
+		data, err = d.getSliding(ctx, d.prefixKey(key))
+	} else {
+		data, err = d.client.Get(ctx, d.prefixKey(key)).Bytes()
+	}
 	if err == redis.Nil {
 		d.recordMiss()
 		return nil, dgcache.ErrKeyNotFound
@@ -122,65 +367,249 @@ func (d *Driver) Get(ctx context.Context, key string) (interface{}, error) {
 		return nil, err
 	}
 
-	// Try to deserialize
-	var result interface{}
-	if err := d.serializer.Unmarshal(data, &result); err != nil {
-		// Fallback: return as string for backward compatibility
-		d.recordHit()
-		return string(data), nil
+	// Values the serializer rejects come back as strings for backward
+	// compatibility; values of another schema version are misses
+	result, ok := d.decodeValue(key, data)
+	if !ok {
+		d.recordMiss()
+		return nil, dgcache.ErrKeyNotFound
 	}
-
 	d.recordHit()
 	return result, nil
 }
 
-// GetMultiple retrieves multiple values from the cache.
-func (d *Driver) GetMultiple(ctx context.Context, keys []string) (map[string]interface{}, error) {
-	prefixedKeys := make([]string, len(keys))
-	for i, key := range keys {
-		prefixedKeys[i] = d.prefixKey(key)
-	}
-
-	vals, err := d.client.MGet(ctx, prefixedKeys...).Result()
+// Normalize runs value through the serializer round-trip, returning it as Get
+// would after a Put.
+func (d *Driver) Normalize(value interface{}) (interface{}, error) {
+	data, err := d.serializer.Marshal(value)
 	if err != nil {
 		return nil, err
 	}
+	var normalized interface{}
+	if err := d.serializer.Unmarshal(data, &normalized); err != nil {
+		return string(data), nil
+	}
+	return normalized, nil
+}
 
+// GetMultiple retrieves multiple values from the cache.
+func (d *Driver) GetMultiple(ctx context.Context, keys []string) (map[string]interface{}, error) {
 	result := make(map[string]interface{})
-	for i, val := range vals {
-		if val != nil {
-			// Convert to bytes for deserialization
-			var data []byte
-			switch v := val.(type) {
-			case string:
-				data = []byte(v)
-			case []byte:
-				data = v
-			default:
-				continue // Skip if not string or bytes
+
+	size := d.chunkSize(len(keys))
+	for start := 0; start < len(keys); start += size {
+		chunk := keys[start:min(start+size, len(keys))]
+
+		prefixedKeys := make([]string, len(chunk))
+		for i, key := range chunk {
+			prefixedKeys[i] = d.prefixKey(key)
+		}
+
+		vals, err := d.client.MGet(ctx, prefixedKeys...).Result()
+		if err != nil {
+			return nil, err
+		}
+
+		for i, val := range vals {
+			if value, ok := d.decodeValue(chunk[i], val); ok {
+				result[chunk[i]] = value
 			}
+		}
+	}
+
+	return result, nil
+}
+
+// GetMultipleWithTTL retrieves multiple values with their remaining TTL,
+// pipelining a GET and a PTTL per key.
+func (d *Driver) GetMultipleWithTTL(ctx context.Context, keys []string) (map[string]dgcache.ValueTTL, error) {
+	result := make(map[string]dgcache.ValueTTL)
+
+	// Two commands per key
+	size := max(d.chunkSize(2*len(keys))/2, 1)
+	for start := 0; start < len(keys); start += size {
+		chunk := keys[start:min(start+size, len(keys))]
+
+		pipe := d.client.Pipeline()
+		gets := make([]*redis.StringCmd, len(chunk))
+		ttls := make([]*redis.DurationCmd, len(chunk))
+		for i, key := range chunk {
+			gets[i] = pipe.Get(ctx, d.prefixKey(key))
+			ttls[i] = pipe.PTTL(ctx, d.prefixKey(key))
+		}
+		if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+			return nil, err
+		}
 
-			// Try to deserialize
-			var value interface{}
-			if err := d.serializer.Unmarshal(data, &value); err != nil {
-				// Fallback: use as string
-				result[keys[i]] = string(data)
-			} else {
-				result[keys[i]] = value
+		for i, key := range chunk {
+			data, err := gets[i].Result()
+			if err != nil {
+				continue
+			}
+			value, ok := d.decodeValue(key, data)
+			if !ok {
+				continue
+			}
+
+			// PTTL is -1 for keys without expiry
+			ttl := ttls[i].Val()
+			if ttl < 0 {
+				ttl = dgcache.NoExpiry
 			}
+			result[key] = dgcache.ValueTTL{Value: value, TTL: ttl}
 		}
 	}
 
 	return result, nil
 }
 
+// GetWithMeta retrieves a value with its remaining TTL, pipelining a GET and
+// a PTTL. Redis does not record when a value was written, so CreatedAt is
+// zero, and Tags are only looked up when meta_tags is set. Unlike Get, it
+// does not slide the expiry of sliding_ttl keys.
+func (d *Driver) GetWithMeta(ctx context.Context, key string) (interface{}, dgcache.ItemMeta, error) {
+	prefixedKey := d.prefixKey(key)
+	pipe := d.client.Pipeline()
+	get := pipe.Get(ctx, prefixedKey)
+	pttl := pipe.PTTL(ctx, prefixedKey)
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, dgcache.ItemMeta{}, err
+	}
+
+	data, err := get.Bytes()
+	if err == redis.Nil {
+		d.recordMiss()
+		return nil, dgcache.ItemMeta{}, dgcache.ErrKeyNotFound
+	}
+	if err != nil {
+		return nil, dgcache.ItemMeta{}, err
+	}
+	value, ok := d.decodeValue(key, data)
+	if !ok {
+		d.recordMiss()
+		return nil, dgcache.ItemMeta{}, dgcache.ErrKeyNotFound
+	}
+
+	// PTTL is -1 for keys without expiry
+	meta := dgcache.ItemMeta{TTL: pttl.Val()}
+	if meta.TTL < 0 {
+		meta.TTL = dgcache.NoExpiry
+	}
+	if d.metaTags {
+		if meta.Tags, err = d.tagsOf(ctx, prefixedKey); err != nil {
+			return nil, dgcache.ItemMeta{}, err
+		}
+	}
+	d.recordHit()
+	return value, meta, nil
+}
+
+// decodeValue deserializes a raw reply value read for key.
+// It returns false if the value is absent, not a string/bytes reply, or
+// written under another schema version. Other data the serializer rejects is
+// counted as a serialization error and returned as a string.
+func (d *Driver) decodeValue(key string, val interface{}) (interface{}, bool) {
+	// Convert to bytes for deserialization
+	var data []byte
+	switch v := val.(type) {
+	case string:
+		data = []byte(v)
+	case []byte:
+		data = v
+	default:
+		return nil, false // Skip if nil, or not string or bytes
+	}
+
+	// Try to deserialize
+	var value interface{}
+	if err := d.serializer.Unmarshal(data, &value); err != nil {
+		if errors.Is(err, serializer.ErrSchemaMismatch) {
+			return nil, false
+		}
+		// Fallback: use as string
+		d.serializationError("decode", key, err)
+		return string(data), true
+	}
+	return value, true
+}
+
+// marshal serializes the value written to key, counting a failure as a
+// serialization error. The returned error wraps ErrInvalidValue.
+func (d *Driver) marshal(key string, value interface{}) ([]byte, error) {
+	data, err := d.serializer.Marshal(value)
+	if err != nil {
+		d.serializationError("encode", key, err)
+		return nil, fmt.Errorf("%w: %q cannot be serialized with %s: %w", dgcache.ErrInvalidValue, key, d.serializer.Name(), err)
+	}
+	return data, nil
+}
+
+// serializationError counts a value of key that failed to encode or decode
+// and, unless disabled, logs the key. The value itself is never logged.
+func (d *Driver) serializationError(op, key string, err error) {
+	d.serializationErrors.Add(1)
+	if d.logSerializationErrors {
+		slog.Warn("cache: serialization failed",
+			"driver", "redis", "op", op, "key", key, "serializer", d.serializer.Name(), "error", err)
+	}
+}
+
+// SerializationErrors returns the number of values that failed to encode on
+// write or decode on read. Undecodable values are still returned as strings,
+// so this includes plain strings written to the prefix by other clients.
+func (d *Driver) SerializationErrors() int64 {
+	return d.serializationErrors.Load()
+}
+
+// chunkSize returns how many commands to send per pipeline for a batch of n operations.
+func (d *Driver) chunkSize(n int) int {
+	if d.maxPipelineSize > 0 && d.maxPipelineSize < n {
+		return d.maxPipelineSize
+	}
+	return n
+}
+
+// GetItem approximates the cache entry for key from GetWithMeta: ExpiresAt
+// is derived from the remaining TTL, CreatedAt is zero, and Tags are only
+// filled when meta_tags is set.
+func (d *Driver) GetItem(ctx context.Context, key string) (*dgcache.Item, error) {
+	value, meta, err := d.GetWithMeta(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	item := &dgcache.Item{Key: key, Value: value, Tags: meta.Tags}
+	if meta.TTL != dgcache.NoExpiry {
+		item.ExpiresAt = time.Now().Add(meta.TTL)
+	}
+	return item, nil
+}
+
 // Put stores a value in the cache with the given TTL.
 func (d *Driver) Put(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
-	data, err := d.serializer.Marshal(value)
+	if err := d.writable("put"); err != nil {
+		return err
+	}
+	ttl, err := d.ttlLimits.Apply(ttl)
 	if err != nil {
 		return err
 	}
-	err = d.client.Set(ctx, d.prefixKey(key), data, ttl).Err()
+
+	data, err := d.marshal(key, value)
+	if err != nil {
+		return err
+	}
+	if d.writeBehind != nil {
+		return d.writeBehind.enqueue(ctx, pendingWrite{key: d.prefixKey(key), data: data, ttl: ttl})
+	}
+	if d.slidingTTL && ttl > 0 {
+		pipe := d.client.TxPipeline()
+		pipe.Set(ctx, d.prefixKey(key), data, ttl)
+		d.recordSlide(ctx, pipe, d.prefixKey(key), ttl)
+		_, err = pipe.Exec(ctx)
+	} else {
+		err = d.client.Set(ctx, d.prefixKey(key), data, ttl).Err()
+	}
 	if err == nil {
 		d.recordSet()
 	}
@@ -189,27 +618,194 @@ func (d *Driver) Put(ctx context.Context, key string, value interface{}, ttl tim
 
 // PutMultiple stores multiple values in the cache.
 func (d *Driver) PutMultiple(ctx context.Context, items map[string]interface{}, ttl time.Duration) error {
-	pipe := d.client.Pipeline()
+	if err := d.writable("put_multiple"); err != nil {
+		return err
+	}
+	ttl, err := d.ttlLimits.Apply(ttl)
+	if err != nil {
+		return err
+	}
+
+	// Serialize everything up front so a failure never leaves a partial batch
+	encoded, skipped, err := d.marshalBatch(items)
+	if err != nil {
+		return err
+	}
+
+	if d.writeBehind != nil {
+		writes := make([]pendingWrite, 0, len(encoded))
+		for key, data := range encoded {
+			writes = append(writes, pendingWrite{key: d.prefixKey(key), data: data, ttl: ttl})
+		}
+		if err := d.writeBehind.enqueue(ctx, writes...); err != nil {
+			return err
+		}
+		return skipped
+	}
+
+	keys := make([]string, 0, len(encoded))
+	for key := range encoded {
+		keys = append(keys, key)
+	}
+
+	size := d.chunkSize(len(keys))
+	for start := 0; start < len(keys); start += size {
+		pipe := d.client.Pipeline()
+		for _, key := range keys[start:min(start+size, len(keys))] {
+			pipe.Set(ctx, d.prefixKey(key), encoded[key], ttl)
+			d.recordSlide(ctx, pipe, d.prefixKey(key), ttl)
+		}
+		if _, err := pipe.Exec(ctx); err != nil {
+			return err
+		}
+	}
+	return skipped
+}
+
+// marshalBatch serializes the values of a batch write.
+// Under the fail_fast policy the first failure is returned as err. Under
+// skip_errors, failing items are left out of encoded and reported in skipped,
+// which the caller returns once the rest of the batch is written.
+func (d *Driver) marshalBatch(items map[string]interface{}) (encoded map[string][]byte, skipped error, err error) {
+	encoded = make(map[string][]byte, len(items))
+	var failed map[string]error
 	for key, value := range items {
-		// Serialize each value
-		data, err := d.serializer.Marshal(value)
+		data, err := d.marshal(key, value)
 		if err != nil {
-			return err
+			if !d.skipSerializationErrors {
+				return nil, nil, err
+			}
+			if failed == nil {
+				failed = make(map[string]error)
+			}
+			failed[key] = err
+			continue
 		}
-		pipe.Set(ctx, d.prefixKey(key), data, ttl)
+		encoded[key] = data
 	}
-	_, err := pipe.Exec(ctx)
-	return err
+
+	if failed != nil {
+		skipped = &dgcache.BatchError{Errors: failed}
+	}
+	return encoded, skipped, nil
 }
 
 // Increment increments the value of a key.
+//
+// Counters bypass the serializer: INCRBY stores a plain integer. Incrementing a
+// value written by Put fails with ErrNotANumber unless it serialized as a bare
+// integer, which JSON does for integers but msgpack does not.
 func (d *Driver) Increment(ctx context.Context, key string, value int64) (int64, error) {
-	return d.client.IncrBy(ctx, d.prefixKey(key), value).Result()
+	if err := d.writable("increment"); err != nil {
+		return 0, err
+	}
+	if err := d.drainWrites(ctx); err != nil {
+		return 0, err
+	}
+	return d.counter(ctx, "INCRBY", key, value, 0)
+}
+
+// counterError turns Redis's rejection of a non-integer value into
+// ErrNotANumber and of a 64-bit overflow into ErrOverflow.
+func counterError(key string, err error) error {
+	if err == nil {
+		return nil
+	}
+	if strings.Contains(err.Error(), "not an integer") {
+		return fmt.Errorf("%w: %q does not hold an integer counter (values written with Put are serialized)", dgcache.ErrNotANumber, key)
+	}
+	if strings.Contains(err.Error(), "would overflow") {
+		return fmt.Errorf("%w: %q", dgcache.ErrOverflow, key)
+	}
+	return err
+}
+
+// counterScript runs the command in ARGV[3] (INCRBY or DECRBY) with ARGV[1]
+// on KEYS[1] and, if that creates the key, sets its expiry to ARGV[2]
+// milliseconds. With ARGV[4] set to "1" it refuses to create the key, for a
+// TTL the store's limits reject. The key is then added to the tag sets in
+// KEYS[2..].
+var counterScript = redis.NewScript(`
+	local created = redis.call("EXISTS", KEYS[1]) == 0
+	if created and ARGV[4] == "1" then
+		return redis.error_reply("ERR ttl out of range")
+	end
+	local value = redis.call(ARGV[3], KEYS[1], ARGV[1])
+	if created and tonumber(ARGV[2]) > 0 then
+		redis.call("PEXPIRE", KEYS[1], ARGV[2])
+	end
+	for i = 2, #KEYS do
+		redis.call("SADD", KEYS[i], KEYS[1])
+	end
+	return value
+`)
+
+// counterArgs returns the counterScript arguments for cmd. A key the command
+// creates expires after ttl as bounded by the TTL limits, so counters obey
+// max_ttl like values written with Forever. If the limits reject ttl, the
+// rejection is returned and the script only fails when it would create the key.
+func (d *Driver) counterArgs(cmd string, delta int64, ttl time.Duration) ([]interface{}, error) {
+	limited, rejected := d.ttlLimits.Apply(ttl)
+	ms := limited.Milliseconds()
+	if limited > 0 && ms == 0 {
+		ms = 1 // PEXPIRE granularity
+	}
+	reject := "0"
+	if rejected != nil {
+		reject = "1"
+	}
+	return []interface{}{delta, ms, cmd, reject}, rejected
+}
+
+// counterScriptError maps a counterScript error to the driver's errors.
+func counterScriptError(key string, err, rejected error) error {
+	if err != nil && rejected != nil && strings.Contains(err.Error(), "ttl out of range") {
+		return rejected
+	}
+	return counterError(key, err)
+}
+
+// counter runs cmd on key, setting ttl if that creates it. Without a TTL to
+// set it is a plain INCRBY or DECRBY.
+func (d *Driver) counter(ctx context.Context, cmd, key string, delta int64, ttl time.Duration) (int64, error) {
+	args, rejected := d.counterArgs(cmd, delta, ttl)
+	if args[1] == int64(0) && rejected == nil {
+		n, err := d.client.Do(ctx, cmd, d.prefixKey(key), delta).Int64()
+		return n, counterError(key, err)
+	}
+	n, err := counterScript.Run(ctx, d.client, []string{d.prefixKey(key)}, args...).Int64()
+	return n, counterScriptError(key, err, rejected)
+}
+
+// IncrementWithTTL increments the value of a key, setting ttl only when the
+// increment creates it.
+func (d *Driver) IncrementWithTTL(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	if err := d.writable("increment"); err != nil {
+		return 0, err
+	}
+	if err := d.drainWrites(ctx); err != nil {
+		return 0, err
+	}
+	return d.counter(ctx, "INCRBY", key, delta, ttl)
 }
 
 // Decrement decrements the value of a key.
 func (d *Driver) Decrement(ctx context.Context, key string, value int64) (int64, error) {
-	return d.client.DecrBy(ctx, d.prefixKey(key), value).Result()
+	if err := d.writable("decrement"); err != nil {
+		return 0, err
+	}
+	if err := d.drainWrites(ctx); err != nil {
+		return 0, err
+	}
+	return d.counter(ctx, "DECRBY", key, value, 0)
+}
+
+// writable rejects op with ErrReadOnly on a read replica store.
+func (d *Driver) writable(op string) error {
+	if d.readReplica {
+		return fmt.Errorf("%w: %s is not allowed on a read replica store", dgcache.ErrReadOnly, op)
+	}
+	return nil
 }
 
 // Forever stores a value in the cache indefinitely.
@@ -217,8 +813,79 @@ func (d *Driver) Forever(ctx context.Context, key string, value interface{}) err
 	return d.Put(ctx, key, value, 0)
 }
 
+// ExtendTTL sets the key to expire after ttl only if that is later than its current expiry.
+// It uses EXPIRE ... GT on Redis 7+ and falls back to a PTTL check on older servers.
+// ttl is bounded by the TTL limits first.
+func (d *Driver) ExtendTTL(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	if err := d.writable("extend_ttl"); err != nil {
+		return false, err
+	}
+	if err := d.drainWrites(ctx); err != nil {
+		return false, err
+	}
+	ttl, err := d.ttlLimits.Apply(ttl)
+	if err != nil {
+		return false, err
+	}
+	prefixedKey := d.prefixKey(key)
+
+	if !d.noExpireGT.Load() {
+		ok, err := d.client.ExpireGT(ctx, prefixedKey, ttl).Result()
+		if err == nil {
+			return ok, nil
+		}
+		if !isUnsupportedExpireFlag(err) {
+			return false, err
+		}
+		d.noExpireGT.Store(true)
+	}
+
+	current, err := d.client.PTTL(ctx, prefixedKey).Result()
+	if err != nil {
+		return false, err
+	}
+	// Negative values mean the key is missing or has no expiry.
+	if current < 0 || current >= ttl {
+		return false, nil
+	}
+	return d.client.PExpire(ctx, prefixedKey, ttl).Result()
+}
+
+// Expire sets the TTL of key with PEXPIRE, replacing any existing expiry.
+// The TTL limits apply as for a write, so a non-positive ttl removes the
+// expiry only when no max_ttl is set.
+func (d *Driver) Expire(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	if err := d.writable("expire"); err != nil {
+		return false, err
+	}
+	if err := d.drainWrites(ctx); err != nil {
+		return false, err
+	}
+	ttl, err := d.ttlLimits.Apply(ttl)
+	if err != nil {
+		return false, err
+	}
+	if ttl <= 0 {
+		return d.client.Persist(ctx, d.prefixKey(key)).Result()
+	}
+	return d.client.PExpire(ctx, d.prefixKey(key), ttl).Result()
+}
+
+// isUnsupportedExpireFlag reports whether err comes from a server that does not
+// understand the EXPIRE NX/XX/GT/LT flags (Redis < 7).
+func isUnsupportedExpireFlag(err error) bool {
+	msg := strings.ToLower(err.Error())
+	return strings.Contains(msg, "wrong number of arguments") || strings.Contains(msg, "syntax error")
+}
+
 // Forget removes a value from the cache.
 func (d *Driver) Forget(ctx context.Context, key string) error {
+	if err := d.writable("forget"); err != nil {
+		return err
+	}
+	if err := d.drainWrites(ctx); err != nil {
+		return err
+	}
 	err := d.client.Del(ctx, d.prefixKey(key)).Err()
 	if err == nil {
 		d.recordDelete()
@@ -228,16 +895,160 @@ func (d *Driver) Forget(ctx context.Context, key string) error {
 
 // ForgetMultiple removes multiple values from the cache.
 func (d *Driver) ForgetMultiple(ctx context.Context, keys []string) error {
-	prefixedKeys := make([]string, len(keys))
-	for i, key := range keys {
-		prefixedKeys[i] = d.prefixKey(key)
+	if err := d.writable("forget_multiple"); err != nil {
+		return err
+	}
+	if err := d.drainWrites(ctx); err != nil {
+		return err
+	}
+	size := d.chunkSize(len(keys))
+	for start := 0; start < len(keys); start += size {
+		chunk := keys[start:min(start+size, len(keys))]
+
+		prefixedKeys := make([]string, len(chunk))
+		for i, key := range chunk {
+			prefixedKeys[i] = d.prefixKey(key)
+		}
+
+		if err := d.client.Del(ctx, prefixedKeys...).Err(); err != nil {
+			return err
+		}
 	}
-	return d.client.Del(ctx, prefixedKeys...).Err()
+	return nil
 }
 
-// Flush removes all items from the cache.
+// Flush removes all items from the cache. With the default "prefix"
+// flush_scope only keys under the driver prefix are deleted, a page of SCAN
+// results at a time; with "db", or without a prefix, the database is flushed.
 func (d *Driver) Flush(ctx context.Context) error {
-	return d.client.FlushDB(ctx).Err()
+	if err := d.writable("flush"); err != nil {
+		return err
+	}
+	if err := d.drainWrites(ctx); err != nil {
+		return err
+	}
+	if d.flushesDB() {
+		return d.client.FlushDB(ctx).Err()
+	}
+	return d.scanDelete(ctx, nil)
+}
+
+// flushesDB reports whether Flush clears the whole database.
+func (d *Driver) flushesDB() bool {
+	return d.flushDB || d.prefix == ""
+}
+
+// SwapAll replaces the store contents with items in one MULTI/EXEC
+// transaction that removes the old keys and SETs the new ones, so readers see
+// either the old or the new dataset. Values are serialized before anything is
+// removed. Under the "prefix" flush_scope the old keys are found with SCAN
+// before the transaction, so a key written concurrently by another client may
+// survive the swap.
+func (d *Driver) SwapAll(ctx context.Context, items map[string]interface{}, ttl time.Duration) error {
+	if err := d.writable("swap_all"); err != nil {
+		return err
+	}
+	ttl, err := d.ttlLimits.Apply(ttl)
+	if err != nil {
+		return err
+	}
+	encoded, skipped, err := d.marshalBatch(items)
+	if err != nil {
+		return err
+	}
+	if err := d.drainWrites(ctx); err != nil {
+		return err
+	}
+
+	var old []string
+	if !d.flushesDB() {
+		if old, err = d.scopedKeys(ctx); err != nil {
+			return err
+		}
+	}
+
+	pipe := d.client.TxPipeline()
+	if d.flushesDB() {
+		pipe.FlushDB(ctx)
+	}
+	for start := 0; start < len(old); start += 1000 {
+		pipe.Del(ctx, old[start:min(start+1000, len(old))]...)
+	}
+	for key, data := range encoded {
+		pipe.Set(ctx, d.prefixKey(key), data, ttl)
+		d.recordSlide(ctx, pipe, d.prefixKey(key), ttl)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+	return skipped
+}
+
+// FlushExcept removes all keys under the driver prefix that do not match any of the patterns.
+// Keys are discovered with SCAN and deleted one page at a time.
+func (d *Driver) FlushExcept(ctx context.Context, patterns ...string) error {
+	if err := d.writable("flush"); err != nil {
+		return err
+	}
+	if err := d.drainWrites(ctx); err != nil {
+		return err
+	}
+	return d.scanDelete(ctx, func(key string) bool {
+		return dgcache.MatchAny(patterns, d.unprefixKey(key))
+	})
+}
+
+// scanMatch is the SCAN pattern covering every key under the driver prefix.
+func (d *Driver) scanMatch() string {
+	if d.prefix == "" {
+		return "*"
+	}
+	return d.prefix + d.separator + "*"
+}
+
+// scanDelete deletes the keys under the driver prefix for which keep, if
+// set, returns false. Keys are discovered with SCAN and deleted in batches.
+func (d *Driver) scanDelete(ctx context.Context, keep func(key string) bool) error {
+	iter := d.client.Scan(ctx, 0, d.scanMatch(), 1000).Iterator()
+	batch := make([]string, 0, 1000)
+	for iter.Next(ctx) {
+		key := iter.Val()
+		if keep != nil && keep(key) {
+			continue
+		}
+		batch = append(batch, key)
+		if len(batch) == cap(batch) {
+			if err := d.client.Del(ctx, batch...).Err(); err != nil {
+				return err
+			}
+			batch = batch[:0]
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+	if len(batch) > 0 {
+		return d.client.Del(ctx, batch...).Err()
+	}
+	return nil
+}
+
+// scopedKeys returns every key under the driver prefix, found with SCAN.
+func (d *Driver) scopedKeys(ctx context.Context) ([]string, error) {
+	var keys []string
+	iter := d.client.Scan(ctx, 0, d.scanMatch(), 1000).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	return keys, iter.Err()
+}
+
+// unprefixKey strips the driver prefix from a Redis key.
+func (d *Driver) unprefixKey(key string) string {
+	if d.prefix == "" {
+		return key
+	}
+	return strings.TrimPrefix(key, d.prefix+d.separator)
 }
 
 // Has checks if a key exists in the cache.
@@ -249,6 +1060,32 @@ func (d *Driver) Has(ctx context.Context, key string) (bool, error) {
 	return n > 0, nil
 }
 
+// HasMultiple checks whether each key exists, pipelining one EXISTS per key
+// since EXISTS with several keys only returns how many of them exist.
+func (d *Driver) HasMultiple(ctx context.Context, keys []string) (map[string]bool, error) {
+	result := make(map[string]bool, len(keys))
+
+	size := d.chunkSize(len(keys))
+	for start := 0; start < len(keys); start += size {
+		chunk := keys[start:min(start+size, len(keys))]
+
+		pipe := d.client.Pipeline()
+		exists := make([]*redis.IntCmd, len(chunk))
+		for i, key := range chunk {
+			exists[i] = pipe.Exists(ctx, d.prefixKey(key))
+		}
+		if _, err := pipe.Exec(ctx); err != nil {
+			return nil, err
+		}
+
+		for i, key := range chunk {
+			result[key] = exists[i].Val() > 0
+		}
+	}
+
+	return result, nil
+}
+
 // Missing checks if a key does not exist in the cache.
 func (d *Driver) Missing(ctx context.Context, key string) (bool, error) {
 	has, err := d.Has(ctx, key)
@@ -270,7 +1107,29 @@ func (d *Driver) Name() string {
 	return "redis"
 }
 
+// Ping checks the connection to the Redis server.
+func (d *Driver) Ping(ctx context.Context) error {
+	return d.client.Ping(ctx).Err()
+}
+
+// Info returns the driver's effective configuration.
+func (d *Driver) Info() dgcache.DriverInfo {
+	return dgcache.DriverInfo{
+		Driver:      d.Name(),
+		Serializer:  d.serializer.Name(),
+		Compression: d.compression,
+		Prefix:      d.prefix,
+	}
+}
+
 // Close closes the driver and releases resources.
+// Writes queued in write-behind mode are flushed first.
 func (d *Driver) Close() error {
+	if d.writeBehind != nil {
+		d.writeBehind.close()
+	}
+	if d.stopPruner != nil {
+		d.stopPruner()
+	}
 	return d.client.Close()
 }
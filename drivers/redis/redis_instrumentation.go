@@ -3,10 +3,13 @@ package redis
 import (
 	"sync/atomic"
 
+	dgcache "github.com/donnigundala/dg-cache"
 	"github.com/donnigundala/dg-core/contracts/cache"
+	"github.com/redis/go-redis/v9"
 )
 
 // Stats returns the current cache statistics.
+// The counters stay at zero unless metrics are enabled for the store.
 func (d *Driver) Stats() cache.Stats {
 	return cache.Stats{
 		Hits:    atomic.LoadInt64(&d.metrics.Hits),
@@ -18,20 +21,48 @@ func (d *Driver) Stats() cache.Stats {
 
 // recordHit increments the hit counter.
 func (d *Driver) recordHit() {
-	atomic.AddInt64(&d.metrics.Hits, 1)
+	if d.metricsEnabled {
+		atomic.AddInt64(&d.metrics.Hits, 1)
+	}
 }
 
 // recordMiss increments the miss counter.
 func (d *Driver) recordMiss() {
-	atomic.AddInt64(&d.metrics.Misses, 1)
+	if d.metricsEnabled {
+		atomic.AddInt64(&d.metrics.Misses, 1)
+	}
 }
 
 // recordSet increments the set counter.
 func (d *Driver) recordSet() {
-	atomic.AddInt64(&d.metrics.Sets, 1)
+	if d.metricsEnabled {
+		atomic.AddInt64(&d.metrics.Sets, 1)
+	}
 }
 
 // recordDelete increments the delete counter.
 func (d *Driver) recordDelete() {
-	atomic.AddInt64(&d.metrics.Deletes, 1)
+	if d.metricsEnabled {
+		atomic.AddInt64(&d.metrics.Deletes, 1)
+	}
+}
+
+// PoolStats returns the go-redis statistics of the client's connection pool.
+// Stores sharing a client report the same pool.
+func (d *Driver) PoolStats() redis.PoolStats {
+	return *d.client.PoolStats()
+}
+
+// PoolUsage returns the state of the client's connection pool.
+// It implements dgcache.PoolReporter.
+func (d *Driver) PoolUsage() dgcache.PoolStats {
+	stats := d.PoolStats()
+	return dgcache.PoolStats{
+		Hits:       stats.Hits,
+		Misses:     stats.Misses,
+		Timeouts:   stats.Timeouts,
+		TotalConns: stats.TotalConns,
+		IdleConns:  stats.IdleConns,
+		StaleConns: stats.StaleConns,
+	}
 }
@@ -1,7 +1,11 @@
 package redis_test
 
 import (
+	"bytes"
 	"context"
+	"fmt"
+	"log/slog"
+	"math"
 	"strconv"
 	"strings"
 	"testing"
@@ -9,13 +13,24 @@ import (
 
 	"github.com/alicebob/miniredis/v2"
 	dgcache "github.com/donnigundala/dg-cache"
+	"github.com/donnigundala/dg-cache/compression"
+	"github.com/donnigundala/dg-cache/drivers/memory"
 	driver "github.com/donnigundala/dg-cache/drivers/redis"
+	"github.com/donnigundala/dg-cache/reliability"
+	"github.com/donnigundala/dg-cache/serializer"
 	"github.com/donnigundala/dg-core/contracts/cache"
+	goredis "github.com/redis/go-redis/v9"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
 func createDriver(t *testing.T) (cache.Driver, *miniredis.Miniredis) {
+	return createDriverWithOptions(t, nil)
+}
+
+// createDriverWithOptions starts a miniredis server and connects a driver to it,
+// merging the given options over the connection settings.
+func createDriverWithOptions(t *testing.T, options map[string]interface{}) (cache.Driver, *miniredis.Miniredis) {
 	s, err := miniredis.Run()
 	require.NoError(t, err)
 
@@ -31,6 +46,9 @@ func createDriver(t *testing.T) (cache.Driver, *miniredis.Miniredis) {
 			"port": port,
 		},
 	}
+	for key, value := range options {
+		cfg.Options[key] = value
+	}
 
 	d, err := driver.NewDriver(cfg)
 	require.NoError(t, err)
@@ -292,3 +310,2014 @@ func TestRedis_GettersSetters(t *testing.T) {
 	d.SetPrefix("new_prefix")
 	assert.Equal(t, "new_prefix", d.GetPrefix())
 }
+
+func TestRedis_ExtendTTL(t *testing.T) {
+	d, s := createDriver(t)
+	defer s.Close()
+	defer d.Close()
+
+	ctx := context.Background()
+	rd := d.(*driver.Driver)
+
+	require.NoError(t, d.Put(ctx, "key", "value", 1*time.Minute))
+
+	// A shorter TTL must not shorten the expiry
+	extended, err := rd.ExtendTTL(ctx, "key", 1*time.Second)
+	assert.NoError(t, err)
+	assert.False(t, extended)
+	assert.Equal(t, 1*time.Minute, s.TTL("test:key"))
+
+	// A longer TTL extends it
+	extended, err = rd.ExtendTTL(ctx, "key", 1*time.Hour)
+	assert.NoError(t, err)
+	assert.True(t, extended)
+	assert.Equal(t, 1*time.Hour, s.TTL("test:key"))
+
+	// Keys without expiry are left alone
+	require.NoError(t, d.Forever(ctx, "forever", "value"))
+	extended, err = rd.ExtendTTL(ctx, "forever", 1*time.Hour)
+	assert.NoError(t, err)
+	assert.False(t, extended)
+	assert.Equal(t, time.Duration(0), s.TTL("test:forever"))
+}
+
+func TestRedis_MaxPipelineSize(t *testing.T) {
+	d, s := createDriverWithOptions(t, map[string]interface{}{
+		"max_pipeline_size": 10,
+	})
+	defer s.Close()
+	defer d.Close()
+
+	ctx := context.Background()
+
+	items := make(map[string]interface{})
+	keys := make([]string, 0, 25)
+	for i := 0; i < 25; i++ {
+		key := "k" + strconv.Itoa(i)
+		items[key] = i
+		keys = append(keys, key)
+	}
+
+	err := d.PutMultiple(ctx, items, 1*time.Minute)
+	assert.NoError(t, err)
+	assert.Len(t, s.Keys(), 25)
+
+	vals, err := d.GetMultiple(ctx, keys)
+	assert.NoError(t, err)
+	assert.Len(t, vals, 25)
+
+	err = d.ForgetMultiple(ctx, keys)
+	assert.NoError(t, err)
+	assert.Empty(t, s.Keys())
+}
+
+func TestRedis_Info(t *testing.T) {
+	d, s := createDriverWithOptions(t, map[string]interface{}{
+		"serializer":  "msgpack",
+		"compression": "gzip",
+	})
+	defer s.Close()
+	defer d.Close()
+
+	info := d.(dgcache.Introspectable).Info()
+	assert.Equal(t, "redis", info.Driver)
+	assert.Equal(t, "msgpack", info.Serializer)
+	assert.Equal(t, "gzip", info.Compression)
+	assert.Equal(t, "test", info.Prefix)
+}
+
+func TestRedis_PoolStats(t *testing.T) {
+	d, s := createDriver(t)
+	defer s.Close()
+	defer d.Close()
+
+	ctx := context.Background()
+	for i := 0; i < 5; i++ {
+		require.NoError(t, d.Put(ctx, "key", i, time.Minute))
+		_, err := d.Get(ctx, "key")
+		require.NoError(t, err)
+	}
+
+	stats := d.(*driver.Driver).PoolStats()
+	assert.NotZero(t, stats.TotalConns)
+	assert.NotZero(t, stats.Hits+stats.Misses)
+	assert.Equal(t, stats.TotalConns, d.(dgcache.PoolReporter).PoolUsage().TotalConns)
+}
+
+func TestRedis_ManagerDebug(t *testing.T) {
+	s := miniredis.RunT(t)
+	port, _ := strconv.Atoi(s.Port())
+	cfg := dgcache.DefaultConfig().
+		WithMetrics(true).
+		WithStore("redis", dgcache.StoreConfig{
+			Driver: "redis",
+			Prefix: "app",
+			Options: map[string]interface{}{
+				"host":        s.Host(),
+				"port":        port,
+				"serializer":  "msgpack",
+				"compression": "gzip",
+			},
+		})
+	manager, err := dgcache.NewManager(cfg)
+	require.NoError(t, err)
+	defer manager.Close()
+
+	ctx := context.Background()
+	require.NoError(t, manager.PutIn(ctx, "redis", "key", "value", time.Minute))
+	_, err = manager.GetIn(ctx, "redis", "key")
+	require.NoError(t, err)
+	_, err = manager.GetIn(ctx, "redis", "missing")
+	require.ErrorIs(t, err, dgcache.ErrKeyNotFound)
+
+	info := manager.Debug()
+	assert.Equal(t, "memory", info.DefaultStore)
+	require.Len(t, info.Stores, 2)
+
+	store := info.Stores["redis"]
+	assert.Empty(t, store.Error)
+	assert.Equal(t, "redis", store.Driver)
+	assert.Equal(t, "app", store.Prefix)
+	assert.Equal(t, "msgpack", store.Serializer)
+	assert.Equal(t, "gzip", store.Compression)
+	assert.Equal(t, int64(1), store.Stats.Hits)
+	assert.Equal(t, int64(1), store.Stats.Misses)
+	assert.Equal(t, 0.5, store.HitRate)
+	require.NotNil(t, store.Pool)
+	assert.NotZero(t, store.Pool.TotalConns)
+
+	memory := info.Stores["memory"]
+	assert.Equal(t, "memory", memory.Driver)
+	assert.Nil(t, memory.Pool)
+}
+
+func TestRedis_FlushExcept(t *testing.T) {
+	d, s := createDriver(t)
+	defer s.Close()
+	defer d.Close()
+
+	ctx := context.Background()
+
+	d.Put(ctx, "config:flags", "on", 0)
+	d.Put(ctx, "config:limits", "10", 0)
+	d.Put(ctx, "user:1", "john", 0)
+	d.Put(ctx, "session:abc", "data", 0)
+	s.Set("other:key", "untouched")
+
+	err := d.(dgcache.SelectiveFlusher).FlushExcept(ctx, "config:*")
+	assert.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{"other:key", "test:config:flags", "test:config:limits"}, s.Keys())
+}
+
+func TestRedis_GetField(t *testing.T) {
+	d, s := createDriver(t)
+	defer s.Close()
+	defer d.Close()
+
+	ctx := context.Background()
+	rd := d.(dgcache.FieldReader)
+
+	type Address struct {
+		City string `json:"city"`
+	}
+	type User struct {
+		Name    string   `json:"name"`
+		Address Address  `json:"address"`
+		Roles   []string `json:"roles"`
+	}
+
+	err := d.Put(ctx, "user:1", User{Name: "John", Address: Address{City: "Jakarta"}, Roles: []string{"admin"}}, 1*time.Minute)
+	require.NoError(t, err)
+
+	city, err := rd.GetField(ctx, "user:1", "address.city")
+	assert.NoError(t, err)
+	assert.Equal(t, "Jakarta", city)
+
+	role, err := rd.GetField(ctx, "user:1", "$.roles.0")
+	assert.NoError(t, err)
+	assert.Equal(t, "admin", role)
+
+	_, err = rd.GetField(ctx, "user:1", "address.zip")
+	assert.ErrorIs(t, err, dgcache.ErrFieldNotFound)
+
+	_, err = rd.GetField(ctx, "missing", "name")
+	assert.ErrorIs(t, err, dgcache.ErrKeyNotFound)
+}
+
+func TestRedis_PrefixSeparator(t *testing.T) {
+	d, s := createDriverWithOptions(t, map[string]interface{}{
+		"prefix_separator": "/",
+	})
+	defer s.Close()
+	defer d.Close()
+
+	ctx := context.Background()
+
+	err := d.(cache.TaggedStore).Tags("users").Put(ctx, "user:1", "john", 1*time.Minute)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"test/user:1", "test/tag/users"}, s.Keys())
+
+	members, err := s.Members("test/tag/users")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"test/user:1"}, members)
+
+	// Flushing the tag must use the same convention
+	err = d.(cache.TaggedStore).Tags("users").Flush(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, s.Keys())
+}
+
+func TestRedis_Rename(t *testing.T) {
+	d, s := createDriver(t)
+	defer s.Close()
+	defer d.Close()
+
+	ctx := context.Background()
+	renamer := d.(dgcache.Renamer)
+
+	require.NoError(t, d.(cache.TaggedStore).Tags("indexes").Put(ctx, "index:building", "data", 1*time.Minute))
+	s.FastForward(10 * time.Second)
+
+	err := renamer.Rename(ctx, "index:building", "index:live")
+	require.NoError(t, err)
+
+	val, err := d.Get(ctx, "index:live")
+	assert.NoError(t, err)
+	assert.Equal(t, "data", val)
+	assert.Equal(t, 50*time.Second, s.TTL("test:index:live"))
+
+	has, _ := d.Has(ctx, "index:building")
+	assert.False(t, has)
+
+	members, err := s.Members("test:tag:indexes")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"test:index:live"}, members)
+
+	err = renamer.Rename(ctx, "missing", "other")
+	assert.ErrorIs(t, err, dgcache.ErrKeyNotFound)
+
+	// The destination loses the tags of the value it replaced
+	require.NoError(t, d.(cache.TaggedStore).Tags("drafts").Put(ctx, "index:next", "draft", 0))
+	require.NoError(t, renamer.Rename(ctx, "index:live", "index:next"))
+	members, err = s.Members("test:tag:indexes")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"test:index:next"}, members)
+	assert.False(t, s.Exists("test:tag:drafts"))
+}
+
+func TestRedis_TimeRoundTrip(t *testing.T) {
+	for _, name := range []string{"json", "msgpack"} {
+		t.Run(name, func(t *testing.T) {
+			d, s := createDriverWithOptions(t, map[string]interface{}{
+				"serializer": name,
+			})
+			defer s.Close()
+			defer d.Close()
+
+			ctx := context.Background()
+			now := time.Date(2025, 6, 1, 12, 30, 0, 0, time.UTC)
+
+			require.NoError(t, d.Put(ctx, "time", now, 1*time.Minute))
+
+			val, err := d.Get(ctx, "time")
+			require.NoError(t, err)
+			restored, ok := val.(time.Time)
+			require.True(t, ok, "expected time.Time, got %T", val)
+			assert.True(t, now.Equal(restored))
+		})
+	}
+}
+
+func TestRedis_MaxTTL(t *testing.T) {
+	d, s := createDriverWithOptions(t, map[string]interface{}{
+		"max_ttl": "24h",
+	})
+	defer s.Close()
+	defer d.Close()
+
+	ctx := context.Background()
+
+	require.NoError(t, d.Forever(ctx, "forever", "value"))
+	assert.Equal(t, 24*time.Hour, s.TTL("test:forever"))
+
+	require.NoError(t, d.Put(ctx, "long", "value", 48*time.Hour))
+	assert.Equal(t, 24*time.Hour, s.TTL("test:long"))
+
+	require.NoError(t, d.PutMultiple(ctx, map[string]interface{}{"a": 1}, 0))
+	assert.Equal(t, 24*time.Hour, s.TTL("test:a"))
+
+	require.NoError(t, d.(cache.TaggedStore).Tags("users").Put(ctx, "user:1", "john", 0))
+	assert.Equal(t, 24*time.Hour, s.TTL("test:user:1"))
+
+	d2, s2 := createDriverWithOptions(t, map[string]interface{}{
+		"max_ttl":    "1h",
+		"ttl_policy": "reject",
+	})
+	defer s2.Close()
+	defer d2.Close()
+
+	err := d2.Put(ctx, "long", "value", 2*time.Hour)
+	assert.ErrorIs(t, err, dgcache.ErrTTLOutOfRange)
+	assert.False(t, s2.Exists("test:long"))
+}
+
+func TestRedis_TTLLimitsOnExpiryChanges(t *testing.T) {
+	d, s := createDriverWithOptions(t, map[string]interface{}{
+		"max_ttl": "1h",
+	})
+	defer s.Close()
+	defer d.Close()
+	ctx := context.Background()
+	tagged := d.(cache.TaggedStore).Tags("counters")
+
+	// Counters created without a TTL get max_ttl and keep it when incremented
+	for name, incr := range map[string]func(string) (int64, error){
+		"increment": func(key string) (int64, error) { return d.Increment(ctx, key, 1) },
+		"decrement": func(key string) (int64, error) { return d.Decrement(ctx, key, 1) },
+		"with_ttl": func(key string) (int64, error) {
+			return d.(dgcache.TTLIncrementer).IncrementWithTTL(ctx, key, 1, 48*time.Hour)
+		},
+		"tagged": func(key string) (int64, error) { return tagged.Increment(ctx, key, 1) },
+	} {
+		_, err := incr(name)
+		require.NoError(t, err, name)
+		assert.Equal(t, time.Hour, s.TTL("test:"+name), name)
+	}
+	members, err := s.Members("test:tag:counters")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"test:tagged"}, members)
+
+	require.NoError(t, d.Put(ctx, "key", "value", time.Minute))
+	ok, err := d.(dgcache.TTLExtender).ExtendTTL(ctx, "key", 48*time.Hour)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, time.Hour, s.TTL("test:key"))
+
+	expirer := d.(dgcache.Expirer)
+	s.SetTTL("test:key", time.Minute)
+	ok, err = expirer.Expire(ctx, "key", 48*time.Hour)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, time.Hour, s.TTL("test:key"))
+
+	// Removing the expiry would exceed max_ttl too
+	s.SetTTL("test:key", time.Minute)
+	ok, err = expirer.Expire(ctx, "key", 0)
+	require.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, time.Hour, s.TTL("test:key"))
+
+	rejecting, s2 := createDriverWithOptions(t, map[string]interface{}{
+		"max_ttl":    "1h",
+		"ttl_policy": "reject",
+	})
+	defer s2.Close()
+	defer rejecting.Close()
+
+	_, err = rejecting.Increment(ctx, "counter", 1)
+	assert.ErrorIs(t, err, dgcache.ErrTTLOutOfRange)
+	_, err = rejecting.(cache.TaggedStore).Tags("counters").Increment(ctx, "counter", 1)
+	assert.ErrorIs(t, err, dgcache.ErrTTLOutOfRange)
+	assert.False(t, s2.Exists("test:counter"))
+	assert.False(t, s2.Exists("test:tag:counters"))
+
+	n, err := rejecting.(dgcache.TTLIncrementer).IncrementWithTTL(ctx, "counter", 1, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	// Existing counters keep their TTL, so nothing is rejected
+	n, err = rejecting.Increment(ctx, "counter", 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+
+	_, err = rejecting.(dgcache.Expirer).Expire(ctx, "counter", 0)
+	assert.ErrorIs(t, err, dgcache.ErrTTLOutOfRange)
+	assert.Equal(t, time.Minute, s2.TTL("test:counter"))
+}
+
+func TestRedis_TTLLimitsInvalid(t *testing.T) {
+	for name, value := range map[string]interface{}{
+		"unparsable": "one hour",
+		"int":        3600,
+		"float":      1.5,
+	} {
+		_, err := driver.NewDriver(dgcache.StoreConfig{
+			Driver:  "redis",
+			Options: map[string]interface{}{"max_ttl": value},
+		})
+		assert.ErrorContains(t, err, "invalid config", name)
+	}
+
+	_, err := driver.NewDriver(dgcache.StoreConfig{
+		Driver:  "redis",
+		Options: map[string]interface{}{"min_ttl": "1h", "max_ttl": "1m"},
+	})
+	assert.ErrorContains(t, err, "invalid config")
+}
+
+func TestRedis_MinTTL(t *testing.T) {
+	d, s := createDriverWithOptions(t, map[string]interface{}{
+		"min_ttl": "1s",
+	})
+	defer s.Close()
+	defer d.Close()
+
+	ctx := context.Background()
+
+	require.NoError(t, d.Put(ctx, "short", "value", time.Millisecond))
+	assert.Equal(t, time.Second, s.TTL("test:short"))
+
+	require.NoError(t, d.Forever(ctx, "forever", "value"))
+	assert.Equal(t, time.Duration(0), s.TTL("test:forever"))
+}
+
+func TestRedis_Transaction(t *testing.T) {
+	d, s := createDriver(t)
+	defer s.Close()
+	defer d.Close()
+
+	ctx := context.Background()
+	transactional := d.(dgcache.Transactional)
+
+	err := transactional.Transaction(ctx, func(tx cache.Store) error {
+		require.NoError(t, tx.Put(ctx, "a", "1", 1*time.Minute))
+		require.NoError(t, tx.Put(ctx, "b", "2", 1*time.Minute))
+
+		// Queued writes are not applied until EXEC
+		assert.False(t, s.Exists("test:a"))
+		val, err := tx.Get(ctx, "a")
+		assert.NoError(t, err)
+		assert.Equal(t, "1", val)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.True(t, s.Exists("test:a"))
+	assert.True(t, s.Exists("test:b"))
+
+	// An error from fn discards all queued writes
+	err = transactional.Transaction(ctx, func(tx cache.Store) error {
+		require.NoError(t, tx.Put(ctx, "c", "3", 1*time.Minute))
+		require.NoError(t, tx.Forget(ctx, "a"))
+		return assert.AnError
+	})
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.False(t, s.Exists("test:c"))
+	assert.True(t, s.Exists("test:a"))
+
+	// A concurrent change to a key read in the transaction aborts it
+	err = transactional.Transaction(ctx, func(tx cache.Store) error {
+		if _, err := tx.Get(ctx, "b"); err != nil {
+			return err
+		}
+		require.NoError(t, d.Put(ctx, "b", "changed", 1*time.Minute))
+		return tx.Put(ctx, "c", "3", 1*time.Minute)
+	})
+	assert.ErrorIs(t, err, dgcache.ErrTransactionConflict)
+	assert.False(t, s.Exists("test:c"))
+}
+
+func TestRedis_SerializerWithoutEnvelope(t *testing.T) {
+	d, s := createDriverWithOptions(t, map[string]interface{}{
+		"serializer_envelope": false,
+	})
+	defer s.Close()
+	defer d.Close()
+
+	ctx := context.Background()
+	user := map[string]interface{}{"name": "john", "roles": []string{"admin"}}
+
+	require.NoError(t, d.Put(ctx, "user", user, 1*time.Minute))
+
+	raw, err := s.Get("test:user")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"john","roles":["admin"]}`, raw)
+	assert.NotContains(t, raw, `"type"`)
+
+	val, err := d.Get(ctx, "user")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"name": "john", "roles": []interface{}{"admin"}}, val)
+}
+
+func TestRedis_RememberNormalizesType(t *testing.T) {
+	s, err := miniredis.Run()
+	require.NoError(t, err)
+	defer s.Close()
+
+	port, _ := strconv.Atoi(s.Port())
+	cfg := dgcache.DefaultConfig().
+		WithDefaultStore("redis").
+		WithStore("redis", dgcache.StoreConfig{
+			Driver: "redis",
+			Options: map[string]interface{}{
+				"host": s.Host(),
+				"port": port,
+			},
+		})
+	manager, err := dgcache.NewManager(cfg)
+	require.NoError(t, err)
+	defer manager.Close()
+
+	type user struct {
+		Name string
+	}
+
+	ctx := context.Background()
+	callback := func() (interface{}, error) {
+		return user{Name: "john"}, nil
+	}
+
+	first, err := manager.Remember(ctx, "user", 1*time.Minute, callback)
+	require.NoError(t, err)
+	second, err := manager.Remember(ctx, "user", 1*time.Minute, callback)
+	require.NoError(t, err)
+
+	assert.IsType(t, second, first)
+	assert.Equal(t, second, first)
+}
+
+func TestRedis_PutMultipleSerializationErrorPolicy(t *testing.T) {
+	items := map[string]interface{}{
+		"good1": "value1",
+		"good2": map[string]interface{}{"a": 1},
+		"bad":   make(chan int),
+	}
+	ctx := context.Background()
+
+	t.Run("fail_fast", func(t *testing.T) {
+		d, s := createDriver(t)
+		defer s.Close()
+		defer d.Close()
+
+		err := d.PutMultiple(ctx, items, 1*time.Minute)
+		assert.Error(t, err)
+
+		// Nothing is written when the batch aborts
+		assert.Empty(t, s.Keys())
+	})
+
+	t.Run("skip_errors", func(t *testing.T) {
+		d, s := createDriverWithOptions(t, map[string]interface{}{
+			"serialization_error_policy": "skip_errors",
+		})
+		defer s.Close()
+		defer d.Close()
+
+		err := d.PutMultiple(ctx, items, 1*time.Minute)
+		var batchErr *dgcache.BatchError
+		require.ErrorAs(t, err, &batchErr)
+		assert.Equal(t, []string{"bad"}, batchErr.Keys())
+
+		assert.ElementsMatch(t, []string{"test:good1", "test:good2"}, s.Keys())
+
+		err = d.(cache.TaggedStore).Tags("t").PutMultiple(ctx, items, 1*time.Minute)
+		require.ErrorAs(t, err, &batchErr)
+		members, _ := s.Members("test:tag:t")
+		assert.ElementsMatch(t, []string{"test:good1", "test:good2"}, members)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		s := miniredis.RunT(t)
+		port, _ := strconv.Atoi(s.Port())
+		_, err := driver.NewDriver(dgcache.StoreConfig{
+			Driver: "redis",
+			Options: map[string]interface{}{
+				"host":                       s.Host(),
+				"port":                       port,
+				"serialization_error_policy": "ignore",
+			},
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestRedis_GetWithFallbackConsistentTypes(t *testing.T) {
+	s, err := miniredis.Run()
+	require.NoError(t, err)
+	defer s.Close()
+
+	port, _ := strconv.Atoi(s.Port())
+	cfg := dgcache.DefaultConfig().
+		WithFallbackBackfillTTL(1*time.Minute).
+		WithStore("l1", dgcache.StoreConfig{Driver: "memory"}).
+		WithStore("l2", dgcache.StoreConfig{
+			Driver: "redis",
+			Options: map[string]interface{}{
+				"host": s.Host(),
+				"port": port,
+			},
+		})
+	manager, err := dgcache.NewManager(cfg)
+	require.NoError(t, err)
+	defer manager.Close()
+
+	type user struct {
+		Name string
+	}
+
+	ctx := context.Background()
+	l1, err := manager.Store("l1")
+	require.NoError(t, err)
+	l2, err := manager.Store("l2")
+	require.NoError(t, err)
+	require.NoError(t, l1.Put(ctx, "user", user{Name: "john"}, 1*time.Minute))
+	require.NoError(t, l2.Put(ctx, "user", user{Name: "john"}, 1*time.Minute))
+
+	fromL1, err := manager.GetWithFallback(ctx, "user", "l1", "l2")
+	require.NoError(t, err)
+
+	// Evict from L1 so the next read is served by L2 and backfilled
+	require.NoError(t, l1.Forget(ctx, "user"))
+	fromL2, err := manager.GetWithFallback(ctx, "user", "l1", "l2")
+	require.NoError(t, err)
+
+	backfilled, err := manager.GetWithFallback(ctx, "user", "l1", "l2")
+	require.NoError(t, err)
+
+	assert.IsType(t, fromL2, fromL1)
+	assert.Equal(t, fromL2, fromL1)
+	assert.Equal(t, fromL2, backfilled)
+}
+
+func TestRedis_KeysForTag(t *testing.T) {
+	d, s := createDriver(t)
+	defer s.Close()
+	defer d.Close()
+
+	ctx := context.Background()
+	tagged := d.(cache.TaggedStore)
+	introspectable := d.(dgcache.TagIntrospectable)
+
+	require.NoError(t, tagged.Tags("users").Put(ctx, "user:2", "jane", 1*time.Minute))
+	require.NoError(t, tagged.Tags("users", "admins").Put(ctx, "user:1", "john", 1*time.Minute))
+
+	keys, err := introspectable.KeysForTag(ctx, "users")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user:1", "user:2"}, keys)
+
+	keys, err = introspectable.KeysForTag(ctx, "admins")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user:1"}, keys)
+
+	require.NoError(t, introspectable.FlushTags(ctx, "admins"))
+	assert.False(t, s.Exists("test:user:1"))
+	assert.True(t, s.Exists("test:user:2"))
+}
+
+// cancelAfterCtx reports itself cancelled once Err has been checked n times.
+type cancelAfterCtx struct {
+	context.Context
+	n int
+}
+
+func (c *cancelAfterCtx) Err() error {
+	if c.n <= 0 {
+		return context.Canceled
+	}
+	c.n--
+	return nil
+}
+
+func TestRedis_FlushTagsIncremental(t *testing.T) {
+	const total = 5000
+
+	d, s := createDriverWithOptions(t, map[string]interface{}{
+		"flush_tags_mode":       "incremental",
+		"flush_tags_batch_size": 100,
+	})
+	defer s.Close()
+	defer d.Close()
+
+	ctx := context.Background()
+	items := make(map[string]interface{}, total)
+	for i := 0; i < total; i++ {
+		items[fmt.Sprintf("item:%d", i)] = i
+	}
+	tagged := d.(cache.TaggedStore).Tags("bulk")
+	require.NoError(t, tagged.PutMultiple(ctx, items, 1*time.Minute))
+	require.NoError(t, d.Put(ctx, "untagged", "value", 1*time.Minute))
+
+	// A cancelled context stops the flush between batches
+	err := tagged.Flush(&cancelAfterCtx{Context: ctx, n: 3})
+	assert.ErrorIs(t, err, context.Canceled)
+	remaining := len(s.Keys())
+	assert.Greater(t, remaining, 2)
+	assert.Less(t, remaining, total+2)
+	assert.True(t, s.Exists("test:tag:bulk"))
+
+	// Retrying completes the flush
+	require.NoError(t, tagged.Flush(ctx))
+	assert.Equal(t, []string{"test:untagged"}, s.Keys())
+}
+
+func TestRedis_FlushTagKeysOnly(t *testing.T) {
+	d, s := createDriver(t)
+	defer s.Close()
+	defer d.Close()
+
+	ctx := context.Background()
+	tagged := d.(cache.TaggedStore).Tags("users")
+	require.NoError(t, tagged.Put(ctx, "user:1", "john", time.Minute))
+	require.NoError(t, tagged.Put(ctx, "user:2", "jane", time.Minute))
+	require.NoError(t, d.Put(ctx, "untagged", "value", time.Minute))
+
+	require.NoError(t, d.(dgcache.TagKeysFlusher).FlushTagKeysOnly(ctx, "users"))
+
+	// The keys are gone but the tag set is kept
+	assert.False(t, s.Exists("test:user:1"))
+	assert.False(t, s.Exists("test:user:2"))
+	assert.True(t, s.Exists("test:untagged"))
+	assert.True(t, s.Exists("test:tag:users"))
+
+	// Tagging again reuses the set
+	require.NoError(t, tagged.Put(ctx, "user:1", "john", time.Minute))
+	keys, err := d.(dgcache.TagIntrospectable).KeysForTag(ctx, "users")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user:1"}, keys)
+}
+
+func TestRedis_NilValue(t *testing.T) {
+	d, s := createDriver(t)
+	defer s.Close()
+	defer d.Close()
+
+	ctx := context.Background()
+	require.NoError(t, d.Put(ctx, "nil", nil, 1*time.Minute))
+
+	has, err := d.Has(ctx, "nil")
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	val, err := d.Get(ctx, "nil")
+	assert.NoError(t, err)
+	assert.Nil(t, val)
+
+	vals, err := d.GetMultiple(ctx, []string{"nil", "missing"})
+	require.NoError(t, err)
+	assert.Contains(t, vals, "nil")
+	assert.Nil(t, vals["nil"])
+	assert.NotContains(t, vals, "missing")
+
+	has, err = d.Has(ctx, "missing")
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	_, err = d.Get(ctx, "missing")
+	assert.ErrorIs(t, err, dgcache.ErrKeyNotFound)
+}
+
+func TestRedis_RememberCachesNil(t *testing.T) {
+	s, err := miniredis.Run()
+	require.NoError(t, err)
+	defer s.Close()
+
+	port, _ := strconv.Atoi(s.Port())
+	cfg := dgcache.DefaultConfig().
+		WithDefaultStore("redis").
+		WithStore("redis", dgcache.StoreConfig{
+			Driver: "redis",
+			Options: map[string]interface{}{
+				"host": s.Host(),
+				"port": port,
+			},
+		})
+	manager, err := dgcache.NewManager(cfg)
+	require.NoError(t, err)
+	defer manager.Close()
+
+	ctx := context.Background()
+	calls := 0
+	callback := func() (interface{}, error) {
+		calls++
+		return nil, nil
+	}
+
+	for i := 0; i < 2; i++ {
+		val, err := manager.Remember(ctx, "negative", 1*time.Minute, callback)
+		require.NoError(t, err)
+		assert.Nil(t, val)
+	}
+	assert.Equal(t, 1, calls)
+}
+
+func TestRedis_Metrics(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("disabled by default", func(t *testing.T) {
+		d, s := createDriver(t)
+		defer s.Close()
+		defer d.Close()
+
+		require.NoError(t, d.Put(ctx, "key", "value", time.Minute))
+		_, _ = d.Get(ctx, "key")
+		assert.Equal(t, cache.Stats{}, d.Stats())
+	})
+
+	t.Run("enable_metrics option", func(t *testing.T) {
+		d, s := createDriverWithOptions(t, map[string]interface{}{"enable_metrics": true})
+		defer s.Close()
+		defer d.Close()
+
+		require.NoError(t, d.Put(ctx, "key", "value", time.Minute))
+		_, _ = d.Get(ctx, "key")
+		_, _ = d.Get(ctx, "missing")
+
+		stats := d.Stats()
+		assert.Equal(t, int64(1), stats.Sets)
+		assert.Equal(t, int64(1), stats.Hits)
+		assert.Equal(t, int64(1), stats.Misses)
+	})
+
+	t.Run("metrics flag enables every driver", func(t *testing.T) {
+		s, err := miniredis.Run()
+		require.NoError(t, err)
+		defer s.Close()
+
+		port, _ := strconv.Atoi(s.Port())
+		cfg := dgcache.DefaultConfig().
+			WithMetrics(true).
+			WithStore("memory", dgcache.StoreConfig{Driver: "memory"}).
+			WithStore("redis", dgcache.StoreConfig{
+				Driver: "redis",
+				Options: map[string]interface{}{
+					"host": s.Host(),
+					"port": port,
+				},
+			})
+		manager, err := dgcache.NewManager(cfg)
+		require.NoError(t, err)
+		defer manager.Close()
+
+		for _, name := range []string{"memory", "redis"} {
+			store, err := manager.Store(name)
+			require.NoError(t, err)
+
+			require.NoError(t, store.Put(ctx, "key", "value", time.Minute))
+			_, _ = store.Get(ctx, "key")
+
+			stats := store.Stats()
+			assert.Equal(t, int64(1), stats.Sets, name)
+			assert.Equal(t, int64(1), stats.Hits, name)
+		}
+	})
+}
+
+func TestRedis_GetMultipleOrdered(t *testing.T) {
+	s, err := miniredis.Run()
+	require.NoError(t, err)
+	defer s.Close()
+
+	port, _ := strconv.Atoi(s.Port())
+	cfg := dgcache.DefaultConfig().
+		WithDefaultStore("redis").
+		WithStore("redis", dgcache.StoreConfig{
+			Driver: "redis",
+			Options: map[string]interface{}{
+				"host": s.Host(),
+				"port": port,
+			},
+		})
+	manager, err := dgcache.NewManager(cfg)
+	require.NoError(t, err)
+	defer manager.Close()
+
+	ctx := context.Background()
+	require.NoError(t, manager.Put(ctx, "z", "last", time.Minute))
+	require.NoError(t, manager.Put(ctx, "a", "first", time.Minute))
+
+	results, err := manager.GetMultipleOrdered(ctx, []string{"z", "missing", "a"})
+	require.NoError(t, err)
+	assert.Equal(t, []dgcache.Result{
+		{Key: "z", Value: "last", Found: true},
+		{Key: "missing"},
+		{Key: "a", Value: "first", Found: true},
+	}, results)
+}
+
+func TestRedis_JSONUseNumber(t *testing.T) {
+	s, err := miniredis.Run()
+	require.NoError(t, err)
+	defer s.Close()
+
+	port, _ := strconv.Atoi(s.Port())
+	cfg := dgcache.DefaultConfig().
+		WithDefaultStore("redis").
+		WithStore("redis", dgcache.StoreConfig{
+			Driver: "redis",
+			Options: map[string]interface{}{
+				"host":            s.Host(),
+				"port":            port,
+				"json_use_number": true,
+			},
+		})
+	manager, err := dgcache.NewManager(cfg)
+	require.NoError(t, err)
+	defer manager.Close()
+
+	ctx := context.Background()
+	const big = int64(9007199254740993) // 2^53 + 1
+	require.NoError(t, manager.Put(ctx, "id", big, time.Minute))
+
+	val, err := manager.GetInt64(ctx, "id")
+	require.NoError(t, err)
+	assert.Equal(t, big, val)
+
+	require.NoError(t, manager.Put(ctx, "price", 9.99, time.Minute))
+	price, err := manager.GetFloat64(ctx, "price")
+	require.NoError(t, err)
+	assert.Equal(t, 9.99, price)
+}
+
+func TestRedis_PruneTags(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("explicit", func(t *testing.T) {
+		d, s := createDriver(t)
+		defer s.Close()
+		defer d.Close()
+
+		tagged := d.(cache.TaggedStore).Tags("users", "all")
+		require.NoError(t, tagged.Put(ctx, "short", "a", 1*time.Second))
+		require.NoError(t, tagged.Put(ctx, "long", "b", 1*time.Minute))
+		s.FastForward(2 * time.Second)
+
+		removed, err := d.(*driver.Driver).PruneTags(ctx, "users")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), removed)
+		members, _ := s.Members("test:tag:users")
+		assert.Equal(t, []string{"test:long"}, members)
+
+		// No tags prunes every tag set
+		removed, err = d.(*driver.Driver).PruneTags(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), removed)
+		members, _ = s.Members("test:tag:all")
+		assert.Equal(t, []string{"test:long"}, members)
+	})
+
+	t.Run("lazily in KeysForTag", func(t *testing.T) {
+		d, s := createDriver(t)
+		defer s.Close()
+		defer d.Close()
+
+		require.NoError(t, d.(cache.TaggedStore).Tags("users").Put(ctx, "short", "a", 1*time.Second))
+		s.FastForward(2 * time.Second)
+
+		keys, err := d.(dgcache.TagIntrospectable).KeysForTag(ctx, "users")
+		require.NoError(t, err)
+		assert.Empty(t, keys)
+		assert.False(t, s.Exists("test:tag:users"))
+	})
+
+	t.Run("background", func(t *testing.T) {
+		d, s := createDriverWithOptions(t, map[string]interface{}{"tag_prune_interval": "10ms"})
+		defer s.Close()
+		defer d.Close()
+
+		require.NoError(t, d.(cache.TaggedStore).Tags("users").Put(ctx, "short", "a", 1*time.Second))
+		require.True(t, s.Exists("test:tag:users"))
+		s.FastForward(2 * time.Second)
+
+		assert.Eventually(t, func() bool {
+			return !s.Exists("test:tag:users")
+		}, time.Second, 10*time.Millisecond)
+	})
+}
+
+func TestRedis_TagExisting(t *testing.T) {
+	d, s := createDriver(t)
+	defer s.Close()
+	defer d.Close()
+
+	ctx := context.Background()
+	require.NoError(t, d.(cache.TaggedStore).Tags("users").Put(ctx, "user:1", "john", time.Minute))
+	require.NoError(t, d.Put(ctx, "user:2", "jane", time.Minute))
+	require.NoError(t, d.Put(ctx, "plain", "value", time.Minute))
+
+	editor := d.(dgcache.TagEditor)
+	require.NoError(t, editor.TagExisting(ctx, "review", "user:1", "plain", "missing"))
+
+	members, err := s.Members("test:tag:review")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"test:plain", "test:user:1"}, members)
+
+	require.NoError(t, d.(dgcache.TagIntrospectable).FlushTags(ctx, "review"))
+	assert.False(t, s.Exists("test:user:1"))
+	assert.False(t, s.Exists("test:plain"))
+	assert.True(t, s.Exists("test:user:2"))
+}
+
+func TestRedis_Untag(t *testing.T) {
+	d, s := createDriver(t)
+	defer s.Close()
+	defer d.Close()
+
+	ctx := context.Background()
+	tagged := d.(cache.TaggedStore).Tags("users", "active")
+	require.NoError(t, tagged.Put(ctx, "user:1", "john", time.Minute))
+	require.NoError(t, tagged.Put(ctx, "user:2", "jane", time.Minute))
+
+	require.NoError(t, d.(dgcache.TagEditor).Untag(ctx, "user:1", "active"))
+
+	// The untagged key survives a flush of the removed tag
+	introspectable := d.(dgcache.TagIntrospectable)
+	require.NoError(t, introspectable.FlushTags(ctx, "active"))
+	assert.True(t, s.Exists("test:user:1"))
+	assert.False(t, s.Exists("test:user:2"))
+
+	// The remaining tag still flushes it
+	require.NoError(t, introspectable.FlushTags(ctx, "users"))
+	assert.False(t, s.Exists("test:user:1"))
+}
+
+func TestRedis_ExportImport(t *testing.T) {
+	d, s := createDriver(t)
+	defer s.Close()
+	defer d.Close()
+
+	ctx := context.Background()
+	require.NoError(t, d.Put(ctx, "name", "john", time.Hour))
+	require.NoError(t, d.Forever(ctx, "count", 42))
+	require.NoError(t, d.(cache.TaggedStore).Tags("users").Put(ctx, "user:1", "jane", time.Hour))
+
+	var buf strings.Builder
+	require.NoError(t, dgcache.ExportStore(ctx, d, &buf))
+
+	// Import into a second Redis instance
+	target, s2 := createDriver(t)
+	defer s2.Close()
+	defer target.Close()
+	require.NoError(t, dgcache.ImportStore(ctx, target, strings.NewReader(buf.String())))
+
+	val, err := target.Get(ctx, "name")
+	require.NoError(t, err)
+	assert.Equal(t, "john", val)
+	assert.InDelta(t, time.Hour, s2.TTL("test:name"), float64(time.Minute))
+
+	val, err = target.Get(ctx, "count")
+	require.NoError(t, err)
+	assert.Equal(t, float64(42), val)
+	assert.Zero(t, s2.TTL("test:count"))
+
+	members, err := s2.Members("test:tag:users")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"test:user:1"}, members)
+}
+
+func TestRedis_RollingCircuitBreaker(t *testing.T) {
+	d, s := createDriverWithOptions(t, map[string]interface{}{
+		"max_retries": -1,
+		"circuit_breaker": map[string]interface{}{
+			"enabled":      true,
+			"breaker_type": "rolling",
+			"window_size":  4,
+			"failure_rate": 0.5,
+			"min_requests": 2,
+			"timeout":      "1m",
+		},
+	})
+	defer d.Close()
+	ctx := context.Background()
+
+	_, ok := d.(*reliability.CircuitBreakerDriver)
+	require.True(t, ok)
+
+	require.NoError(t, d.Put(ctx, "key", "value", time.Minute))
+	s.Close()
+
+	// One success and one failure reach the 50% rate
+	_, err := d.Get(ctx, "key")
+	require.Error(t, err)
+	assert.NotErrorIs(t, err, reliability.ErrCircuitOpen)
+
+	_, err = d.Get(ctx, "key")
+	assert.ErrorIs(t, err, reliability.ErrCircuitOpen)
+}
+
+func TestRedis_CircuitBreakerForwards(t *testing.T) {
+	d, s := createDriverWithOptions(t, map[string]interface{}{
+		"circuit_breaker": map[string]interface{}{"enabled": true},
+	})
+	defer s.Close()
+	defer d.Close()
+	ctx := context.Background()
+
+	_, ok := d.(*reliability.CircuitBreakerDriver)
+	require.True(t, ok)
+
+	require.NoError(t, d.Put(ctx, "session", "data", time.Minute))
+
+	extended, err := d.(dgcache.TTLExtender).ExtendTTL(ctx, "session", time.Hour)
+	require.NoError(t, err)
+	assert.True(t, extended)
+	assert.Equal(t, time.Hour, s.TTL("test:session"))
+
+	require.NoError(t, d.Put(ctx, "config:app", "v1", 0))
+	require.NoError(t, d.(dgcache.SelectiveFlusher).FlushExcept(ctx, "config:*"))
+	assert.False(t, s.Exists("test:session"))
+	assert.True(t, s.Exists("test:config:app"))
+
+	require.NoError(t, d.(dgcache.Renamer).Rename(ctx, "config:app", "config:live"))
+	assert.True(t, s.Exists("test:config:live"))
+
+	require.NoError(t, d.Put(ctx, "user:1", map[string]interface{}{"name": "john"}, 0))
+	name, err := d.(dgcache.FieldReader).GetField(ctx, "user:1", "name")
+	require.NoError(t, err)
+	assert.Equal(t, "john", name)
+}
+
+func TestRedis_TracingForwards(t *testing.T) {
+	inner, s := createDriver(t)
+	defer s.Close()
+	d := dgcache.NewTracingDriver(inner)
+	defer d.Close()
+	ctx := context.Background()
+
+	require.NoError(t, d.Ping(ctx))
+	assert.Equal(t, "redis", d.Info().Driver)
+
+	require.NoError(t, d.Tags("users").Put(ctx, "user:1", "john", time.Minute))
+	keys, err := d.KeysForTag(ctx, "users")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user:1"}, keys)
+
+	extended, err := d.ExtendTTL(ctx, "user:1", time.Hour)
+	require.NoError(t, err)
+	assert.True(t, extended)
+	assert.Equal(t, time.Hour, s.TTL("test:user:1"))
+
+	require.NoError(t, d.Transaction(ctx, func(tx cache.Store) error {
+		return tx.Put(ctx, "user:2", "jane", 0)
+	}))
+	require.NoError(t, d.HSet(ctx, "profile", "name", "john"))
+	val, err := d.HGet(ctx, "profile", "name")
+	require.NoError(t, err)
+	assert.Equal(t, "john", val)
+
+	require.NoError(t, d.FlushTags(ctx, "users"))
+	has, err := d.Has(ctx, "user:1")
+	require.NoError(t, err)
+	assert.False(t, has)
+}
+
+func TestRedis_HasMultiple(t *testing.T) {
+	d, s := createDriverWithOptions(t, map[string]interface{}{"max_pipeline_size": 2})
+	defer s.Close()
+	defer d.Close()
+
+	ctx := context.Background()
+	require.NoError(t, d.Put(ctx, "a", 1, 0))
+	require.NoError(t, d.Put(ctx, "b", 2, time.Minute))
+	require.NoError(t, d.Put(ctx, "c", 3, time.Second))
+	s.FastForward(2 * time.Second)
+
+	result, err := d.(dgcache.MultiChecker).HasMultiple(ctx, []string{"a", "b", "c", "d", "e"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"a": true, "b": true, "c": false, "d": false, "e": false}, result)
+}
+
+func TestRedis_Hash(t *testing.T) {
+	d, s := createDriver(t)
+	defer s.Close()
+	defer d.Close()
+	ctx := context.Background()
+
+	hashes, ok := d.(dgcache.HashStore)
+	require.True(t, ok)
+
+	require.NoError(t, hashes.HSet(ctx, "user:1", "name", "john"))
+	require.NoError(t, hashes.HSet(ctx, "user:1", "roles", []string{"admin"}))
+
+	// Fields live in a single Redis hash under the prefixed key
+	fields, err := s.HKeys("test:user:1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"name", "roles"}, fields)
+
+	val, err := hashes.HGet(ctx, "user:1", "name")
+	require.NoError(t, err)
+	assert.Equal(t, "john", val)
+
+	_, err = hashes.HGet(ctx, "user:1", "missing")
+	assert.ErrorIs(t, err, dgcache.ErrKeyNotFound)
+
+	all, err := hashes.HGetAll(ctx, "user:1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"name":  "john",
+		"roles": []interface{}{"admin"},
+	}, all)
+
+	require.NoError(t, hashes.HDel(ctx, "user:1", "name"))
+	all, err = hashes.HGetAll(ctx, "user:1")
+	require.NoError(t, err)
+	assert.Len(t, all, 1)
+
+	all, err = hashes.HGetAll(ctx, "missing")
+	require.NoError(t, err)
+	assert.Empty(t, all)
+}
+
+func TestRedis_IncrementWithTTL(t *testing.T) {
+	d, s := createDriver(t)
+	defer s.Close()
+	defer d.Close()
+	ctx := context.Background()
+
+	incrementer, ok := d.(dgcache.TTLIncrementer)
+	require.True(t, ok)
+
+	n, err := incrementer.IncrementWithTTL(ctx, "hits", 1, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	assert.Equal(t, time.Minute, s.TTL("test:hits"))
+
+	// The TTL is not refreshed by later increments
+	s.FastForward(30 * time.Second)
+	n, err = incrementer.IncrementWithTTL(ctx, "hits", 2, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), n)
+	assert.Equal(t, 30*time.Second, s.TTL("test:hits"))
+
+	// Once expired, the next increment opens a new window
+	s.FastForward(31 * time.Second)
+	n, err = incrementer.IncrementWithTTL(ctx, "hits", 1, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+	assert.Equal(t, time.Minute, s.TTL("test:hits"))
+
+	// A non-positive TTL creates the key without expiry
+	_, err = incrementer.IncrementWithTTL(ctx, "forever", 1, 0)
+	require.NoError(t, err)
+	assert.Equal(t, time.Duration(0), s.TTL("test:forever"))
+}
+
+type injectedProfile struct {
+	ID   int
+	Name string
+}
+
+func TestRedis_SetSerializer(t *testing.T) {
+	s := miniredis.RunT(t)
+	client := goredis.NewClient(&goredis.Options{Addr: s.Addr()})
+	d := driver.NewDriverWithClient(client, "test")
+	defer d.Close()
+
+	serializer.RegisterType(injectedProfile{})
+	d.SetSerializer(serializer.NewMsgpackSerializer())
+	d.SetCompressor(compression.NewGzipCompressor(compression.DefaultCompression))
+
+	info := d.Info()
+	assert.Equal(t, "msgpack", info.Serializer)
+	assert.Equal(t, "gzip", info.Compression)
+
+	ctx := context.Background()
+	profile := injectedProfile{ID: 7, Name: "Jane"}
+	require.NoError(t, d.Put(ctx, "profile", profile, time.Minute))
+
+	val, err := d.Get(ctx, "profile")
+	require.NoError(t, err)
+	assert.Equal(t, profile, val)
+
+	// Stored gzip-compressed, not as JSON
+	raw, err := s.Get("test:profile")
+	require.NoError(t, err)
+	assert.Equal(t, []byte{0x1f, 0x8b}, []byte(raw[:2]))
+}
+
+func TestRedis_IncrementOverflow(t *testing.T) {
+	d, s := createDriver(t)
+	defer s.Close()
+	defer d.Close()
+
+	ctx := context.Background()
+	n, err := d.Increment(ctx, "balance", math.MaxInt64)
+	require.NoError(t, err)
+	assert.Equal(t, int64(math.MaxInt64), n)
+
+	_, err = d.Increment(ctx, "balance", 1)
+	assert.ErrorIs(t, err, dgcache.ErrOverflow)
+	raw, err := s.Get("test:balance")
+	require.NoError(t, err)
+	assert.Equal(t, strconv.FormatInt(math.MaxInt64, 10), raw)
+
+	// A transaction rejects the overflow while staging, before anything is sent
+	err = d.(dgcache.Transactional).Transaction(ctx, func(tx cache.Store) error {
+		if _, err := tx.Increment(ctx, "staged", math.MaxInt64); err != nil {
+			return err
+		}
+		_, err := tx.Increment(ctx, "staged", 1)
+		return err
+	})
+	assert.ErrorIs(t, err, dgcache.ErrOverflow)
+	assert.False(t, s.Exists("test:staged"))
+}
+
+func TestRedis_TransactionIncrementValidation(t *testing.T) {
+	d, s := createDriver(t)
+	defer s.Close()
+	defer d.Close()
+
+	ctx := context.Background()
+	transactional := d.(dgcache.Transactional)
+
+	// A string that serializes quoted would fail INCRBY inside EXEC, after
+	// the other queued writes applied; it is rejected while staging instead
+	err := transactional.Transaction(ctx, func(tx cache.Store) error {
+		require.NoError(t, tx.Put(ctx, "other", "x", 0))
+		require.NoError(t, tx.Put(ctx, "quoted", "5", 0))
+		_, err := tx.Increment(ctx, "quoted", 1)
+		return err
+	})
+	assert.ErrorIs(t, err, dgcache.ErrNotANumber)
+	assert.ErrorContains(t, err, `"quoted"`)
+	assert.False(t, s.Exists("test:other"))
+
+	// The same applies to a value already stored
+	require.NoError(t, d.Put(ctx, "stored", "5", 0))
+	err = transactional.Transaction(ctx, func(tx cache.Store) error {
+		_, err := tx.Increment(ctx, "stored", 1)
+		return err
+	})
+	assert.ErrorIs(t, err, dgcache.ErrNotANumber)
+
+	// Bare integers and staged counters are accepted
+	require.NoError(t, d.Put(ctx, "count", 5, 0))
+	err = transactional.Transaction(ctx, func(tx cache.Store) error {
+		n, err := tx.Increment(ctx, "count", 1)
+		require.NoError(t, err)
+		assert.Equal(t, int64(6), n)
+		n, err = tx.Decrement(ctx, "count", 10)
+		require.NoError(t, err)
+		assert.Equal(t, int64(-4), n)
+		return nil
+	})
+	require.NoError(t, err)
+	raw, err := s.Get("test:count")
+	require.NoError(t, err)
+	assert.Equal(t, "-4", raw)
+
+	// Decrementing by MinInt64 cannot be negated into an increment
+	err = transactional.Transaction(ctx, func(tx cache.Store) error {
+		_, err := tx.Decrement(ctx, "count", math.MinInt64)
+		return err
+	})
+	assert.ErrorIs(t, err, dgcache.ErrOverflow)
+}
+
+func TestRedis_IncrementSerializedValue(t *testing.T) {
+	d, s := createDriver(t)
+	defer s.Close()
+	defer d.Close()
+
+	ctx := context.Background()
+	require.NoError(t, d.Put(ctx, "profile", injectedProfile{ID: 1, Name: "Jane"}, time.Minute))
+	before, err := s.Get("test:profile")
+	require.NoError(t, err)
+
+	_, err = d.Increment(ctx, "profile", 1)
+	assert.ErrorIs(t, err, dgcache.ErrNotANumber)
+	_, err = d.Decrement(ctx, "profile", 1)
+	assert.ErrorIs(t, err, dgcache.ErrNotANumber)
+
+	// The stored value is left untouched
+	after, err := s.Get("test:profile")
+	require.NoError(t, err)
+	assert.Equal(t, before, after)
+
+	// Integers serialized by JSON are plain counters
+	require.NoError(t, d.Put(ctx, "count", 41, time.Minute))
+	n, err := d.Increment(ctx, "count", 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), n)
+}
+
+func TestRedis_WriteBehind(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("buffered writes land in Redis", func(t *testing.T) {
+		d, s := createDriverWithOptions(t, map[string]interface{}{
+			"write_behind":                true,
+			"write_behind_flush_interval": "10ms",
+		})
+		defer s.Close()
+		defer d.Close()
+
+		require.NoError(t, d.Put(ctx, "a", "1", time.Minute))
+		require.NoError(t, d.PutMultiple(ctx, map[string]interface{}{"b": "2", "c": "3"}, time.Minute))
+
+		assert.Eventually(t, func() bool {
+			return s.Exists("test:a") && s.Exists("test:b") && s.Exists("test:c")
+		}, time.Second, 5*time.Millisecond)
+		assert.Greater(t, s.TTL("test:a"), time.Duration(0))
+
+		val, err := d.Get(ctx, "b")
+		require.NoError(t, err)
+		assert.Equal(t, "2", val)
+	})
+
+	t.Run("close flushes pending writes", func(t *testing.T) {
+		d, s := createDriverWithOptions(t, map[string]interface{}{
+			"write_behind":                true,
+			"write_behind_flush_interval": "1h",
+			"write_behind_batch_size":     1000,
+		})
+		defer s.Close()
+
+		for i := 0; i < 10; i++ {
+			require.NoError(t, d.Put(ctx, fmt.Sprintf("key%d", i), i, 0))
+		}
+		assert.False(t, s.Exists("test:key0"))
+
+		require.NoError(t, d.Close())
+		for i := 0; i < 10; i++ {
+			assert.True(t, s.Exists(fmt.Sprintf("test:key%d", i)))
+		}
+		assert.Equal(t, int64(0), d.(*driver.Driver).FailedWrites())
+	})
+
+	t.Run("removals flush queued writes first", func(t *testing.T) {
+		d, s := createDriverWithOptions(t, map[string]interface{}{
+			"write_behind":                true,
+			"write_behind_flush_interval": "1h",
+			"write_behind_batch_size":     1000,
+		})
+		defer s.Close()
+		defer d.Close()
+
+		require.NoError(t, d.Put(ctx, "a", "1", 0))
+		require.NoError(t, d.Forget(ctx, "a"))
+		require.NoError(t, d.Put(ctx, "b", "2", 0))
+		require.NoError(t, d.ForgetMultiple(ctx, []string{"b"}))
+		require.NoError(t, d.Put(ctx, "c", "3", 0))
+		require.NoError(t, d.Flush(ctx))
+
+		// Later writes apply on top of the queued values
+		require.NoError(t, d.Put(ctx, "n", 1, 0))
+		n, err := d.Increment(ctx, "n", 5)
+		require.NoError(t, err)
+		assert.Equal(t, int64(6), n)
+		require.NoError(t, d.Put(ctx, "e", "v", 0))
+		ok, err := d.(dgcache.Expirer).Expire(ctx, "e", time.Minute)
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		// Nothing is left in the queue to bring the keys back on Close
+		require.NoError(t, d.Close())
+		assert.False(t, s.Exists("test:a"))
+		assert.False(t, s.Exists("test:b"))
+		assert.False(t, s.Exists("test:c"))
+		val, err := s.Get("test:n")
+		require.NoError(t, err)
+		assert.Equal(t, "6", val)
+		assert.Greater(t, s.TTL("test:e"), time.Duration(0))
+	})
+
+	t.Run("unknown full policy", func(t *testing.T) {
+		_, err := driver.NewDriver(dgcache.StoreConfig{
+			Driver:  "redis",
+			Options: map[string]interface{}{"write_behind_full_policy": "wait"},
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestRedis_GetMultipleWithTTL(t *testing.T) {
+	d, s := createDriver(t)
+	defer s.Close()
+	defer d.Close()
+
+	ctx := context.Background()
+	require.NoError(t, d.Put(ctx, "short", "a", time.Minute))
+	require.NoError(t, d.Put(ctx, "long", "b", time.Hour))
+	require.NoError(t, d.Forever(ctx, "forever", "c"))
+
+	reader := d.(dgcache.TTLReader)
+	values, err := reader.GetMultipleWithTTL(ctx, []string{"short", "long", "forever", "missing"})
+	require.NoError(t, err)
+	require.Len(t, values, 3)
+
+	assert.Equal(t, "a", values["short"].Value)
+	assert.InDelta(t, time.Minute, values["short"].TTL, float64(time.Second))
+	assert.InDelta(t, time.Hour, values["long"].TTL, float64(time.Second))
+	assert.Equal(t, "c", values["forever"].Value)
+	assert.Equal(t, dgcache.NoExpiry, values["forever"].TTL)
+
+	// Remaining TTLs shrink as time passes
+	s.FastForward(30 * time.Second)
+	values, err = reader.GetMultipleWithTTL(ctx, []string{"short"})
+	require.NoError(t, err)
+	assert.InDelta(t, 30*time.Second, values["short"].TTL, float64(time.Second))
+}
+
+func TestRedis_ReadSerializers(t *testing.T) {
+	s := miniredis.RunT(t)
+	parts := strings.Split(s.Addr(), ":")
+	port, _ := strconv.Atoi(parts[1])
+	newDriver := func(options map[string]interface{}) cache.Driver {
+		options["host"] = parts[0]
+		options["port"] = port
+		d, err := driver.NewDriver(dgcache.StoreConfig{Driver: "redis", Prefix: "test", Options: options})
+		require.NoError(t, err)
+		t.Cleanup(func() { d.Close() })
+		return d
+	}
+	ctx := context.Background()
+	serializer.RegisterType(injectedProfile{})
+
+	legacy := newDriver(map[string]interface{}{"serializer": "json"})
+	require.NoError(t, legacy.Put(ctx, "profile", injectedProfile{ID: 1, Name: "Jane"}, time.Minute))
+	require.NoError(t, legacy.Put(ctx, "name", "john", time.Minute))
+	require.NoError(t, legacy.Put(ctx, "count", 1234, time.Minute))
+
+	migrated := newDriver(map[string]interface{}{
+		"serializer":       "msgpack",
+		"read_serializers": []interface{}{"json"},
+	})
+
+	val, err := migrated.Get(ctx, "profile")
+	require.NoError(t, err)
+	assert.Equal(t, injectedProfile{ID: 1, Name: "Jane"}, val)
+	val, err = migrated.Get(ctx, "name")
+	require.NoError(t, err)
+	assert.Equal(t, "john", val)
+	val, err = migrated.Get(ctx, "count")
+	require.NoError(t, err)
+	assert.EqualValues(t, 1234, val)
+
+	// New writes use the primary format
+	require.NoError(t, migrated.Put(ctx, "profile", injectedProfile{ID: 2, Name: "Joe"}, time.Minute))
+	raw, err := s.Get("test:profile")
+	require.NoError(t, err)
+	assert.NotEqual(t, byte('{'), raw[0])
+	val, err = migrated.Get(ctx, "profile")
+	require.NoError(t, err)
+	assert.Equal(t, injectedProfile{ID: 2, Name: "Joe"}, val)
+}
+
+func TestRedis_SwapAll(t *testing.T) {
+	d, s := createDriver(t)
+	defer s.Close()
+	defer d.Close()
+
+	ctx := context.Background()
+	require.NoError(t, d.PutMultiple(ctx, map[string]interface{}{"old:1": "a", "old:2": "b"}, time.Minute))
+	require.NoError(t, s.Set("unprefixed", "app"))
+
+	swapper := d.(dgcache.Swapper)
+	require.NoError(t, swapper.SwapAll(ctx, map[string]interface{}{"new:1": "c", "new:2": "d"}, time.Minute))
+
+	assert.False(t, s.Exists("test:old:1"))
+	assert.False(t, s.Exists("test:old:2"))
+	assert.True(t, s.Exists("unprefixed"), "swap is scoped to the prefix")
+	values, err := d.GetMultiple(ctx, []string{"old:1", "new:1", "new:2"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"new:1": "c", "new:2": "d"}, values)
+	assert.Greater(t, s.TTL("test:new:1"), time.Duration(0))
+
+	// A value that cannot be serialized aborts the swap before anything is removed
+	err = swapper.SwapAll(ctx, map[string]interface{}{"bad": make(chan int)}, time.Minute)
+	assert.Error(t, err)
+	assert.True(t, s.Exists("test:new:1"))
+}
+
+func TestRedis_ReadReplica(t *testing.T) {
+	primary, s := createDriver(t)
+	defer s.Close()
+	defer primary.Close()
+
+	parts := strings.Split(s.Addr(), ":")
+	port, _ := strconv.Atoi(parts[1])
+	replica, err := driver.NewDriver(dgcache.StoreConfig{
+		Driver:  "redis",
+		Prefix:  "test",
+		Options: map[string]interface{}{"host": parts[0], "port": port, "read_replica": true},
+	})
+	require.NoError(t, err)
+	defer replica.Close()
+
+	ctx := context.Background()
+	require.NoError(t, primary.(cache.TaggedStore).Tags("users").Put(ctx, "user:1", "john", time.Minute))
+	require.NoError(t, primary.Put(ctx, "user:2", "jane", time.Minute))
+	_, err = s.SAdd("test:tag:users", "test:user:gone")
+	require.NoError(t, err)
+
+	// Reads are served
+	value, err := replica.Get(ctx, "user:1")
+	require.NoError(t, err)
+	assert.Equal(t, "john", value)
+	keys, err := replica.(dgcache.TagIntrospectable).KeysForTag(ctx, "users")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user:1"}, keys)
+
+	// Writes are rejected before reaching Redis
+	err = replica.Put(ctx, "user:3", "bob", time.Minute)
+	assert.ErrorIs(t, err, dgcache.ErrReadOnly)
+	assert.ErrorContains(t, err, "put is not allowed on a read replica store")
+	assert.ErrorIs(t, replica.Forget(ctx, "user:2"), dgcache.ErrReadOnly)
+	assert.ErrorIs(t, replica.Flush(ctx), dgcache.ErrReadOnly)
+	_, err = replica.Increment(ctx, "counter", 1)
+	assert.ErrorIs(t, err, dgcache.ErrReadOnly)
+	assert.ErrorIs(t, replica.(cache.TaggedStore).Tags("users").Flush(ctx), dgcache.ErrReadOnly)
+
+	assert.False(t, s.Exists("test:user:3"))
+	assert.True(t, s.Exists("test:user:2"))
+	// KeysForTag left the dead member for the primary to prune
+	members, err := s.Members("test:tag:users")
+	require.NoError(t, err)
+	assert.Contains(t, members, "test:user:gone")
+
+	_, err = driver.NewDriver(dgcache.StoreConfig{
+		Driver:  "redis",
+		Options: map[string]interface{}{"host": parts[0], "port": port, "read_replica": true, "write_behind": true},
+	})
+	assert.Error(t, err)
+}
+
+func TestRedis_FlushScope(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("prefix scope keeps other prefixes", func(t *testing.T) {
+		d, s := createDriver(t)
+		defer s.Close()
+		defer d.Close()
+
+		parts := strings.Split(s.Addr(), ":")
+		port, _ := strconv.Atoi(parts[1])
+		other, err := driver.NewDriver(dgcache.StoreConfig{
+			Driver:  "redis",
+			Prefix:  "other",
+			Options: map[string]interface{}{"host": parts[0], "port": port},
+		})
+		require.NoError(t, err)
+		defer other.Close()
+
+		require.NoError(t, d.Put(ctx, "k1", "v1", time.Minute))
+		require.NoError(t, d.(cache.TaggedStore).Tags("users").Put(ctx, "k2", "v2", time.Minute))
+		require.NoError(t, other.Put(ctx, "k1", "theirs", time.Minute))
+		require.NoError(t, s.Set("unprefixed", "app"))
+
+		require.NoError(t, d.Flush(ctx))
+
+		for _, key := range s.Keys() {
+			assert.False(t, strings.HasPrefix(key, "test:"), "key %s survived the flush", key)
+		}
+		value, err := other.Get(ctx, "k1")
+		require.NoError(t, err)
+		assert.Equal(t, "theirs", value)
+		assert.True(t, s.Exists("unprefixed"))
+	})
+
+	t.Run("db scope clears the database", func(t *testing.T) {
+		d, s := createDriverWithOptions(t, map[string]interface{}{"flush_scope": "db"})
+		defer s.Close()
+		defer d.Close()
+
+		require.NoError(t, d.Put(ctx, "k1", "v1", time.Minute))
+		require.NoError(t, s.Set("unprefixed", "app"))
+
+		require.NoError(t, d.Flush(ctx))
+		assert.Empty(t, s.Keys())
+	})
+
+	t.Run("unknown scope is rejected", func(t *testing.T) {
+		_, err := driver.NewDriver(dgcache.StoreConfig{
+			Driver:  "redis",
+			Options: map[string]interface{}{"flush_scope": "cluster"},
+		})
+		assert.Error(t, err)
+	})
+}
+
+func TestRedis_SerializationErrors(t *testing.T) {
+	ctx := context.Background()
+
+	var logs bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+	defer slog.SetDefault(previous)
+
+	d, s := createDriver(t)
+	defer s.Close()
+	defer d.Close()
+	counter := d.(dgcache.SerializationErrorCounter)
+	assert.Zero(t, counter.SerializationErrors())
+
+	// Data left behind in a format the serializer rejects still reads as a string
+	require.NoError(t, s.Set("test:user:1", `{"type":"main.User","value":{"Name":`))
+	value, err := d.Get(ctx, "user:1")
+	require.NoError(t, err)
+	assert.Equal(t, `{"type":"main.User","value":{"Name":`, value)
+	assert.Equal(t, int64(1), counter.SerializationErrors())
+
+	_, err = d.GetMultiple(ctx, []string{"user:1"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), counter.SerializationErrors())
+
+	// Encoding failures count too
+	assert.Error(t, d.Put(ctx, "user:2", make(chan int), time.Minute))
+	assert.Equal(t, int64(3), counter.SerializationErrors())
+
+	// The key is logged, the value is not
+	assert.Contains(t, logs.String(), "key=user:1")
+	assert.Contains(t, logs.String(), "key=user:2")
+	assert.NotContains(t, logs.String(), "main.User")
+
+	t.Run("logging disabled", func(t *testing.T) {
+		logs.Reset()
+		d, s := createDriverWithOptions(t, map[string]interface{}{"log_serialization_errors": false})
+		defer s.Close()
+		defer d.Close()
+
+		require.NoError(t, s.Set("test:bad", "{"))
+		_, err := d.Get(ctx, "bad")
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), d.(dgcache.SerializationErrorCounter).SerializationErrors())
+		assert.Empty(t, logs.String())
+	})
+}
+
+func TestRedis_SchemaVersion(t *testing.T) {
+	s := miniredis.RunT(t)
+	parts := strings.Split(s.Addr(), ":")
+	port, _ := strconv.Atoi(parts[1])
+	newVersioned := func(version int) cache.Driver {
+		d, err := driver.NewDriver(dgcache.StoreConfig{
+			Driver:  "redis",
+			Prefix:  "test",
+			Options: map[string]interface{}{"host": parts[0], "port": port, "schema_version": version},
+		})
+		require.NoError(t, err)
+		t.Cleanup(func() { d.Close() })
+		return d
+	}
+	v1, v2 := newVersioned(1), newVersioned(2)
+
+	ctx := context.Background()
+	require.NoError(t, v1.Put(ctx, "user:1", map[string]interface{}{"name": "john"}, time.Minute))
+	_, err := v1.Increment(ctx, "visits", 5)
+	require.NoError(t, err)
+
+	// The writing version reads its own values
+	value, err := v1.Get(ctx, "user:1")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"name": "john"}, value)
+
+	// Another version sees a miss
+	_, err = v2.Get(ctx, "user:1")
+	assert.ErrorIs(t, err, dgcache.ErrKeyNotFound)
+	values, err := v2.GetMultiple(ctx, []string{"user:1"})
+	require.NoError(t, err)
+	assert.Empty(t, values)
+	assert.Zero(t, v2.(dgcache.SerializationErrorCounter).SerializationErrors())
+
+	// Counters are unversioned
+	n, err := v2.Increment(ctx, "visits", 1)
+	require.NoError(t, err)
+	assert.Equal(t, int64(6), n)
+	value, err = v2.Get(ctx, "visits")
+	require.NoError(t, err)
+	assert.EqualValues(t, 6, value)
+
+	// Negative versions are rejected
+	_, err = driver.NewDriver(dgcache.StoreConfig{
+		Driver:  "redis",
+		Options: map[string]interface{}{"host": parts[0], "port": port, "schema_version": -1},
+	})
+	assert.Error(t, err)
+}
+
+func TestRedis_SlidingTTL(t *testing.T) {
+	d, s := createDriverWithOptions(t, map[string]interface{}{"sliding_ttl": true})
+	defer s.Close()
+	defer d.Close()
+	ctx := context.Background()
+
+	require.NoError(t, d.Put(ctx, "read", "value", time.Second))
+	require.NoError(t, d.PutMultiple(ctx, map[string]interface{}{"untouched": "value"}, time.Second))
+
+	// Reading keeps the key alive past its original expiry
+	for i := 0; i < 3; i++ {
+		s.FastForward(600 * time.Millisecond)
+		_, err := d.Get(ctx, "read")
+		require.NoError(t, err)
+		assert.Equal(t, time.Second, s.TTL("test:read"))
+	}
+	assert.False(t, s.Exists("test:untouched"))
+
+	// The sliding record is not exported as a value
+	var keys []string
+	require.NoError(t, d.(dgcache.Exporter).Export(ctx, func(item dgcache.Item) error {
+		keys = append(keys, item.Key)
+		return nil
+	}))
+	assert.Equal(t, []string{"read"}, keys)
+
+	// Without reads it expires after the TTL
+	s.FastForward(time.Second)
+	_, err := d.Get(ctx, "read")
+	assert.ErrorIs(t, err, dgcache.ErrKeyNotFound)
+
+	// A persistent key stays persistent
+	require.NoError(t, d.Forever(ctx, "forever", "value"))
+	_, err = d.Get(ctx, "forever")
+	require.NoError(t, err)
+	assert.Zero(t, s.TTL("test:forever"))
+}
+
+func TestRedis_SlidingTTLWritePaths(t *testing.T) {
+	d, s := createDriverWithOptions(t, map[string]interface{}{"sliding_ttl": true})
+	defer s.Close()
+	defer d.Close()
+	ctx := context.Background()
+
+	require.NoError(t, d.(dgcache.Swapper).SwapAll(ctx, map[string]interface{}{"swapped": "value"}, time.Second))
+	tagged := d.(cache.TaggedStore).Tags("users")
+	require.NoError(t, tagged.Put(ctx, "tagged", "value", time.Second))
+	require.NoError(t, tagged.PutMultiple(ctx, map[string]interface{}{"tagged_multi": "value"}, time.Second))
+	require.NoError(t, d.(dgcache.Transactional).Transaction(ctx, func(tx cache.Store) error {
+		return tx.Put(ctx, "transacted", "value", time.Second)
+	}))
+	require.NoError(t, d.Put(ctx, "old", "value", time.Second))
+	require.NoError(t, d.(dgcache.Renamer).Rename(ctx, "old", "renamed"))
+	assert.False(t, s.Exists("test:slide:old"))
+
+	for _, key := range []string{"swapped", "tagged", "tagged_multi", "transacted", "renamed"} {
+		s.FastForward(100 * time.Millisecond)
+		_, err := d.Get(ctx, key)
+		require.NoError(t, err, key)
+		assert.Equal(t, time.Second, s.TTL("test:"+key), key)
+	}
+}
+
+func TestRedis_SlidingTTLMaxLifetime(t *testing.T) {
+	d, s := createDriverWithOptions(t, map[string]interface{}{
+		"sliding_ttl":              true,
+		"sliding_ttl_max_lifetime": "500ms",
+	})
+	defer s.Close()
+	defer d.Close()
+	ctx := context.Background()
+
+	require.NoError(t, d.Put(ctx, "key", "value", time.Second))
+	s.FastForward(800 * time.Millisecond)
+	_, err := d.Get(ctx, "key")
+	require.NoError(t, err)
+
+	// Extended, but only up to the max lifetime after the write
+	ttl := s.TTL("test:key")
+	assert.Greater(t, ttl, 200*time.Millisecond)
+	assert.LessOrEqual(t, ttl, 500*time.Millisecond)
+
+	_, err = driver.NewDriver(dgcache.StoreConfig{
+		Driver:  "redis",
+		Options: map[string]interface{}{"sliding_ttl": true, "read_replica": true},
+	})
+	assert.Error(t, err)
+}
+
+func TestRedis_GetWithMeta(t *testing.T) {
+	d, s := createDriverWithOptions(t, map[string]interface{}{"meta_tags": true})
+	defer s.Close()
+	defer d.Close()
+
+	ctx := context.Background()
+	require.NoError(t, d.(cache.TaggedStore).Tags("users", "admins").Put(ctx, "user:1", "john", time.Minute))
+	require.NoError(t, d.Forever(ctx, "config", "value"))
+
+	value, meta, err := d.(dgcache.MetaReader).GetWithMeta(ctx, "user:1")
+	require.NoError(t, err)
+	assert.Equal(t, "john", value)
+	assert.Equal(t, time.Minute, meta.TTL)
+	assert.Equal(t, []string{"admins", "users"}, meta.Tags)
+	assert.True(t, meta.CreatedAt.IsZero())
+
+	_, meta, err = d.(dgcache.MetaReader).GetWithMeta(ctx, "config")
+	require.NoError(t, err)
+	assert.Equal(t, dgcache.NoExpiry, meta.TTL)
+	assert.Nil(t, meta.Tags)
+
+	_, _, err = d.(dgcache.MetaReader).GetWithMeta(ctx, "missing")
+	assert.ErrorIs(t, err, dgcache.ErrKeyNotFound)
+}
+
+func TestRedis_GetItem(t *testing.T) {
+	d, s := createDriverWithOptions(t, map[string]interface{}{"meta_tags": true})
+	defer s.Close()
+	defer d.Close()
+
+	ctx := context.Background()
+	before := time.Now()
+	require.NoError(t, d.(cache.TaggedStore).Tags("users").Put(ctx, "user:1", "john", time.Minute))
+	require.NoError(t, d.Forever(ctx, "config", "value"))
+
+	item, err := d.(dgcache.ItemStore).GetItem(ctx, "user:1")
+	require.NoError(t, err)
+	assert.Equal(t, "user:1", item.Key)
+	assert.Equal(t, "john", item.Value)
+	assert.WithinDuration(t, before.Add(time.Minute), item.ExpiresAt, time.Second)
+	assert.Equal(t, []string{"users"}, item.Tags)
+	assert.True(t, item.CreatedAt.IsZero())
+
+	item, err = d.(dgcache.ItemStore).GetItem(ctx, "config")
+	require.NoError(t, err)
+	assert.Equal(t, "value", item.Value)
+	assert.True(t, item.ExpiresAt.IsZero())
+
+	_, err = d.(dgcache.ItemStore).GetItem(ctx, "missing")
+	assert.ErrorIs(t, err, dgcache.ErrKeyNotFound)
+}
+
+func TestRedis_Invalidator(t *testing.T) {
+	s := miniredis.RunT(t)
+	ctx := context.Background()
+
+	// Two processes, each with a local memory cache, sharing one channel
+	newManager := func() *dgcache.Manager {
+		cfg := dgcache.DefaultConfig().WithStore("memory", dgcache.StoreConfig{Driver: "memory"})
+		manager, err := dgcache.NewManager(cfg)
+		require.NoError(t, err)
+		t.Cleanup(func() { manager.Close() })
+
+		client := goredis.NewClient(&goredis.Options{Addr: s.Addr()})
+		t.Cleanup(func() { client.Close() })
+		require.NoError(t, manager.SetInvalidator(ctx, driver.NewInvalidator(client, "cache:invalidate")))
+		return manager
+	}
+	a, b := newManager(), newManager()
+
+	for _, m := range []*dgcache.Manager{a, b} {
+		require.NoError(t, m.Put(ctx, "user:1", "john", time.Minute))
+		require.NoError(t, m.Tags("users").Put(ctx, "user:2", "jane", time.Minute))
+	}
+
+	require.NoError(t, a.Forget(ctx, "user:1"))
+	assert.Eventually(t, func() bool {
+		has, err := b.Has(ctx, "user:1")
+		return err == nil && !has
+	}, time.Second, 10*time.Millisecond)
+
+	require.NoError(t, a.FlushTags(ctx, "users"))
+	assert.Eventually(t, func() bool {
+		has, err := b.Has(ctx, "user:2")
+		return err == nil && !has
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestRedis_LowercaseKeys(t *testing.T) {
+	d, s := createDriverWithOptions(t, map[string]interface{}{"lowercase_keys": true})
+	defer s.Close()
+	defer d.Close()
+	ctx := context.Background()
+
+	require.NoError(t, d.Put(ctx, "User:1", "john", time.Minute))
+	assert.True(t, s.Exists("test:user:1"))
+
+	val, err := d.Get(ctx, "user:1")
+	require.NoError(t, err)
+	assert.Equal(t, "john", val)
+	values, err := d.GetMultiple(ctx, []string{"USER:1"})
+	require.NoError(t, err)
+	assert.Equal(t, "john", values["USER:1"])
+
+	require.NoError(t, d.Forget(ctx, "uSeR:1"))
+	assert.False(t, s.Exists("test:user:1"))
+}
+
+func TestRedis_MemorySerializerParity(t *testing.T) {
+	type user struct {
+		Name  string   `json:"name"`
+		Roles []string `json:"roles"`
+	}
+	ctx := context.Background()
+
+	redisDriver, s := createDriver(t)
+	defer s.Close()
+	defer redisDriver.Close()
+	memoryDriver, err := memory.NewDriver(dgcache.StoreConfig{
+		Driver:  "memory",
+		Options: map[string]interface{}{"serializer": "json"},
+	})
+	require.NoError(t, err)
+	defer memoryDriver.Close()
+
+	values := map[string]interface{}{
+		"struct": user{Name: "john", Roles: []string{"admin"}},
+		"int":    42,
+		"string": "hello",
+		"slice":  []int{1, 2},
+	}
+	for key, value := range values {
+		require.NoError(t, redisDriver.Put(ctx, key, value, time.Minute))
+		require.NoError(t, memoryDriver.Put(ctx, key, value, time.Minute))
+
+		fromRedis, err := redisDriver.Get(ctx, key)
+		require.NoError(t, err)
+		fromMemory, err := memoryDriver.Get(ctx, key)
+		require.NoError(t, err)
+		assert.Equal(t, fromRedis, fromMemory, key)
+	}
+
+	fromMemory, err := memoryDriver.Get(ctx, "struct")
+	require.NoError(t, err)
+	assert.IsType(t, map[string]interface{}{}, fromMemory)
+}
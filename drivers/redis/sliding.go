@@ -0,0 +1,67 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// slideKey returns the Redis key recording the sliding TTL of a prefixed key.
+func (d *Driver) slideKey(prefixedKey string) string {
+	return d.prefix + d.separator + "slide" + d.separator + d.unprefixKey(prefixedKey)
+}
+
+// recordSlide queues a write of the sliding TTL record of prefixedKey on pipe.
+// The record holds the TTL and the max lifetime deadline in milliseconds
+// (0 = no cap), and expires with the key. It does nothing unless sliding_ttl
+// is set and ttl is positive.
+func (d *Driver) recordSlide(ctx context.Context, pipe redis.Pipeliner, prefixedKey string, ttl time.Duration) {
+	if !d.slidingTTL || ttl <= 0 {
+		return
+	}
+
+	var deadline int64
+	if d.slidingMaxLifetime > 0 {
+		deadline = time.Now().Add(d.slidingMaxLifetime).UnixMilli()
+	}
+	record := strconv.FormatInt(ttl.Milliseconds(), 10) + ":" + strconv.FormatInt(deadline, 10)
+	pipe.Set(ctx, d.slideKey(prefixedKey), record, ttl)
+}
+
+// getSlidingScript reads KEYS[1] and, if it has a sliding TTL record in
+// KEYS[2] and still expires, resets its expiry to the recorded TTL, bounded
+// by the record's deadline. ARGV[1] is the current time in milliseconds.
+// An expiry is never shortened, and a key made persistent stays persistent.
+var getSlidingScript = redis.NewScript(`
+	local value = redis.call("GET", KEYS[1])
+	if not value then
+		return false
+	end
+
+	local record = redis.call("GET", KEYS[2])
+	local current = redis.call("PTTL", KEYS[1])
+	if record and current > 0 then
+		local ttl, deadline = string.match(record, "^(%d+):(%d+)$")
+		ttl, deadline = tonumber(ttl), tonumber(deadline)
+		if deadline > 0 then
+			ttl = math.min(ttl, deadline - tonumber(ARGV[1]))
+		end
+		if ttl > current then
+			redis.call("PEXPIRE", KEYS[1], ttl)
+			redis.call("PEXPIRE", KEYS[2], ttl)
+		end
+	end
+	return value
+`)
+
+// getSliding reads a prefixed key with GET and slides its expiry in one
+// script. It returns redis.Nil if the key does not exist.
+func (d *Driver) getSliding(ctx context.Context, prefixedKey string) ([]byte, error) {
+	value, err := getSlidingScript.Run(ctx, d.client, []string{prefixedKey, d.slideKey(prefixedKey)}, time.Now().UnixMilli()).Text()
+	if err != nil {
+		return nil, err
+	}
+	return []byte(value), nil
+}
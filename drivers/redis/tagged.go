@@ -2,8 +2,12 @@ package redis
 
 import (
 	"context"
+	"sort"
+	"strings"
+	"sync"
 	"time"
 
+	dgcache "github.com/donnigundala/dg-cache"
 	"github.com/donnigundala/dg-core/contracts/cache"
 	"github.com/redis/go-redis/v9"
 )
@@ -31,8 +35,273 @@ func (c *TaggedCache) Tags(tags ...string) cache.TaggedStore {
 }
 
 // tagKey returns the Redis key for a tag set.
-func (c *TaggedCache) tagKey(tag string) string {
-	return c.prefix + ":tag:" + tag
+func (d *Driver) tagKey(tag string) string {
+	return d.prefix + d.separator + "tag" + d.separator + tag
+}
+
+// KeysForTag returns the keys associated with tag, read with SMEMBERS.
+// Members whose key has since expired are left out and removed from the tag set.
+func (d *Driver) KeysForTag(ctx context.Context, tag string) ([]string, error) {
+	tagKey := d.tagKey(tag)
+	members, err := d.client.SMembers(ctx, tagKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	live, err := d.pruneMembers(ctx, tagKey, members)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, len(live))
+	for i, member := range live {
+		keys[i] = d.unprefixKey(member)
+	}
+	sort.Strings(keys)
+	return keys, nil
+}
+
+// PruneTags removes members whose key no longer exists, such as keys that
+// expired by TTL, from the given tag sets, or from every tag set if no tags
+// are given. It returns the number of members removed.
+func (d *Driver) PruneTags(ctx context.Context, tags ...string) (int64, error) {
+	if err := d.writable("prune_tags"); err != nil {
+		return 0, err
+	}
+	if len(tags) > 0 {
+		var removed int64
+		for _, tag := range tags {
+			n, err := d.pruneTagSet(ctx, d.tagKey(tag))
+			removed += n
+			if err != nil {
+				return removed, err
+			}
+		}
+		return removed, nil
+	}
+
+	var removed int64
+	iter := d.client.Scan(ctx, 0, d.tagKey("*"), 1000).Iterator()
+	for iter.Next(ctx) {
+		n, err := d.pruneTagSet(ctx, iter.Val())
+		removed += n
+		if err != nil {
+			return removed, err
+		}
+	}
+	return removed, iter.Err()
+}
+
+// pruneTagSet walks a tag set with SSCAN and removes members whose key is gone.
+func (d *Driver) pruneTagSet(ctx context.Context, tagKey string) (int64, error) {
+	batchSize := d.flushTagsBatchSize
+	if batchSize <= 0 {
+		batchSize = 1000
+	}
+
+	var removed int64
+	var cursor uint64
+	for {
+		members, next, err := d.client.SScan(ctx, tagKey, cursor, "", int64(batchSize)).Result()
+		if err != nil {
+			return removed, err
+		}
+		live, err := d.pruneMembers(ctx, tagKey, members)
+		removed += int64(len(members) - len(live))
+		if err != nil {
+			return removed, err
+		}
+
+		cursor = next
+		if cursor == 0 {
+			return removed, nil
+		}
+	}
+}
+
+// pruneMembersScript removes the members in ARGV whose key no longer exists
+// from the tag set in KEYS[1], returning the removed members. Running it as a
+// script keeps a concurrent tagged Put from being pruned between the checks.
+var pruneMembersScript = redis.NewScript(`
+	local dead = {}
+	for _, member in ipairs(ARGV) do
+		if redis.call("EXISTS", member) == 0 then
+			redis.call("SREM", KEYS[1], member)
+			table.insert(dead, member)
+		end
+	end
+	return dead
+`)
+
+// pruneMembers removes members of a tag set whose key is gone and returns the live members.
+// A read replica only leaves the dead members out.
+func (d *Driver) pruneMembers(ctx context.Context, tagKey string, members []string) ([]string, error) {
+	if len(members) == 0 {
+		return members, nil
+	}
+
+	var dead []string
+	var err error
+	if d.readReplica {
+		dead, err = d.deadMembers(ctx, members)
+	} else {
+		args := make([]interface{}, len(members))
+		for i, member := range members {
+			args[i] = member
+		}
+		dead, err = pruneMembersScript.Run(ctx, d.client, []string{tagKey}, args...).StringSlice()
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(dead) == 0 {
+		return members, nil
+	}
+
+	removed := make(map[string]struct{}, len(dead))
+	for _, member := range dead {
+		removed[member] = struct{}{}
+	}
+	live := make([]string, 0, len(members)-len(dead))
+	for _, member := range members {
+		if _, ok := removed[member]; !ok {
+			live = append(live, member)
+		}
+	}
+	return live, nil
+}
+
+// deadMembers returns the members whose key is gone, checked with EXISTS.
+func (d *Driver) deadMembers(ctx context.Context, members []string) ([]string, error) {
+	pipe := d.client.Pipeline()
+	exists := make([]*redis.IntCmd, len(members))
+	for i, member := range members {
+		exists[i] = pipe.Exists(ctx, member)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	var dead []string
+	for i, cmd := range exists {
+		if cmd.Val() == 0 {
+			dead = append(dead, members[i])
+		}
+	}
+	return dead, nil
+}
+
+// startTagPruner runs PruneTags over every tag set at the given interval until Close.
+func (d *Driver) startTagPruner(interval time.Duration) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				_, _ = d.PruneTags(ctx)
+			}
+		}
+	}()
+
+	var once sync.Once
+	d.stopPruner = func() {
+		once.Do(func() {
+			cancel()
+			<-done
+		})
+	}
+}
+
+// FlushTags removes all keys associated with any of the tags, and the tag sets themselves.
+func (d *Driver) FlushTags(ctx context.Context, tags ...string) error {
+	return (&TaggedCache{Driver: d, tags: tags}).Flush(ctx)
+}
+
+// tagsOf returns the sorted names of the tags whose set contains prefixedKey,
+// scanning every tag set of the store and pipelining a SISMEMBER per set.
+func (d *Driver) tagsOf(ctx context.Context, prefixedKey string) ([]string, error) {
+	tagPrefix := d.tagKey("")
+	var tagKeys []string
+	iter := d.client.Scan(ctx, 0, d.tagKey("*"), 1000).Iterator()
+	for iter.Next(ctx) {
+		tagKeys = append(tagKeys, iter.Val())
+	}
+	if err := iter.Err(); err != nil || len(tagKeys) == 0 {
+		return nil, err
+	}
+
+	pipe := d.client.Pipeline()
+	members := make([]*redis.BoolCmd, len(tagKeys))
+	for i, tagKey := range tagKeys {
+		members[i] = pipe.SIsMember(ctx, tagKey, prefixedKey)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	var tags []string
+	for i, member := range members {
+		if member.Val() {
+			tags = append(tags, strings.TrimPrefix(tagKeys[i], tagPrefix))
+		}
+	}
+	sort.Strings(tags)
+	return tags, nil
+}
+
+// tagExistingScript adds the keys in ARGV that exist to the tag set in KEYS[1].
+var tagExistingScript = redis.NewScript(`
+	for _, key in ipairs(ARGV) do
+		if redis.call("EXISTS", key) == 1 then
+			redis.call("SADD", KEYS[1], key)
+		end
+	end
+	return 0
+`)
+
+// TagExisting adds the existing keys to the tag set with SADD. Absent keys are skipped.
+func (d *Driver) TagExisting(ctx context.Context, tag string, keys ...string) error {
+	if err := d.writable("tag_existing"); err != nil {
+		return err
+	}
+	if err := d.drainWrites(ctx); err != nil {
+		return err
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+
+	args := make([]interface{}, len(keys))
+	for i, key := range keys {
+		args[i] = d.prefixKey(key)
+	}
+	return tagExistingScript.Run(ctx, d.client, []string{d.tagKey(tag)}, args...).Err()
+}
+
+// Untag removes key from the given tag sets with SREM, without deleting it.
+func (d *Driver) Untag(ctx context.Context, key string, tags ...string) error {
+	if err := d.writable("untag"); err != nil {
+		return err
+	}
+	if len(tags) == 0 {
+		return nil
+	}
+
+	pipe := d.client.Pipeline()
+	prefixedKey := d.prefixKey(key)
+	for _, tag := range tags {
+		pipe.SRem(ctx, d.tagKey(tag), prefixedKey)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
 }
 
 // addTags adds the key to the tag sets.
@@ -54,8 +323,19 @@ func (c *TaggedCache) addTags(ctx context.Context, key string) error {
 
 // Put stores a value in the cache and associates it with the tags.
 func (c *TaggedCache) Put(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	if err := c.writable("put"); err != nil {
+		return err
+	}
+	if err := c.drainWrites(ctx); err != nil {
+		return err
+	}
+	ttl, err := c.ttlLimits.Apply(ttl)
+	if err != nil {
+		return err
+	}
+
 	// Serialize the value
-	data, err := c.serializer.Marshal(value)
+	data, err := c.marshal(key, value)
 	if err != nil {
 		return err
 	}
@@ -64,10 +344,11 @@ func (c *TaggedCache) Put(ctx context.Context, key string, value interface{}, tt
 	pipe := c.client.Pipeline()
 
 	// Set the value
-	pipe.Set(ctx, c.prefixKey(key), data, ttl)
+	prefixedKey := c.prefixKey(key)
+	pipe.Set(ctx, prefixedKey, data, ttl)
+	c.recordSlide(ctx, pipe, prefixedKey, ttl)
 
 	// Add to tag sets
-	prefixedKey := c.prefixKey(key)
 	for _, tag := range c.tags {
 		pipe.SAdd(ctx, c.tagKey(tag), prefixedKey)
 	}
@@ -78,65 +359,72 @@ func (c *TaggedCache) Put(ctx context.Context, key string, value interface{}, tt
 
 // PutMultiple stores multiple values and associates them with the tags.
 func (c *TaggedCache) PutMultiple(ctx context.Context, items map[string]interface{}, ttl time.Duration) error {
-	pipe := c.client.Pipeline()
+	if err := c.writable("put_multiple"); err != nil {
+		return err
+	}
+	if err := c.drainWrites(ctx); err != nil {
+		return err
+	}
+	ttl, err := c.ttlLimits.Apply(ttl)
+	if err != nil {
+		return err
+	}
 
-	for key, value := range items {
-		// Serialize each value
-		data, err := c.serializer.Marshal(value)
-		if err != nil {
-			return err
-		}
+	encoded, skipped, err := c.marshalBatch(items)
+	if err != nil {
+		return err
+	}
+
+	pipe := c.client.Pipeline()
 
+	for key, data := range encoded {
 		prefixedKey := c.prefixKey(key)
 		pipe.Set(ctx, prefixedKey, data, ttl)
+		c.recordSlide(ctx, pipe, prefixedKey, ttl)
 
 		for _, tag := range c.tags {
 			pipe.SAdd(ctx, c.tagKey(tag), prefixedKey)
 		}
 	}
 
-	_, err := pipe.Exec(ctx)
-	return err
+	if _, err := pipe.Exec(ctx); err != nil {
+		return err
+	}
+	return skipped
 }
 
 // Increment increments a value and associates it with the tags.
 func (c *TaggedCache) Increment(ctx context.Context, key string, value int64) (int64, error) {
-	// We can't easily pipeline the return value of IncrBy with SAdd if we want to return it immediately
-	// But we can just run them sequentially or use a transaction.
-	// For simplicity and performance, we'll use a pipeline but we need the result.
-
-	pipe := c.client.Pipeline()
-	incr := pipe.IncrBy(ctx, c.prefixKey(key), value)
-
-	prefixedKey := c.prefixKey(key)
-	for _, tag := range c.tags {
-		pipe.SAdd(ctx, c.tagKey(tag), prefixedKey)
+	if err := c.writable("increment"); err != nil {
+		return 0, err
 	}
-
-	_, err := pipe.Exec(ctx)
-	if err != nil {
+	if err := c.drainWrites(ctx); err != nil {
 		return 0, err
 	}
-
-	return incr.Val(), nil
+	return c.counter(ctx, "INCRBY", key, value)
 }
 
 // Decrement decrements a value and associates it with the tags.
 func (c *TaggedCache) Decrement(ctx context.Context, key string, value int64) (int64, error) {
-	pipe := c.client.Pipeline()
-	decr := pipe.DecrBy(ctx, c.prefixKey(key), value)
-
-	prefixedKey := c.prefixKey(key)
-	for _, tag := range c.tags {
-		pipe.SAdd(ctx, c.tagKey(tag), prefixedKey)
+	if err := c.writable("decrement"); err != nil {
+		return 0, err
 	}
-
-	_, err := pipe.Exec(ctx)
-	if err != nil {
+	if err := c.drainWrites(ctx); err != nil {
 		return 0, err
 	}
+	return c.counter(ctx, "DECRBY", key, value)
+}
 
-	return decr.Val(), nil
+// counter runs cmd on key like Driver.counter and adds the key to the tag
+// sets in the same script, so a rejected counter is never tagged.
+func (c *TaggedCache) counter(ctx context.Context, cmd, key string, delta int64) (int64, error) {
+	keys := []string{c.prefixKey(key)}
+	for _, tag := range c.tags {
+		keys = append(keys, c.tagKey(tag))
+	}
+	args, rejected := c.counterArgs(cmd, delta, 0)
+	n, err := counterScript.Run(ctx, c.client, keys, args...).Int64()
+	return n, counterScriptError(key, err, rejected)
 }
 
 // Forever stores a value indefinitely and associates it with the tags.
@@ -146,18 +434,29 @@ func (c *TaggedCache) Forever(ctx context.Context, key string, value interface{}
 
 // Flush removes all items associated with the current tags.
 func (c *TaggedCache) Flush(ctx context.Context) error {
+	if err := c.writable("flush"); err != nil {
+		return err
+	}
 	if len(c.tags) == 0 {
 		return nil
 	}
+	if err := c.drainWrites(ctx); err != nil {
+		return err
+	}
+
+	if c.flushTagsBatchSize > 0 {
+		return c.flushIncremental(ctx)
+	}
 
 	// Load Lua script
 	script := redis.NewScript(`
 		local prefix = ARGV[1]
+		local separator = ARGV[2]
 		local keysToDelete = {}
 		local tagsToDelete = {}
 
 		for i, tagName in ipairs(KEYS) do
-			local tagKey = prefix .. ":tag:" .. tagName
+			local tagKey = prefix .. separator .. "tag" .. separator .. tagName
 			table.insert(tagsToDelete, tagKey)
 			
 			local keys = redis.call("SMEMBERS", tagKey)
@@ -183,5 +482,127 @@ func (c *TaggedCache) Flush(ctx context.Context) error {
 		return #keysToDelete
 	`)
 
-	return script.Run(ctx, c.client, c.tags, c.prefix).Err()
+	return script.Run(ctx, c.client, c.tags, c.prefix, c.separator).Err()
+}
+
+// flushIncremental deletes the tagged keys in batches using SSCAN.
+// The context is checked between batches; on cancellation the remaining
+// members and the tag set are kept, so the flush can simply be retried.
+func (c *TaggedCache) flushIncremental(ctx context.Context) error {
+	for _, tag := range c.tags {
+		tagKey := c.tagKey(tag)
+		if err := c.deleteMembers(ctx, tagKey, c.flushTagsBatchSize); err != nil {
+			return err
+		}
+		if err := c.client.Del(ctx, tagKey).Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteMembers deletes the keys in a tag set in batches of batchSize using
+// SSCAN, checking the context between batches. The set itself is untouched.
+func (d *Driver) deleteMembers(ctx context.Context, tagKey string, batchSize int) error {
+	var cursor uint64
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		members, next, err := d.client.SScan(ctx, tagKey, cursor, "", int64(batchSize)).Result()
+		if err != nil {
+			return err
+		}
+		if len(members) > 0 {
+			if err := d.client.Del(ctx, members...).Err(); err != nil {
+				return err
+			}
+		}
+
+		cursor = next
+		if cursor == 0 {
+			return nil
+		}
+	}
+}
+
+// FlushTagKeysOnly deletes the keys in the tag sets in batches using SSCAN,
+// but keeps the sets and their members. Redis cannot store an empty set, so
+// the members stay until KeysForTag or PruneTags prunes them as expired;
+// tagging a key again that is still a member is a no-op.
+func (d *Driver) FlushTagKeysOnly(ctx context.Context, tags ...string) error {
+	if err := d.writable("flush_tag_keys_only"); err != nil {
+		return err
+	}
+	if err := d.drainWrites(ctx); err != nil {
+		return err
+	}
+	batchSize := d.flushTagsBatchSize
+	if batchSize <= 0 {
+		batchSize = 1000
+	}
+
+	for _, tag := range tags {
+		if err := d.deleteMembers(ctx, d.tagKey(tag), batchSize); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// renameScript renames KEYS[1] to KEYS[2], moves the sliding TTL record
+// KEYS[3] to KEYS[4], and moves oldKey's memberships in the tag sets KEYS[5..]
+// to newKey, dropping newKey's previous record and memberships.
+// It returns 0 without changing anything if KEYS[1] does not exist.
+var renameScript = redis.NewScript(`
+	if redis.call("EXISTS", KEYS[1]) == 0 then
+		return 0
+	end
+	if redis.call("EXISTS", KEYS[3]) == 1 then
+		redis.call("RENAME", KEYS[3], KEYS[4])
+	else
+		redis.call("DEL", KEYS[4])
+	end
+	for i = 5, #KEYS do
+		local tagged = redis.call("SREM", KEYS[i], KEYS[1])
+		redis.call("SREM", KEYS[i], KEYS[2])
+		if tagged == 1 then
+			redis.call("SADD", KEYS[i], KEYS[2])
+		end
+	end
+	redis.call("RENAME", KEYS[1], KEYS[2])
+	return 1
+`)
+
+// Rename moves the value at oldKey to newKey with RENAME, which keeps the remaining TTL.
+// The rename and the tag updates run in one script, so newKey takes over exactly
+// oldKey's tags and sliding TTL: memberships newKey had before the rename are removed.
+func (d *Driver) Rename(ctx context.Context, oldKey, newKey string) error {
+	if err := d.writable("rename"); err != nil {
+		return err
+	}
+	if err := d.drainWrites(ctx); err != nil {
+		return err
+	}
+	oldPrefixed, newPrefixed := d.prefixKey(oldKey), d.prefixKey(newKey)
+	keys := []string{oldPrefixed, newPrefixed, d.slideKey(oldPrefixed), d.slideKey(newPrefixed)}
+
+	tagPattern := d.prefix + d.separator + "tag" + d.separator + "*"
+	iter := d.client.Scan(ctx, 0, tagPattern, 1000).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return err
+	}
+
+	renamed, err := renameScript.Run(ctx, d.client, keys).Int()
+	if err != nil {
+		return err
+	}
+	if renamed == 0 {
+		return dgcache.ErrKeyNotFound
+	}
+	return nil
 }
@@ -0,0 +1,299 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	dgcache "github.com/donnigundala/dg-cache"
+	"github.com/donnigundala/dg-core/contracts/cache"
+	"github.com/redis/go-redis/v9"
+)
+
+// stagedValue is the state of a key written inside a transaction: the value
+// and the bytes it is written to Redis as.
+type stagedValue struct {
+	value   interface{}
+	data    []byte
+	deleted bool
+}
+
+// transaction queues writes for a MULTI/EXEC block.
+// Keys read through it are WATCHed, so the transaction aborts with
+// ErrTransactionConflict if any of them changes before EXEC.
+type transaction struct {
+	d       *Driver
+	tx      *redis.Tx
+	cmds    []func(pipe redis.Pipeliner)
+	staged  map[string]stagedValue
+	flushed bool
+}
+
+// Transaction runs fn and applies its writes atomically with MULTI/EXEC.
+// Reads inside fn execute immediately and WATCH the keys they touch.
+func (d *Driver) Transaction(ctx context.Context, fn func(tx cache.Store) error) error {
+	if err := d.writable("transaction"); err != nil {
+		return err
+	}
+	if err := d.drainWrites(ctx); err != nil {
+		return err
+	}
+	err := d.client.Watch(ctx, func(rtx *redis.Tx) error {
+		t := &transaction{
+			d:      d,
+			tx:     rtx,
+			staged: make(map[string]stagedValue),
+		}
+		if err := fn(t); err != nil {
+			return err
+		}
+		if len(t.cmds) == 0 {
+			return nil
+		}
+
+		_, err := rtx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			for _, cmd := range t.cmds {
+				cmd(pipe)
+			}
+			return nil
+		})
+		return err
+	})
+	if errors.Is(err, redis.TxFailedErr) {
+		return dgcache.ErrTransactionConflict
+	}
+	return err
+}
+
+// lookup returns the value of key as seen by the transaction, watching it
+// if it has to be read from Redis.
+func (t *transaction) lookup(ctx context.Context, key string) (interface{}, bool, error) {
+	if staged, ok := t.staged[key]; ok {
+		return staged.value, !staged.deleted, nil
+	}
+	data, ok, err := t.read(ctx, key)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+	value, ok := t.d.decodeValue(key, data)
+	return value, ok, nil
+}
+
+// read returns the bytes key holds in Redis, watching it, unless the
+// transaction flushed the store.
+func (t *transaction) read(ctx context.Context, key string) ([]byte, bool, error) {
+	if t.flushed {
+		return nil, false, nil
+	}
+
+	prefixedKey := t.d.prefixKey(key)
+	if err := t.tx.Watch(ctx, prefixedKey).Err(); err != nil {
+		return nil, false, err
+	}
+	data, err := t.tx.Get(ctx, prefixedKey).Bytes()
+	if err == redis.Nil {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return data, true, nil
+}
+
+// counterValue returns the integer INCRBY will find at key on commit. The
+// bytes staged or stored there must be a bare decimal integer, as Redis
+// requires; anything else, including a number the serializer wrapped or
+// quoted, is rejected while staging so EXEC never fails halfway.
+func (t *transaction) counterValue(ctx context.Context, key string) (int64, bool, error) {
+	var data []byte
+	var ok bool
+	if staged, isStaged := t.staged[key]; isStaged {
+		data, ok = staged.data, !staged.deleted
+	} else {
+		var err error
+		if data, ok, err = t.read(ctx, key); err != nil {
+			return 0, false, err
+		}
+	}
+	if !ok {
+		return 0, false, nil
+	}
+
+	n, err := strconv.ParseInt(string(data), 10, 64)
+	if err != nil || strconv.FormatInt(n, 10) != string(data) {
+		return 0, false, fmt.Errorf("%w: %q does not hold an integer counter (values written with Put are serialized)", dgcache.ErrNotANumber, key)
+	}
+	return n, true, nil
+}
+
+// Get retrieves a value, including values staged in the transaction.
+func (t *transaction) Get(ctx context.Context, key string) (interface{}, error) {
+	value, ok, err := t.lookup(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, dgcache.ErrKeyNotFound
+	}
+	return value, nil
+}
+
+// GetMultiple retrieves multiple values, including values staged in the transaction.
+func (t *transaction) GetMultiple(ctx context.Context, keys []string) (map[string]interface{}, error) {
+	result := make(map[string]interface{})
+	for _, key := range keys {
+		value, ok, err := t.lookup(ctx, key)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			result[key] = value
+		}
+	}
+	return result, nil
+}
+
+// Put queues a value with the given TTL.
+func (t *transaction) Put(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	ttl, err := t.d.ttlLimits.Apply(ttl)
+	if err != nil {
+		return err
+	}
+	data, err := t.d.marshal(key, value)
+	if err != nil {
+		return err
+	}
+
+	prefixedKey := t.d.prefixKey(key)
+	t.staged[key] = stagedValue{value: value, data: data}
+	t.cmds = append(t.cmds, func(pipe redis.Pipeliner) {
+		pipe.Set(ctx, prefixedKey, data, ttl)
+		t.d.recordSlide(ctx, pipe, prefixedKey, ttl)
+	})
+	return nil
+}
+
+// PutMultiple queues multiple values with the given TTL.
+func (t *transaction) PutMultiple(ctx context.Context, items map[string]interface{}, ttl time.Duration) error {
+	for key, value := range items {
+		if err := t.Put(ctx, key, value, ttl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Increment queues an increment and returns the value the key will hold on commit.
+func (t *transaction) Increment(ctx context.Context, key string, value int64) (int64, error) {
+	n, ok, err := t.counterValue(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+
+	// INCRBY would fail on commit, so reject the overflow while staging
+	newValue := n + value
+	if (n >= 0) == (value >= 0) && (newValue >= 0) != (n >= 0) {
+		return 0, fmt.Errorf("%w: %q", dgcache.ErrOverflow, key)
+	}
+	// A counter the transaction creates gets the TTL limits of a write without expiry
+	var ttl time.Duration
+	if !ok {
+		if ttl, err = t.d.ttlLimits.Apply(0); err != nil {
+			return 0, err
+		}
+	}
+	prefixedKey := t.d.prefixKey(key)
+	t.staged[key] = stagedValue{value: newValue, data: []byte(strconv.FormatInt(newValue, 10))}
+	t.cmds = append(t.cmds, func(pipe redis.Pipeliner) {
+		pipe.IncrBy(ctx, prefixedKey, value)
+		if ttl > 0 {
+			pipe.PExpire(ctx, prefixedKey, ttl)
+		}
+	})
+	return newValue, nil
+}
+
+// Decrement queues a decrement and returns the value the key will hold on commit.
+func (t *transaction) Decrement(ctx context.Context, key string, value int64) (int64, error) {
+	// -math.MinInt64 wraps around to itself
+	if value == math.MinInt64 {
+		return 0, fmt.Errorf("%w: %q decremented by %d", dgcache.ErrOverflow, key, value)
+	}
+	return t.Increment(ctx, key, -value)
+}
+
+// Forever queues a value without expiry.
+func (t *transaction) Forever(ctx context.Context, key string, value interface{}) error {
+	return t.Put(ctx, key, value, 0)
+}
+
+// Forget queues the removal of a key.
+func (t *transaction) Forget(ctx context.Context, key string) error {
+	prefixedKey := t.d.prefixKey(key)
+	t.staged[key] = stagedValue{deleted: true}
+	t.cmds = append(t.cmds, func(pipe redis.Pipeliner) {
+		pipe.Del(ctx, prefixedKey)
+	})
+	return nil
+}
+
+// ForgetMultiple queues the removal of multiple keys.
+func (t *transaction) ForgetMultiple(ctx context.Context, keys []string) error {
+	for _, key := range keys {
+		_ = t.Forget(ctx, key)
+	}
+	return nil
+}
+
+// Flush queues the removal of all keys. Under the "prefix" flush_scope the
+// keys are found with SCAN now and deleted at EXEC.
+func (t *transaction) Flush(ctx context.Context) error {
+	var keys []string
+	if !t.d.flushesDB() {
+		var err error
+		if keys, err = t.d.scopedKeys(ctx); err != nil {
+			return err
+		}
+	}
+
+	t.staged = make(map[string]stagedValue)
+	t.flushed = true
+	t.cmds = append(t.cmds, func(pipe redis.Pipeliner) {
+		if t.d.flushesDB() {
+			pipe.FlushDB(ctx)
+			return
+		}
+		for start := 0; start < len(keys); start += 1000 {
+			pipe.Del(ctx, keys[start:min(start+1000, len(keys))]...)
+		}
+	})
+	return nil
+}
+
+// Has checks if a key exists, including values staged in the transaction.
+func (t *transaction) Has(ctx context.Context, key string) (bool, error) {
+	_, ok, err := t.lookup(ctx, key)
+	return ok, err
+}
+
+// Missing checks if a key does not exist, including values staged in the transaction.
+func (t *transaction) Missing(ctx context.Context, key string) (bool, error) {
+	has, err := t.Has(ctx, key)
+	return !has, err
+}
+
+// GetPrefix returns the driver's key prefix.
+func (t *transaction) GetPrefix() string {
+	return t.d.GetPrefix()
+}
+
+// SetPrefix is a no-op; the prefix cannot be changed inside a transaction.
+func (t *transaction) SetPrefix(prefix string) {}
+
+// Stats returns the driver's statistics.
+func (t *transaction) Stats() cache.Stats {
+	return t.d.Stats()
+}
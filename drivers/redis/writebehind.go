@@ -0,0 +1,221 @@
+package redis
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// ErrWriteBehindFull is returned by Put and PutMultiple in write-behind mode
+// when the queue is full and the "drop" policy discards the write.
+var ErrWriteBehindFull = errors.New("cache: write-behind queue is full")
+
+// pendingWrite is a serialized value waiting to be flushed, or a barrier
+// that is closed once every write queued before it has been flushed.
+type pendingWrite struct {
+	key  string // prefixed
+	data []byte
+	ttl  time.Duration
+
+	barrier chan struct{}
+}
+
+// writeBehind queues writes and flushes them to Redis in the background.
+type writeBehind struct {
+	d         *Driver
+	queue     chan pendingWrite
+	batchSize int
+	drop      bool
+
+	// failed counts writes that were dropped or failed to flush.
+	failed atomic.Int64
+
+	// mu guards closed; enqueue holds it shared so close waits for senders.
+	mu     sync.RWMutex
+	closed bool
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// startWriteBehind starts the background flusher described by config.
+func (d *Driver) startWriteBehind(config Config) {
+	wb := &writeBehind{
+		d:         d,
+		queue:     make(chan pendingWrite, max(config.WriteBehindBufferSize, 1)),
+		batchSize: max(config.WriteBehindBatchSize, 1),
+		drop:      config.WriteBehindFullPolicy == "drop",
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+	interval := config.WriteBehindFlushInterval
+	if interval <= 0 {
+		interval = 100 * time.Millisecond
+	}
+
+	go wb.run(interval)
+	d.writeBehind = wb
+}
+
+// enqueue queues writes in order. Under the block policy it waits for room
+// until ctx is done; under the drop policy it discards what does not fit.
+func (wb *writeBehind) enqueue(ctx context.Context, writes ...pendingWrite) error {
+	wb.mu.RLock()
+	defer wb.mu.RUnlock()
+	if wb.closed {
+		return errors.New("cache: write-behind queue is closed")
+	}
+
+	for i, w := range writes {
+		if wb.drop {
+			select {
+			case wb.queue <- w:
+				continue
+			default:
+				wb.failed.Add(int64(len(writes) - i))
+				return ErrWriteBehindFull
+			}
+		}
+
+		select {
+		case wb.queue <- w:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+// drain waits until every write queued before the call has been flushed,
+// so a removal that follows cannot be undone by a write still in the queue.
+// The barrier waits for room even under the drop policy.
+func (wb *writeBehind) drain(ctx context.Context) error {
+	barrier := make(chan struct{})
+
+	wb.mu.RLock()
+	if wb.closed {
+		// close has flushed the queue already
+		wb.mu.RUnlock()
+		return nil
+	}
+	select {
+	case wb.queue <- pendingWrite{barrier: barrier}:
+		wb.mu.RUnlock()
+	case <-ctx.Done():
+		wb.mu.RUnlock()
+		return ctx.Err()
+	}
+
+	select {
+	case <-barrier:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run collects queued writes into batches, flushing a batch when it is full,
+// when the interval passes, and when the queue is closed.
+func (wb *writeBehind) run(interval time.Duration) {
+	defer close(wb.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	batch := make([]pendingWrite, 0, wb.batchSize)
+	flush := func() {
+		if len(batch) > 0 {
+			wb.flush(batch)
+			batch = batch[:0]
+		}
+	}
+	add := func(w pendingWrite) {
+		if w.barrier != nil {
+			flush()
+			close(w.barrier)
+			return
+		}
+		batch = append(batch, w)
+		if len(batch) >= wb.batchSize {
+			flush()
+		}
+	}
+
+	for {
+		select {
+		case w := <-wb.queue:
+			add(w)
+		case <-ticker.C:
+			flush()
+		case <-wb.stop:
+			// Drain whatever is left; enqueue no longer accepts writes
+			for {
+				select {
+				case w := <-wb.queue:
+					add(w)
+				default:
+					flush()
+					return
+				}
+			}
+		}
+	}
+}
+
+// flush writes a batch in one pipeline. The caller's context is gone by now,
+// so the flush runs without one.
+func (wb *writeBehind) flush(batch []pendingWrite) {
+	ctx := context.Background()
+	pipe := wb.d.client.Pipeline()
+	sets := make([]*redis.StatusCmd, len(batch))
+	for i, w := range batch {
+		sets[i] = pipe.Set(ctx, w.key, w.data, w.ttl)
+		wb.d.recordSlide(ctx, pipe, w.key, w.ttl)
+	}
+
+	_, _ = pipe.Exec(ctx)
+	for _, cmd := range sets {
+		if cmd.Err() != nil {
+			wb.failed.Add(1)
+			continue
+		}
+		wb.d.recordSet()
+	}
+}
+
+// close stops accepting writes and waits for the queue to be flushed.
+func (wb *writeBehind) close() {
+	wb.mu.Lock()
+	if wb.closed {
+		wb.mu.Unlock()
+		return
+	}
+	wb.closed = true
+	wb.mu.Unlock()
+
+	close(wb.stop)
+	<-wb.done
+}
+
+// drainWrites flushes the writes queued so far in write-behind mode, before
+// an operation that removes or moves keys.
+func (d *Driver) drainWrites(ctx context.Context) error {
+	if d.writeBehind == nil {
+		return nil
+	}
+	return d.writeBehind.drain(ctx)
+}
+
+// FailedWrites returns the number of write-behind writes that were dropped
+// because the queue was full or failed when flushed. It is 0 when
+// write-behind is disabled.
+func (d *Driver) FailedWrites() int64 {
+	if d.writeBehind == nil {
+		return 0
+	}
+	return d.writeBehind.failed.Load()
+}
@@ -2,6 +2,8 @@ package dgcache
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 )
 
 // Error types for cache operations.
@@ -17,6 +19,42 @@ var (
 
 	// ErrStoreNotFound is returned when a cache store is not found.
 	ErrStoreNotFound = fmt.Errorf("cache: store not found")
+
+	// ErrFieldNotFound is returned when a field path does not exist in a cached value.
+	ErrFieldNotFound = fmt.Errorf("cache: field not found")
+
+	// ErrTTLOutOfRange is returned when a write's TTL violates the store's TTL limits.
+	ErrTTLOutOfRange = fmt.Errorf("cache: ttl out of range")
+
+	// ErrTransactionConflict is returned when a transaction is aborted because
+	// a key it read was modified concurrently.
+	ErrTransactionConflict = fmt.Errorf("cache: transaction conflict")
+
+	// ErrUnsupportedExportVersion is returned by ImportStore for data written in an unknown format version.
+	ErrUnsupportedExportVersion = fmt.Errorf("cache: unsupported export version")
+
+	// ErrTagLimitExceeded is returned when tagging a key would grow a tag past
+	// the store's per-tag key limit.
+	ErrTagLimitExceeded = fmt.Errorf("cache: tag key limit exceeded")
+
+	// ErrWrongType is returned when an operation targets a key holding a
+	// different kind of value, such as a hash operation on a plain value.
+	ErrWrongType = fmt.Errorf("cache: key holds the wrong kind of value")
+
+	// ErrNotANumber is returned when incrementing or decrementing a key whose
+	// value is not an integer. The value is left unchanged.
+	ErrNotANumber = fmt.Errorf("cache: value is not an integer")
+
+	// ErrOverflow is returned when incrementing or decrementing a counter
+	// would overflow int64. The value is left unchanged.
+	ErrOverflow = fmt.Errorf("cache: increment or decrement would overflow")
+
+	// ErrReadOnly is returned when writing to a store that only serves reads,
+	// such as a Redis store connected to a read replica.
+	ErrReadOnly = fmt.Errorf("cache: store is read-only")
+
+	// ErrNotSupported is returned when a store does not support an optional operation.
+	ErrNotSupported = fmt.Errorf("cache: operation not supported by store")
 )
 
 // ErrInvalidConfig returns a configuration error with a formatted message.
@@ -28,3 +66,84 @@ func ErrInvalidConfig(format string, args ...interface{}) error {
 func ErrDriverError(driver string, err error) error {
 	return fmt.Errorf("cache: driver '%s' error: %w", driver, err)
 }
+
+// CachedError is returned by Remember when a previous callback failure for the
+// key is still cached (see Config.ErrorTTL). It is never returned as a value.
+type CachedError struct {
+	// Key is the cache key whose callback failed.
+	Key string
+
+	// Message is the message of the original callback error.
+	Message string
+}
+
+// Error implements the error interface.
+func (e *CachedError) Error() string {
+	return fmt.Sprintf("cache: cached callback error for key '%s': %s", e.Key, e.Message)
+}
+
+// BatchError reports the items of a batch operation that failed while the
+// rest of the batch was applied.
+type BatchError struct {
+	// Errors maps each failed key to its error.
+	Errors map[string]error
+}
+
+// Keys returns the failed keys in sorted order.
+func (e *BatchError) Keys() []string {
+	keys := make([]string, 0, len(e.Errors))
+	for key := range e.Errors {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Error implements the error interface.
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("cache: %d item(s) failed: %s", len(e.Errors), strings.Join(e.Keys(), ", "))
+}
+
+// Unwrap returns the individual item errors.
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Errors))
+	for _, key := range e.Keys() {
+		errs = append(errs, e.Errors[key])
+	}
+	return errs
+}
+
+// PanicError is returned by Remember when its callback panics and
+// Config.RecoverPanics is enabled.
+type PanicError struct {
+	Key   string
+	Value interface{}
+	Stack []byte
+}
+
+// Error implements the error interface.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("cache: callback for key '%s' panicked: %v", e.Key, e.Value)
+}
+
+// CacheError adds the store, operation, and key to an error returned by the Manager.
+// errors.Is and errors.As see through it to the underlying error.
+type CacheError struct {
+	Store string
+	Op    string
+	Key   string
+	Err   error
+}
+
+// Error implements the error interface.
+func (e *CacheError) Error() string {
+	if e.Key == "" {
+		return fmt.Sprintf("cache: %s on store '%s': %v", e.Op, e.Store, e.Err)
+	}
+	return fmt.Sprintf("cache: %s '%s' on store '%s': %v", e.Op, e.Key, e.Store, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *CacheError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,132 @@
+package dgcache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/donnigundala/dg-cache/serializer"
+	"github.com/donnigundala/dg-core/contracts/cache"
+)
+
+// exportVersion is the version of the format written by ExportStore.
+const exportVersion = 1
+
+// exportHeader is the first line of an export.
+type exportHeader struct {
+	Version int `json:"version"`
+}
+
+// exportRecord is one exported item. Values are encoded with the JSON
+// serializer, so they read back like values from a JSON-serialized store.
+type exportRecord struct {
+	Key       string          `json:"key"`
+	Value     json.RawMessage `json:"value"`
+	ExpiresAt *time.Time      `json:"expires_at,omitempty"`
+	Tags      []string        `json:"tags,omitempty"`
+}
+
+// ExportStore writes every item of store to w as newline-delimited JSON: a
+// version header followed by one record per item with its key, value,
+// absolute expiry, and tags. Callback errors cached by Remember are left out.
+// The store must implement Exporter.
+func ExportStore(ctx context.Context, store cache.Store, w io.Writer) error {
+	exporter, ok := capability[Exporter](store)
+	if !ok {
+		return ErrNotSupported
+	}
+
+	enc := json.NewEncoder(w)
+	if err := enc.Encode(exportHeader{Version: exportVersion}); err != nil {
+		return err
+	}
+
+	ser := serializer.NewJSONSerializer()
+	return exporter.Export(ctx, func(item Item) error {
+		// Cached callback errors are bookkeeping, not data
+		if isErrorKey(item.Key) {
+			return nil
+		}
+		data, err := ser.Marshal(item.Value)
+		if err != nil {
+			return fmt.Errorf("cache: export key '%s': %w", item.Key, err)
+		}
+
+		record := exportRecord{Key: item.Key, Value: data, Tags: item.Tags}
+		if !item.ExpiresAt.IsZero() {
+			expiresAt := item.ExpiresAt
+			record.ExpiresAt = &expiresAt
+		}
+		return enc.Encode(record)
+	})
+}
+
+// ImportStore reads items written by ExportStore from r and stores them in
+// store with their remaining TTL and tags. Items that expired since the export
+// are skipped. Tags are ignored if the store does not support tagging.
+func ImportStore(ctx context.Context, store cache.Store, r io.Reader) error {
+	dec := json.NewDecoder(r)
+
+	var header exportHeader
+	if err := dec.Decode(&header); err != nil {
+		return fmt.Errorf("cache: import header: %w", err)
+	}
+	if header.Version != exportVersion {
+		return fmt.Errorf("%w: %d", ErrUnsupportedExportVersion, header.Version)
+	}
+
+	ser := serializer.NewJSONSerializer()
+	taggable, _ := capability[cache.TaggedStore](store)
+	for dec.More() {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		var record exportRecord
+		if err := dec.Decode(&record); err != nil {
+			return fmt.Errorf("cache: import record: %w", err)
+		}
+
+		var value interface{}
+		if err := ser.Unmarshal(record.Value, &value); err != nil {
+			return fmt.Errorf("cache: import key '%s': %w", record.Key, err)
+		}
+
+		var ttl time.Duration
+		if record.ExpiresAt != nil {
+			ttl = time.Until(*record.ExpiresAt)
+			if ttl <= 0 {
+				continue
+			}
+		}
+
+		target := store
+		if len(record.Tags) > 0 && taggable != nil {
+			target = taggable.Tags(record.Tags...)
+		}
+		if err := target.Put(ctx, record.Key, value, ttl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Export writes every item of the default cache store to w. See ExportStore.
+func (m *Manager) Export(ctx context.Context, w io.Writer) error {
+	store, err := m.Store("")
+	if err != nil {
+		return m.wrapError("export", "", err)
+	}
+	return m.wrapError("export", "", ExportStore(ctx, store, w))
+}
+
+// Import reads items written by Export into the default cache store. See ImportStore.
+func (m *Manager) Import(ctx context.Context, r io.Reader) error {
+	store, err := m.Store("")
+	if err != nil {
+		return m.wrapError("import", "", err)
+	}
+	return m.wrapError("import", "", ImportStore(ctx, store, r))
+}
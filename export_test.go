@@ -0,0 +1,87 @@
+package dgcache_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	dgcache "github.com/donnigundala/dg-cache"
+	"github.com/donnigundala/dg-cache/drivers/memory"
+	contracts "github.com/donnigundala/dg-core/contracts/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportImport(t *testing.T) {
+	ctx := context.Background()
+
+	source, err := memory.NewDriver(dgcache.StoreConfig{Driver: "memory"})
+	require.NoError(t, err)
+	defer source.Close()
+	target, err := memory.NewDriver(dgcache.StoreConfig{Driver: "memory"})
+	require.NoError(t, err)
+	defer target.Close()
+
+	require.NoError(t, source.Put(ctx, "name", "john", time.Hour))
+	require.NoError(t, source.Forever(ctx, "forever", []interface{}{"a", "b"}))
+	require.NoError(t, source.(contracts.TaggedStore).Tags("users").Put(ctx, "user:1", "jane", time.Hour))
+
+	var buf bytes.Buffer
+	require.NoError(t, dgcache.ExportStore(ctx, source, &buf))
+	assert.True(t, strings.HasPrefix(buf.String(), `{"version":1}`))
+	require.NoError(t, dgcache.ImportStore(ctx, target, &buf))
+
+	val, err := target.Get(ctx, "name")
+	require.NoError(t, err)
+	assert.Equal(t, "john", val)
+	val, err = target.Get(ctx, "forever")
+	require.NoError(t, err)
+	assert.Equal(t, []interface{}{"a", "b"}, val)
+
+	// Remaining TTLs and tags transfer
+	var items []dgcache.Item
+	require.NoError(t, target.(dgcache.Exporter).Export(ctx, func(item dgcache.Item) error {
+		items = append(items, item)
+		return nil
+	}))
+	require.Len(t, items, 3)
+	for _, item := range items {
+		switch item.Key {
+		case "forever":
+			assert.True(t, item.ExpiresAt.IsZero())
+		default:
+			assert.WithinDuration(t, time.Now().Add(time.Hour), item.ExpiresAt, time.Minute)
+		}
+	}
+
+	keys, err := target.(dgcache.TagIntrospectable).KeysForTag(ctx, "users")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user:1"}, keys)
+}
+
+func TestImportSkipsExpired(t *testing.T) {
+	ctx := context.Background()
+	manager := createManager(t)
+
+	data := `{"version":1}
+{"key":"old","value":"v","expires_at":"2000-01-01T00:00:00Z"}
+{"key":"new","value":"v"}
+`
+	require.NoError(t, manager.Import(ctx, strings.NewReader(data)))
+
+	has, err := manager.Has(ctx, "old")
+	require.NoError(t, err)
+	assert.False(t, has)
+	has, err = manager.Has(ctx, "new")
+	require.NoError(t, err)
+	assert.True(t, has)
+}
+
+func TestImportUnsupportedVersion(t *testing.T) {
+	manager := createManager(t)
+
+	err := manager.Import(context.Background(), strings.NewReader(`{"version":99}`))
+	assert.ErrorIs(t, err, dgcache.ErrUnsupportedExportVersion)
+}
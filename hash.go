@@ -0,0 +1,56 @@
+package dgcache
+
+import (
+	"context"
+)
+
+// hashStore returns the default store as a HashStore.
+func (m *Manager) hashStore() (HashStore, error) {
+	store, err := m.Store("")
+	if err != nil {
+		return nil, err
+	}
+	hashes, ok := capability[HashStore](store)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+	return hashes, nil
+}
+
+// HGet returns the value of field in the hash at key in the default cache store.
+func (m *Manager) HGet(ctx context.Context, key, field string) (interface{}, error) {
+	hashes, err := m.hashStore()
+	if err != nil {
+		return nil, m.wrapError("hget", key, err)
+	}
+	value, err := hashes.HGet(ctx, key, field)
+	return value, m.wrapError("hget", key, err)
+}
+
+// HSet sets field in the hash at key in the default cache store.
+func (m *Manager) HSet(ctx context.Context, key, field string, value interface{}) error {
+	hashes, err := m.hashStore()
+	if err != nil {
+		return m.wrapError("hset", key, err)
+	}
+	return m.wrapError("hset", key, hashes.HSet(ctx, key, field, value))
+}
+
+// HGetAll returns every field of the hash at key in the default cache store.
+func (m *Manager) HGetAll(ctx context.Context, key string) (map[string]interface{}, error) {
+	hashes, err := m.hashStore()
+	if err != nil {
+		return nil, m.wrapError("hgetall", key, err)
+	}
+	fields, err := hashes.HGetAll(ctx, key)
+	return fields, m.wrapError("hgetall", key, err)
+}
+
+// HDel removes fields from the hash at key in the default cache store.
+func (m *Manager) HDel(ctx context.Context, key string, fields ...string) error {
+	hashes, err := m.hashStore()
+	if err != nil {
+		return m.wrapError("hdel", key, err)
+	}
+	return m.wrapError("hdel", key, hashes.HDel(ctx, key, fields...))
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
 
 	"github.com/donnigundala/dg-core/contracts/cache"
 	"github.com/donnigundala/dg-core/contracts/foundation"
@@ -18,6 +19,61 @@ func (m *Manager) GetAs(ctx context.Context, key string, dest interface{}) error
 		return err
 	}
 
+	return assignValue(value, dest)
+}
+
+// GetAsMultiple retrieves the keys of dests in one batch and decodes each value
+// into its destination pointer, as GetAs does. A failed key does not abort the
+// batch: found lists the decoded keys in sorted order, and errs maps every other
+// key to its error (ErrKeyNotFound when absent).
+func (m *Manager) GetAsMultiple(ctx context.Context, dests map[string]interface{}) (found []string, errs map[string]error) {
+	errs = make(map[string]error)
+	if len(dests) == 0 {
+		return nil, errs
+	}
+
+	keys := make([]string, 0, len(dests))
+	for key := range dests {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	values, err := m.GetMultiple(ctx, keys)
+	if err != nil {
+		for _, key := range keys {
+			errs[key] = err
+		}
+		return nil, errs
+	}
+
+	for _, key := range keys {
+		value, ok := values[key]
+		if !ok {
+			errs[key] = m.wrapError("get", key, ErrKeyNotFound)
+			continue
+		}
+		if err := assignValue(value, dests[key]); err != nil {
+			errs[key] = m.wrapError("get", key, err)
+			continue
+		}
+		found = append(found, key)
+	}
+	return found, errs
+}
+
+// PullInto retrieves a value, deletes it, and decodes it into dest as GetAs
+// does. The key is deleted even if decoding fails, so a one-shot token can
+// never be used twice.
+func (m *Manager) PullInto(ctx context.Context, key string, dest interface{}) error {
+	value, err := m.Pull(ctx, key)
+	if err != nil {
+		return err
+	}
+	return assignValue(value, dest)
+}
+
+// assignValue stores a cached value into the destination pointer dest.
+func assignValue(value interface{}, dest interface{}) error {
 	// If value is nil, return error
 	if value == nil {
 		return ErrKeyNotFound
@@ -38,6 +94,12 @@ func (m *Manager) GetAs(ctx context.Context, key string, dest interface{}) error
 		return nil
 	}
 
+	// A stored pointer of the same type is copied into dest
+	if valueType == destType && !reflect.ValueOf(value).IsNil() {
+		reflect.ValueOf(dest).Elem().Set(reflect.ValueOf(value).Elem())
+		return nil
+	}
+
 	// If value is already the right type (for interface{} cases)
 	if valueType.AssignableTo(destType.Elem()) {
 		reflect.ValueOf(dest).Elem().Set(reflect.ValueOf(value))
@@ -88,6 +150,11 @@ func (m *Manager) GetInt(ctx context.Context, key string) (int, error) {
 		return int(i64), nil
 	}
 
+	if n, ok := val.(json.Number); ok {
+		i64, err := numberToInt64(n)
+		return int(i64), err
+	}
+
 	return 0, fmt.Errorf("value is not an int: got %T", val)
 }
 
@@ -110,6 +177,10 @@ func (m *Manager) GetInt64(ctx context.Context, key string) (int64, error) {
 		return int64(i), nil
 	}
 
+	if n, ok := val.(json.Number); ok {
+		return numberToInt64(n)
+	}
+
 	return 0, fmt.Errorf("value is not an int64: got %T", val)
 }
 
@@ -132,9 +203,26 @@ func (m *Manager) GetFloat64(ctx context.Context, key string) (float64, error) {
 		return float64(i), nil
 	}
 
+	if n, ok := val.(json.Number); ok {
+		return n.Float64()
+	}
+
 	return 0, fmt.Errorf("value is not a float64: got %T", val)
 }
 
+// numberToInt64 converts a json.Number exactly when it is an integer, and
+// truncates it like a float64 value otherwise.
+func numberToInt64(n json.Number) (int64, error) {
+	if i64, err := n.Int64(); err == nil {
+		return i64, nil
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return 0, err
+	}
+	return int64(f), nil
+}
+
 // GetBool retrieves a bool value from the cache.
 func (m *Manager) GetBool(ctx context.Context, key string) (bool, error) {
 	val, err := m.Get(ctx, key)
@@ -149,6 +237,50 @@ func (m *Manager) GetBool(ctx context.Context, key string) (bool, error) {
 	return false, fmt.Errorf("value is not a bool: got %T", val)
 }
 
+// MatchKey reports whether key matches the glob pattern.
+// It supports the Redis-style wildcards '*' (any sequence) and '?' (any single character).
+func MatchKey(pattern, key string) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '*':
+			// Collapse consecutive stars
+			for len(pattern) > 0 && pattern[0] == '*' {
+				pattern = pattern[1:]
+			}
+			if len(pattern) == 0 {
+				return true
+			}
+			for i := 0; i <= len(key); i++ {
+				if MatchKey(pattern, key[i:]) {
+					return true
+				}
+			}
+			return false
+		case '?':
+			if len(key) == 0 {
+				return false
+			}
+		default:
+			if len(key) == 0 || pattern[0] != key[0] {
+				return false
+			}
+		}
+		pattern = pattern[1:]
+		key = key[1:]
+	}
+	return len(key) == 0
+}
+
+// MatchAny reports whether key matches at least one of the glob patterns.
+func MatchAny(patterns []string, key string) bool {
+	for _, pattern := range patterns {
+		if MatchKey(pattern, key) {
+			return true
+		}
+	}
+	return false
+}
+
 // Resolve resolves the main cache manager from the application container.
 func Resolve(app foundation.Application) (cache.Cache, error) {
 	instance, err := app.Make(Binding)
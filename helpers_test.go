@@ -2,13 +2,16 @@ package dgcache_test
 
 import (
 	"context"
+	"encoding/json"
 	"testing"
+	"time"
 
 	cache "github.com/donnigundala/dg-cache"
 	dgcache "github.com/donnigundala/dg-cache"
 	"github.com/donnigundala/dg-cache/drivers/memory"
 	"github.com/donnigundala/dg-core/foundation"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // -----------------------------------------------------------------------------
@@ -64,6 +67,28 @@ func TestManager_GetFloat64(t *testing.T) {
 	_, _ = manager.GetFloat64(ctx, "key")
 }
 
+func TestManager_GetNumberJSONNumber(t *testing.T) {
+	manager, _ := dgcache.NewManager(dgcache.DefaultConfig())
+	manager.RegisterDriver("memory", memory.NewDriver)
+	ctx := context.Background()
+
+	// 2^53 + 1 can't be represented exactly as a float64
+	_ = manager.Put(ctx, "big", json.Number("9007199254740993"), 0)
+
+	i64, err := manager.GetInt64(ctx, "big")
+	assert.NoError(t, err)
+	assert.Equal(t, int64(9007199254740993), i64)
+
+	i, err := manager.GetInt(ctx, "big")
+	assert.NoError(t, err)
+	assert.Equal(t, 9007199254740993, i)
+
+	_ = manager.Put(ctx, "ratio", json.Number("1.5"), 0)
+	f, err := manager.GetFloat64(ctx, "ratio")
+	assert.NoError(t, err)
+	assert.Equal(t, 1.5, f)
+}
+
 func TestManager_GetBool(t *testing.T) {
 	manager, _ := dgcache.NewManager(dgcache.DefaultConfig())
 	ctx := context.Background()
@@ -253,3 +278,81 @@ func TestInjectable_Panic(t *testing.T) {
 		inject.Store("redis")
 	})
 }
+
+func TestMatchKey(t *testing.T) {
+	tests := []struct {
+		pattern string
+		key     string
+		match   bool
+	}{
+		{"config:*", "config:flags", true},
+		{"config:*", "user:1", false},
+		{"user:?", "user:1", true},
+		{"user:?", "user:12", false},
+		{"*:flags", "config:flags", true},
+		{"exact", "exact", true},
+		{"exact", "exactly", false},
+		{"*", "", true},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.match, dgcache.MatchKey(tt.pattern, tt.key), "%s ~ %s", tt.pattern, tt.key)
+	}
+}
+
+func TestManager_GetAsMultiple(t *testing.T) {
+	manager := createManager(t)
+	defer manager.Close()
+	ctx := context.Background()
+
+	type User struct {
+		ID   int
+		Name string
+	}
+	require.NoError(t, manager.Put(ctx, "user:1", User{ID: 1, Name: "John"}, time.Minute))
+	require.NoError(t, manager.Put(ctx, "count", 42, time.Minute))
+	require.NoError(t, manager.Put(ctx, "title", "Home", time.Minute))
+
+	var user User
+	var missing User
+	var count string // type mismatch
+	var title string
+	found, errs := manager.GetAsMultiple(ctx, map[string]interface{}{
+		"user:1": &user,
+		"user:2": &missing,
+		"count":  &count,
+		"title":  &title,
+	})
+
+	assert.Equal(t, []string{"title", "user:1"}, found)
+	assert.Equal(t, User{ID: 1, Name: "John"}, user)
+	assert.Equal(t, "Home", title)
+
+	require.Len(t, errs, 2)
+	assert.ErrorIs(t, errs["user:2"], dgcache.ErrKeyNotFound)
+	assert.Error(t, errs["count"])
+	assert.NotErrorIs(t, errs["count"], dgcache.ErrKeyNotFound)
+}
+
+func TestManager_PullInto(t *testing.T) {
+	manager := createManager(t)
+	defer manager.Close()
+	ctx := context.Background()
+
+	type Token struct {
+		UserID int
+		Scope  string
+	}
+	require.NoError(t, manager.Put(ctx, "token:abc", Token{UserID: 7, Scope: "reset"}, time.Minute))
+
+	var token Token
+	require.NoError(t, manager.PullInto(ctx, "token:abc", &token))
+	assert.Equal(t, Token{UserID: 7, Scope: "reset"}, token)
+
+	has, err := manager.Has(ctx, "token:abc")
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	// A second pull finds nothing
+	assert.ErrorIs(t, manager.PullInto(ctx, "token:abc", &token), dgcache.ErrKeyNotFound)
+}
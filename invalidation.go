@@ -0,0 +1,101 @@
+package dgcache
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"log/slog"
+
+	"github.com/donnigundala/dg-core/contracts/cache"
+)
+
+// Invalidation asks the processes sharing a logical cache to drop their local
+// copies of Keys and of every key tagged with one of Tags.
+type Invalidation struct {
+	// Origin identifies the publishing manager, which ignores its own messages.
+	Origin string   `json:"origin"`
+	Keys   []string `json:"keys,omitempty"`
+	Tags   []string `json:"tags,omitempty"`
+}
+
+// Invalidator broadcasts invalidations between processes. drivers/redis
+// provides one over Redis pub/sub.
+type Invalidator interface {
+	// Publish broadcasts inv to every subscriber, including the publisher.
+	Publish(ctx context.Context, inv Invalidation) error
+
+	// Subscribe calls handler for every invalidation published from now on.
+	// It returns once the subscription is active.
+	Subscribe(ctx context.Context, handler func(Invalidation)) error
+
+	// Close stops delivering invalidations.
+	Close() error
+}
+
+// SetInvalidator wires inv into the manager: Forget, ForgetMultiple and
+// FlushTags publish what they removed, and invalidations published by other
+// managers evict the same keys and tags from this manager's memory stores.
+//
+// Publishing is best effort. A failed publish is logged and never fails the
+// operation, since the local removal already succeeded; other processes then
+// keep their copies until they expire. Stop closes the invalidator.
+func (m *Manager) SetInvalidator(ctx context.Context, inv Invalidator) error {
+	origin := make([]byte, 8)
+	_, _ = rand.Read(origin)
+
+	m.mu.Lock()
+	m.invalidator = inv
+	m.invalidationOrigin = hex.EncodeToString(origin)
+	m.mu.Unlock()
+
+	return inv.Subscribe(ctx, m.applyInvalidation)
+}
+
+// publishInvalidation broadcasts keys and tags if an invalidator is set,
+// logging a failure instead of returning it.
+func (m *Manager) publishInvalidation(ctx context.Context, keys, tags []string) {
+	m.mu.RLock()
+	inv, origin := m.invalidator, m.invalidationOrigin
+	m.mu.RUnlock()
+	if inv == nil || (len(keys) == 0 && len(tags) == 0) {
+		return
+	}
+
+	err := inv.Publish(ctx, Invalidation{Origin: origin, Keys: keys, Tags: tags})
+	if err != nil {
+		slog.Warn("cache: publishing invalidation failed",
+			"keys", keys,
+			"tags", tags,
+			"error", err,
+		)
+	}
+}
+
+// applyInvalidation evicts an invalidation published by another manager from
+// every initialized memory store. Shared stores such as Redis were already
+// updated by the publisher.
+func (m *Manager) applyInvalidation(inv Invalidation) {
+	m.mu.RLock()
+	if inv.Origin == m.invalidationOrigin {
+		m.mu.RUnlock()
+		return
+	}
+	var local []cache.Store
+	for name, store := range m.stores {
+		if m.config.Stores[name].Driver == "memory" {
+			local = append(local, store)
+		}
+	}
+	m.mu.RUnlock()
+
+	ctx := context.Background()
+	for _, store := range local {
+		if len(inv.Keys) > 0 {
+			_ = store.ForgetMultiple(ctx, inv.Keys)
+			m.forgetErrors(ctx, "", store, inv.Keys...)
+		}
+		if introspectable, ok := capability[TagIntrospectable](store); ok && len(inv.Tags) > 0 {
+			_ = introspectable.FlushTags(ctx, inv.Tags...)
+		}
+	}
+}
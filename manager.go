@@ -2,7 +2,13 @@ package dgcache
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"math/rand/v2"
+	"reflect"
+	"runtime/debug"
+	"strings"
 	"sync"
 	"time"
 
@@ -15,17 +21,42 @@ type Manager struct {
 	config       Config
 	stores       map[string]cache.Store
 	drivers      map[string]DriverFactory
+	middleware   map[string]Middleware
 	mu           sync.RWMutex
 	defaultStore string
 
+	// Background refreshes started by ScheduleRefresh, keyed by cache key
+	refreshMu sync.Mutex
+	refreshes map[string]*refreshJob
+	refreshWG sync.WaitGroup
+	stopping  bool
+
+	// Unknown store names already warned about by FallbackToDefault
+	fallbackWarned sync.Map
+
+	// Slots for running callbacks; nil without MaxConcurrentCallbacks
+	callbackSlots chan struct{}
+
+	// Cross-process invalidation set by SetInvalidator
+	invalidator        Invalidator
+	invalidationOrigin string
+
 	// Observability
-	metricHits      metric.Int64ObservableCounter
-	metricMisses    metric.Int64ObservableCounter
-	metricSets      metric.Int64ObservableCounter
-	metricDeletes   metric.Int64ObservableCounter
-	metricEvictions metric.Int64ObservableCounter
-	metricItems     metric.Int64ObservableGauge
-	metricBytes     metric.Int64ObservableGauge
+	metricHits                metric.Int64ObservableCounter
+	metricMisses              metric.Int64ObservableCounter
+	metricSets                metric.Int64ObservableCounter
+	metricDeletes             metric.Int64ObservableCounter
+	metricEvictions           metric.Int64ObservableCounter
+	metricExpired             metric.Int64ObservableCounter
+	metricSerializationErrors metric.Int64ObservableCounter
+	metricItems               metric.Int64ObservableGauge
+	metricBytes               metric.Int64ObservableGauge
+	metricPoolHits            metric.Int64ObservableCounter
+	metricPoolMisses          metric.Int64ObservableCounter
+	metricPoolTimeouts        metric.Int64ObservableCounter
+	metricPoolConns           metric.Int64ObservableGauge
+	metricPoolIdleConns       metric.Int64ObservableGauge
+	metricCallback            metric.Registration
 }
 
 // DriverFactory is a function that creates a cache driver.
@@ -53,8 +84,12 @@ func NewManager(config Config) (*Manager, error) {
 		config:       config,
 		stores:       make(map[string]cache.Store),
 		drivers:      make(map[string]DriverFactory),
+		middleware:   make(map[string]Middleware),
 		defaultStore: config.DefaultStore,
 	}
+	if config.MaxConcurrentCallbacks > 0 {
+		m.callbackSlots = make(chan struct{}, config.MaxConcurrentCallbacks)
+	}
 
 	// Load globally registered drivers
 	globalDriversMu.RLock()
@@ -63,9 +98,42 @@ func NewManager(config Config) (*Manager, error) {
 		m.drivers[name] = factory
 	}
 
+	// Load globally registered middleware
+	globalMiddlewareMu.RLock()
+	defer globalMiddlewareMu.RUnlock()
+	for name, middleware := range globalMiddleware {
+		m.middleware[name] = middleware
+	}
+
 	return m, nil
 }
 
+// NewManagerWithDrivers creates a manager like NewManager, but first checks
+// that the named drivers are registered, failing early with ErrDriverNotFound
+// instead of on first use of a store. Without names, it checks the drivers of
+// every configured store.
+//
+// Drivers register themselves when their package is imported; import
+// drivers/builtin to register all the drivers that ship with dg-cache.
+func NewManagerWithDrivers(config Config, drivers ...string) (*Manager, error) {
+	if len(drivers) == 0 {
+		for _, store := range config.Stores {
+			drivers = append(drivers, store.Driver)
+		}
+	}
+
+	globalDriversMu.RLock()
+	for _, name := range drivers {
+		if _, ok := globalDrivers[name]; !ok {
+			globalDriversMu.RUnlock()
+			return nil, fmt.Errorf("%w: '%s' (import its package or drivers/builtin)", ErrDriverNotFound, name)
+		}
+	}
+	globalDriversMu.RUnlock()
+
+	return NewManager(config)
+}
+
 // RegisterDriver registers a driver factory for the given driver name.
 func (m *Manager) RegisterDriver(name string, factory DriverFactory) {
 	m.mu.Lock()
@@ -76,14 +144,22 @@ func (m *Manager) RegisterDriver(name string, factory DriverFactory) {
 // Verify Manager implements Cache interface
 var _ cache.Cache = (*Manager)(nil)
 
-// DefaultStore returns the default cache store.
+// DefaultStore returns the default cache store, or nil if it can't be created.
+// Use DefaultStoreErr to find out why.
 func (m *Manager) DefaultStore() cache.Store {
 	store, _ := m.Store("")
 	return store
 }
 
+// DefaultStoreErr returns the default cache store, or the error that prevented
+// creating it (e.g. an unregistered driver).
+func (m *Manager) DefaultStoreErr() (cache.Store, error) {
+	return m.Store("")
+}
+
 // Store returns the cache store with the given name.
-// If name is empty, returns the default store.
+// If name is empty, returns the default store. Unknown names fail with
+// ErrStoreNotFound unless Config.FallbackToDefault is set.
 func (m *Manager) Store(name string) (cache.Store, error) {
 	if name == "" {
 		name = m.defaultStore
@@ -98,7 +174,50 @@ func (m *Manager) Store(name string) (cache.Store, error) {
 	}
 
 	// Store not initialized, create it
-	return m.createStore(name)
+	store, err := m.createStore(name)
+	if errors.Is(err, ErrStoreNotFound) && m.config.FallbackToDefault && name != m.defaultStore {
+		if _, warned := m.fallbackWarned.LoadOrStore(name, struct{}{}); !warned {
+			slog.Warn("cache: store not configured, using default store",
+				"store", name, "default", m.defaultStore)
+		}
+		return m.Store(m.defaultStore)
+	}
+	return store, err
+}
+
+// MustStore returns the cache store with the given name or panics.
+func (m *Manager) MustStore(name string) cache.Store {
+	store, err := m.Store(name)
+	if err != nil {
+		panic(err)
+	}
+	return store
+}
+
+// storeName returns name, or the default store's name if name is empty.
+func (m *Manager) storeName(name string) string {
+	if name == "" {
+		return m.defaultStore
+	}
+	return name
+}
+
+// wrapError adds the default store, op, and key to err.
+// It returns nil for a nil err and leaves errors that already carry context unchanged.
+func (m *Manager) wrapError(op, key string, err error) error {
+	return m.wrapStoreError(m.defaultStore, op, key, err)
+}
+
+// wrapStoreError adds the store, op, and key to err.
+func (m *Manager) wrapStoreError(store, op, key string, err error) error {
+	if err == nil {
+		return nil
+	}
+	var cacheErr *CacheError
+	if errors.As(err, &cacheErr) {
+		return err
+	}
+	return &CacheError{Store: store, Op: op, Key: key, Err: err}
 }
 
 // createStore creates and caches a new store instance.
@@ -123,12 +242,22 @@ func (m *Manager) createStore(name string) (cache.Store, error) {
 		return nil, ErrDriverNotFound
 	}
 
+	if m.config.Metrics {
+		storeConfig.Metrics = true
+	}
+
 	// Create driver
 	driver, err := factory(storeConfig)
 	if err != nil {
 		return nil, ErrDriverError(storeConfig.Driver, err)
 	}
 
+	// Wrap with middleware
+	driver, err = m.applyMiddleware(driver, storeConfig)
+	if err != nil {
+		return nil, err
+	}
+
 	// Set prefix
 	prefix := storeConfig.Prefix
 	if prefix == "" {
@@ -142,103 +271,375 @@ func (m *Manager) createStore(name string) (cache.Store, error) {
 	return driver, nil
 }
 
+// Info returns the effective configuration of the named store.
+// If name is empty, the default store is used.
+func (m *Manager) Info(name string) (DriverInfo, error) {
+	store, err := m.Store(name)
+	if err != nil {
+		return DriverInfo{}, err
+	}
+	introspectable, ok := capability[Introspectable](store)
+	if !ok {
+		return DriverInfo{}, ErrNotSupported
+	}
+	return introspectable.Info(), nil
+}
+
 // Get retrieves a value from the default cache store.
+// On a miss it returns ErrKeyNotFound, or (nil, nil) if MissReturnsError is disabled.
 func (m *Manager) Get(ctx context.Context, key string) (interface{}, error) {
-	store, err := m.Store("")
+	return m.GetIn(ctx, "", key)
+}
+
+// GetIn retrieves a value from the named store, or the default store if name is empty.
+// Misses are reported like Get.
+func (m *Manager) GetIn(ctx context.Context, name, key string) (interface{}, error) {
+	name = m.storeName(name)
+	store, err := m.Store(name)
 	if err != nil {
-		return nil, err
+		return nil, m.wrapStoreError(name, "get", key, err)
+	}
+	value, err := getRequestCached(ctx, name, store, key)
+	if errors.Is(err, ErrKeyNotFound) && !m.config.missReturnsError() {
+		return nil, nil
 	}
-	return store.Get(ctx, key)
+	return value, m.wrapStoreError(name, "get", key, err)
+}
+
+// GetWithFallback tries each named store in order and returns the first hit.
+// If no stores are given, the default store is used. When FallbackBackfillTTL is set,
+// the value is written back into the earlier stores that missed.
+// Store errors other than a miss do not stop the search; the first one is returned
+// if no store has the key.
+//
+// Values are returned in the representation of the last store that implements
+// Normalizer (typically the serializing L2), so a hit in an in-memory L1 yields
+// the same type as a hit that was served from L2 and backfilled.
+//
+// With FallbackReadRepairRate set, that fraction of hits in an earlier store is
+// checked against the last store, which is treated as the source of truth. A
+// differing value is replaced by the last store's, and a key the last store no
+// longer has is forgotten and the search continues.
+func (m *Manager) GetWithFallback(ctx context.Context, key string, stores ...string) (interface{}, error) {
+	if len(stores) == 0 {
+		stores = []string{""}
+	}
+
+	resolved := make([]cache.Store, len(stores))
+	canonical := -1
+	var normalizer Normalizer
+	for i, name := range stores {
+		store, err := m.Store(name)
+		if err != nil {
+			return nil, m.wrapStoreError(name, "get", key, err)
+		}
+		resolved[i] = store
+		if n, ok := capability[Normalizer](store); ok {
+			canonical, normalizer = i, n
+		}
+	}
+
+	var firstErr error
+	for i, store := range resolved {
+		value, err := store.Get(ctx, key)
+		if err != nil {
+			if !errors.Is(err, ErrKeyNotFound) && firstErr == nil {
+				firstErr = m.wrapStoreError(stores[i], "get", key, err)
+			}
+			continue
+		}
+
+		if i < canonical {
+			if normalized, err := normalizer.Normalize(value); err == nil {
+				value = normalized
+			}
+		}
+
+		if last := len(resolved) - 1; i < last && m.sampleReadRepair() {
+			truth, err := resolved[last].Get(ctx, key)
+			switch {
+			case errors.Is(err, ErrKeyNotFound):
+				_ = store.Forget(ctx, key)
+				continue
+			case err == nil && !reflect.DeepEqual(value, truth):
+				value = truth
+				m.repair(ctx, store, key, value)
+			}
+		}
+
+		if m.config.FallbackBackfillTTL > 0 {
+			m.backfill(ctx, stores[:i], key, value)
+		}
+		return value, nil
+	}
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if !m.config.missReturnsError() {
+		return nil, nil
+	}
+	return nil, m.wrapStoreError(stores[len(stores)-1], "get", key, ErrKeyNotFound)
+}
+
+// backfill writes value into the given stores, ignoring failures.
+func (m *Manager) backfill(ctx context.Context, stores []string, key string, value interface{}) {
+	for _, name := range stores {
+		store, err := m.Store(name)
+		if err != nil {
+			continue
+		}
+		_ = store.Put(ctx, key, value, m.config.FallbackBackfillTTL)
+	}
+}
+
+// sampleReadRepair reports whether GetWithFallback verifies the next hit.
+func (m *Manager) sampleReadRepair() bool {
+	rate := m.config.FallbackReadRepairRate
+	return rate >= 1 || (rate > 0 && rand.Float64() < rate)
+}
+
+// repair replaces a stale value in store with the FallbackBackfillTTL, or
+// forgets it when backfilling is disabled so the next read falls through.
+func (m *Manager) repair(ctx context.Context, store cache.Store, key string, value interface{}) {
+	if m.config.FallbackBackfillTTL > 0 {
+		_ = store.Put(ctx, key, value, m.config.FallbackBackfillTTL)
+		return
+	}
+	_ = store.Forget(ctx, key)
 }
 
 // GetMultiple retrieves multiple values from the default cache store.
 func (m *Manager) GetMultiple(ctx context.Context, keys []string) (map[string]interface{}, error) {
-	store, err := m.Store("")
+	return m.GetMultipleIn(ctx, "", keys)
+}
+
+// GetMultipleIn retrieves multiple values from the named store.
+func (m *Manager) GetMultipleIn(ctx context.Context, name string, keys []string) (map[string]interface{}, error) {
+	name = m.storeName(name)
+	store, err := m.Store(name)
+	if err != nil {
+		return nil, m.wrapStoreError(name, "get_multiple", "", err)
+	}
+	values, err := store.GetMultiple(ctx, keys)
+	return values, m.wrapStoreError(name, "get_multiple", "", err)
+}
+
+// Result is the outcome of looking up one key in GetMultipleOrdered.
+type Result struct {
+	Key   string
+	Value interface{}
+	Found bool
+}
+
+// GetMultipleOrdered retrieves multiple values from the default cache store,
+// returning one Result per key in the order the keys were given.
+// Missing keys are reported with Found set to false.
+func (m *Manager) GetMultipleOrdered(ctx context.Context, keys []string) ([]Result, error) {
+	values, err := m.GetMultiple(ctx, keys)
 	if err != nil {
 		return nil, err
 	}
-	return store.GetMultiple(ctx, keys)
+
+	results := make([]Result, len(keys))
+	for i, key := range keys {
+		value, found := values[key]
+		results[i] = Result{Key: key, Value: value, Found: found}
+	}
+	return results, nil
 }
 
 // Put stores a value in the default cache store.
 func (m *Manager) Put(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
-	store, err := m.Store("")
+	return m.PutIn(ctx, "", key, value, ttl)
+}
+
+// PutIn stores a value in the named store.
+func (m *Manager) PutIn(ctx context.Context, name string, key string, value interface{}, ttl time.Duration) error {
+	name = m.storeName(name)
+	store, err := m.Store(name)
 	if err != nil {
-		return err
+		return m.wrapStoreError(name, "put", key, err)
+	}
+	defer forgetRequestCached(ctx, name, key)
+	if err := store.Put(ctx, key, value, ttl); err != nil {
+		return m.wrapStoreError(name, "put", key, err)
 	}
-	return store.Put(ctx, key, value, ttl)
+	m.forgetErrors(ctx, name, store, key)
+	return nil
 }
 
 // PutMultiple stores multiple values in the default cache store.
 func (m *Manager) PutMultiple(ctx context.Context, items map[string]interface{}, ttl time.Duration) error {
-	store, err := m.Store("")
+	return m.PutMultipleIn(ctx, "", items, ttl)
+}
+
+// PutMultipleIn stores multiple values in the named store.
+func (m *Manager) PutMultipleIn(ctx context.Context, name string, items map[string]interface{}, ttl time.Duration) error {
+	name = m.storeName(name)
+	store, err := m.Store(name)
 	if err != nil {
-		return err
+		return m.wrapStoreError(name, "put_multiple", "", err)
 	}
-	return store.PutMultiple(ctx, items, ttl)
+	defer forgetRequestCachedItems(ctx, name, items)
+	return m.wrapStoreError(name, "put_multiple", "", store.PutMultiple(ctx, items, ttl))
 }
 
 // Increment increments a value in the default cache store.
 func (m *Manager) Increment(ctx context.Context, key string, value int64) (int64, error) {
-	store, err := m.Store("")
+	return m.IncrementIn(ctx, "", key, value)
+}
+
+// IncrementIn increments a value in the named store.
+func (m *Manager) IncrementIn(ctx context.Context, name string, key string, value int64) (int64, error) {
+	name = m.storeName(name)
+	store, err := m.Store(name)
 	if err != nil {
-		return 0, err
+		return 0, m.wrapStoreError(name, "increment", key, err)
 	}
-	return store.Increment(ctx, key, value)
+	defer forgetRequestCached(ctx, name, key)
+	n, err := store.Increment(ctx, key, value)
+	return n, m.wrapStoreError(name, "increment", key, err)
 }
 
 // Decrement decrements a value in the default cache store.
 func (m *Manager) Decrement(ctx context.Context, key string, value int64) (int64, error) {
-	store, err := m.Store("")
+	return m.DecrementIn(ctx, "", key, value)
+}
+
+// DecrementIn decrements a value in the named store.
+func (m *Manager) DecrementIn(ctx context.Context, name string, key string, value int64) (int64, error) {
+	name = m.storeName(name)
+	store, err := m.Store(name)
 	if err != nil {
-		return 0, err
+		return 0, m.wrapStoreError(name, "decrement", key, err)
 	}
-	return store.Decrement(ctx, key, value)
+	defer forgetRequestCached(ctx, name, key)
+	n, err := store.Decrement(ctx, key, value)
+	return n, m.wrapStoreError(name, "decrement", key, err)
 }
 
 // Forever stores a value in the default cache store indefinitely.
 func (m *Manager) Forever(ctx context.Context, key string, value interface{}) error {
-	store, err := m.Store("")
+	return m.ForeverIn(ctx, "", key, value)
+}
+
+// ForeverIn stores a value in the named store indefinitely.
+func (m *Manager) ForeverIn(ctx context.Context, name string, key string, value interface{}) error {
+	name = m.storeName(name)
+	store, err := m.Store(name)
 	if err != nil {
-		return err
+		return m.wrapStoreError(name, "forever", key, err)
+	}
+	defer forgetRequestCached(ctx, name, key)
+	if err := store.Forever(ctx, key, value); err != nil {
+		return m.wrapStoreError(name, "forever", key, err)
 	}
-	return store.Forever(ctx, key, value)
+	m.forgetErrors(ctx, name, store, key)
+	return nil
 }
 
 // Forget removes a value from the default cache store.
 func (m *Manager) Forget(ctx context.Context, key string) error {
-	store, err := m.Store("")
+	return m.ForgetIn(ctx, "", key)
+}
+
+// ForgetIn removes a value from the named store.
+func (m *Manager) ForgetIn(ctx context.Context, name, key string) error {
+	name = m.storeName(name)
+	store, err := m.Store(name)
 	if err != nil {
-		return err
+		return m.wrapStoreError(name, "forget", key, err)
+	}
+	defer forgetRequestCached(ctx, name, key)
+	if err := store.Forget(ctx, key); err != nil {
+		return m.wrapStoreError(name, "forget", key, err)
 	}
-	return store.Forget(ctx, key)
+	m.forgetErrors(ctx, name, store, key)
+	m.publishInvalidation(ctx, []string{key}, nil)
+	return nil
 }
 
 // ForgetMultiple removes multiple values from the default cache store.
 func (m *Manager) ForgetMultiple(ctx context.Context, keys []string) error {
-	store, err := m.Store("")
+	return m.ForgetMultipleIn(ctx, "", keys)
+}
+
+// ForgetMultipleIn removes multiple values from the named store.
+func (m *Manager) ForgetMultipleIn(ctx context.Context, name string, keys []string) error {
+	name = m.storeName(name)
+	store, err := m.Store(name)
 	if err != nil {
-		return err
+		return m.wrapStoreError(name, "forget_multiple", "", err)
+	}
+	defer forgetRequestCached(ctx, name, keys...)
+	if err := store.ForgetMultiple(ctx, keys); err != nil {
+		return m.wrapStoreError(name, "forget_multiple", "", err)
 	}
-	return store.ForgetMultiple(ctx, keys)
+	m.forgetErrors(ctx, name, store, keys...)
+	m.publishInvalidation(ctx, keys, nil)
+	return nil
 }
 
 // Flush removes all items from the default cache store.
 func (m *Manager) Flush(ctx context.Context) error {
+	return m.FlushIn(ctx, "")
+}
+
+// FlushIn removes all items from the named store.
+func (m *Manager) FlushIn(ctx context.Context, name string) error {
+	name = m.storeName(name)
+	store, err := m.Store(name)
+	if err != nil {
+		return m.wrapStoreError(name, "flush", "", err)
+	}
+	defer flushRequestCached(ctx, name)
+	return m.wrapStoreError(name, "flush", "", store.Flush(ctx))
+}
+
+// FlushExcept removes all items from the default cache store except keys matching the patterns.
+func (m *Manager) FlushExcept(ctx context.Context, patterns ...string) error {
 	store, err := m.Store("")
 	if err != nil {
-		return err
+		return m.wrapError("flush_except", "", err)
+	}
+	flusher, ok := capability[SelectiveFlusher](store)
+	if !ok {
+		return m.wrapError("flush_except", "", ErrNotSupported)
 	}
-	return store.Flush(ctx)
+	defer flushRequestCached(ctx, m.defaultStore)
+	return m.wrapError("flush_except", "", flusher.FlushExcept(ctx, patterns...))
 }
 
 // Has checks if a key exists in the default cache store.
 func (m *Manager) Has(ctx context.Context, key string) (bool, error) {
+	return m.HasIn(ctx, "", key)
+}
+
+// HasIn checks if a key exists in the named store.
+func (m *Manager) HasIn(ctx context.Context, name, key string) (bool, error) {
+	name = m.storeName(name)
+	store, err := m.Store(name)
+	if err != nil {
+		return false, m.wrapStoreError(name, "has", key, err)
+	}
+	ok, err := store.Has(ctx, key)
+	return ok, m.wrapStoreError(name, "has", key, err)
+}
+
+// HasMultiple reports for every key whether it exists in the default cache
+// store, in one call instead of one Has per key.
+func (m *Manager) HasMultiple(ctx context.Context, keys []string) (map[string]bool, error) {
 	store, err := m.Store("")
 	if err != nil {
-		return false, err
+		return nil, m.wrapError("has_multiple", "", err)
 	}
-	return store.Has(ctx, key)
+	checker, ok := capability[MultiChecker](store)
+	if !ok {
+		return nil, m.wrapError("has_multiple", "", ErrNotSupported)
+	}
+	result, err := checker.HasMultiple(ctx, keys)
+	return result, m.wrapError("has_multiple", "", err)
 }
 
 // Stats returns the statistics of the default cache store.
@@ -256,7 +657,7 @@ func (m *Manager) Tags(tags ...string) cache.TaggedStore {
 	if err != nil {
 		panic(fmt.Sprintf("failed to get default store: %v", err))
 	}
-	if Taggable, ok := store.(cache.TaggedStore); ok {
+	if Taggable, ok := capability[cache.TaggedStore](store); ok {
 		return Taggable.Tags(tags...)
 	}
 	panic("default cache store does not support tagging")
@@ -264,58 +665,463 @@ func (m *Manager) Tags(tags ...string) cache.TaggedStore {
 
 // Missing checks if a key does not exist in the default cache store.
 func (m *Manager) Missing(ctx context.Context, key string) (bool, error) {
+	return m.MissingIn(ctx, "", key)
+}
+
+// MissingIn checks if a key does not exist in the named store.
+func (m *Manager) MissingIn(ctx context.Context, name, key string) (bool, error) {
+	name = m.storeName(name)
+	store, err := m.Store(name)
+	if err != nil {
+		return false, m.wrapStoreError(name, "missing", key, err)
+	}
+	ok, err := store.Missing(ctx, key)
+	return ok, m.wrapStoreError(name, "missing", key, err)
+}
+
+// IncrementWithTTL increments a counter in the default cache store, setting
+// ttl only when the increment creates the key.
+func (m *Manager) IncrementWithTTL(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	store, err := m.Store("")
+	if err != nil {
+		return 0, m.wrapError("increment", key, err)
+	}
+	incrementer, ok := capability[TTLIncrementer](store)
+	if !ok {
+		return 0, m.wrapError("increment", key, ErrNotSupported)
+	}
+	defer forgetRequestCached(ctx, m.defaultStore, key)
+	n, err := incrementer.IncrementWithTTL(ctx, key, delta, ttl)
+	return n, m.wrapError("increment", key, err)
+}
+
+// SwapAll atomically replaces the contents of the default cache store with items.
+func (m *Manager) SwapAll(ctx context.Context, items map[string]interface{}, ttl time.Duration) error {
+	store, err := m.Store("")
+	if err != nil {
+		return m.wrapError("swap_all", "", err)
+	}
+	swapper, ok := capability[Swapper](store)
+	if !ok {
+		return m.wrapError("swap_all", "", ErrNotSupported)
+	}
+	defer flushRequestCached(ctx, m.defaultStore)
+	return m.wrapError("swap_all", "", swapper.SwapAll(ctx, items, ttl))
+}
+
+// GetMultipleWithTTL retrieves multiple values from the default cache store
+// with their remaining TTL, for callers that cache them again client-side.
+func (m *Manager) GetMultipleWithTTL(ctx context.Context, keys []string) (map[string]ValueTTL, error) {
+	store, err := m.Store("")
+	if err != nil {
+		return nil, m.wrapError("get_multiple", "", err)
+	}
+	reader, ok := capability[TTLReader](store)
+	if !ok {
+		return nil, m.wrapError("get_multiple", "", ErrNotSupported)
+	}
+	values, err := reader.GetMultipleWithTTL(ctx, keys)
+	return values, m.wrapError("get_multiple", "", err)
+}
+
+// GetWithMeta retrieves a value from the default cache store with its
+// metadata. Misses are reported like Get, with an empty ItemMeta.
+func (m *Manager) GetWithMeta(ctx context.Context, key string) (interface{}, ItemMeta, error) {
 	store, err := m.Store("")
 	if err != nil {
-		return false, err
+		return nil, ItemMeta{}, m.wrapError("get", key, err)
+	}
+	reader, ok := capability[MetaReader](store)
+	if !ok {
+		return nil, ItemMeta{}, m.wrapError("get", key, ErrNotSupported)
+	}
+	value, meta, err := reader.GetWithMeta(ctx, key)
+	if errors.Is(err, ErrKeyNotFound) && !m.config.missReturnsError() {
+		return nil, ItemMeta{}, nil
 	}
-	return store.Missing(ctx, key)
+	return value, meta, m.wrapError("get", key, err)
+}
+
+// GetItem returns a copy of the entry for key in the default store, with its
+// value, expiry and tags. Misses are reported like Get. It returns
+// ErrNotSupported if the store does not implement ItemStore.
+func (m *Manager) GetItem(ctx context.Context, key string) (*Item, error) {
+	store, err := m.Store("")
+	if err != nil {
+		return nil, m.wrapError("get", key, err)
+	}
+	itemStore, ok := capability[ItemStore](store)
+	if !ok {
+		return nil, m.wrapError("get", key, ErrNotSupported)
+	}
+	item, err := itemStore.GetItem(ctx, key)
+	if errors.Is(err, ErrKeyNotFound) && !m.config.missReturnsError() {
+		return nil, nil
+	}
+	return item, m.wrapError("get", key, err)
+}
+
+// Expire sets the TTL of an existing key in the default cache store.
+func (m *Manager) Expire(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	store, err := m.Store("")
+	if err != nil {
+		return false, m.wrapError("expire", key, err)
+	}
+	expirer, ok := capability[Expirer](store)
+	if !ok {
+		return false, m.wrapError("expire", key, ErrNotSupported)
+	}
+	ok, err = expirer.Expire(ctx, key, ttl)
+	return ok, m.wrapError("expire", key, err)
+}
+
+// GetField retrieves one field of a cached object in the default cache store.
+func (m *Manager) GetField(ctx context.Context, key, path string) (interface{}, error) {
+	store, err := m.Store("")
+	if err != nil {
+		return nil, m.wrapError("get_field", key, err)
+	}
+	reader, ok := capability[FieldReader](store)
+	if !ok {
+		return nil, m.wrapError("get_field", key, ErrNotSupported)
+	}
+	value, err := reader.GetField(ctx, key, path)
+	return value, m.wrapError("get_field", key, err)
+}
+
+// ExtendTTL extends the expiry of a key in the default cache store, never shortening it.
+func (m *Manager) ExtendTTL(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	store, err := m.Store("")
+	if err != nil {
+		return false, m.wrapError("extend_ttl", key, err)
+	}
+	extender, ok := capability[TTLExtender](store)
+	if !ok {
+		return false, m.wrapError("extend_ttl", key, ErrNotSupported)
+	}
+	extended, err := extender.ExtendTTL(ctx, key, ttl)
+	return extended, m.wrapError("extend_ttl", key, err)
+}
+
+// Rename atomically renames a key in the default cache store.
+func (m *Manager) Rename(ctx context.Context, oldKey, newKey string) error {
+	store, err := m.Store("")
+	if err != nil {
+		return m.wrapError("rename", oldKey, err)
+	}
+	renamer, ok := capability[Renamer](store)
+	if !ok {
+		return m.wrapError("rename", oldKey, ErrNotSupported)
+	}
+	defer forgetRequestCached(ctx, m.defaultStore, oldKey, newKey)
+	return m.wrapError("rename", oldKey, renamer.Rename(ctx, oldKey, newKey))
+}
+
+// KeysForTag returns the keys associated with tag in the default cache store.
+func (m *Manager) KeysForTag(ctx context.Context, tag string) ([]string, error) {
+	store, err := m.Store("")
+	if err != nil {
+		return nil, m.wrapError("keys_for_tag", "", err)
+	}
+	introspectable, ok := capability[TagIntrospectable](store)
+	if !ok {
+		return nil, m.wrapError("keys_for_tag", "", ErrNotSupported)
+	}
+	keys, err := introspectable.KeysForTag(ctx, tag)
+	return keys, m.wrapError("keys_for_tag", "", err)
+}
+
+// FlushTags removes all keys associated with any of the tags in the default cache store.
+func (m *Manager) FlushTags(ctx context.Context, tags ...string) error {
+	store, err := m.Store("")
+	if err != nil {
+		return m.wrapError("flush_tags", "", err)
+	}
+	introspectable, ok := capability[TagIntrospectable](store)
+	if !ok {
+		return m.wrapError("flush_tags", "", ErrNotSupported)
+	}
+	defer flushRequestCached(ctx, m.defaultStore)
+	if err := introspectable.FlushTags(ctx, tags...); err != nil {
+		return m.wrapError("flush_tags", "", err)
+	}
+	m.publishInvalidation(ctx, nil, tags)
+	return nil
+}
+
+// FlushTagKeysOnly removes all keys associated with any of the tags in the
+// default cache store but keeps the tags. See TagKeysFlusher.
+func (m *Manager) FlushTagKeysOnly(ctx context.Context, tags ...string) error {
+	store, err := m.Store("")
+	if err != nil {
+		return m.wrapError("flush_tag_keys_only", "", err)
+	}
+	flusher, ok := capability[TagKeysFlusher](store)
+	if !ok {
+		return m.wrapError("flush_tag_keys_only", "", ErrNotSupported)
+	}
+	defer flushRequestCached(ctx, m.defaultStore)
+	return m.wrapError("flush_tag_keys_only", "", flusher.FlushTagKeysOnly(ctx, tags...))
+}
+
+// TagExisting associates existing keys in the default cache store with tag.
+func (m *Manager) TagExisting(ctx context.Context, tag string, keys ...string) error {
+	store, err := m.Store("")
+	if err != nil {
+		return m.wrapError("tag_existing", "", err)
+	}
+	editor, ok := capability[TagEditor](store)
+	if !ok {
+		return m.wrapError("tag_existing", "", ErrNotSupported)
+	}
+	return m.wrapError("tag_existing", "", editor.TagExisting(ctx, tag, keys...))
+}
+
+// Untag removes key from the given tags in the default cache store without deleting it.
+func (m *Manager) Untag(ctx context.Context, key string, tags ...string) error {
+	store, err := m.Store("")
+	if err != nil {
+		return m.wrapError("untag", key, err)
+	}
+	editor, ok := capability[TagEditor](store)
+	if !ok {
+		return m.wrapError("untag", key, ErrNotSupported)
+	}
+	return m.wrapError("untag", key, editor.Untag(ctx, key, tags...))
+}
+
+// Transaction runs fn against the default cache store and applies its writes atomically.
+// See Transactional for the semantics.
+func (m *Manager) Transaction(ctx context.Context, fn func(tx cache.Store) error) error {
+	store, err := m.Store("")
+	if err != nil {
+		return m.wrapError("transaction", "", err)
+	}
+	transactional, ok := capability[Transactional](store)
+	if !ok {
+		return m.wrapError("transaction", "", ErrNotSupported)
+	}
+	defer flushRequestCached(ctx, m.defaultStore)
+	return m.wrapError("transaction", "", transactional.Transaction(ctx, fn))
 }
 
 // Remember retrieves a value from the cache or executes the callback and stores the result.
 // This implements the cache-aside pattern.
 func (m *Manager) Remember(ctx context.Context, key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error) {
+	return m.RememberIn(ctx, "", key, ttl, callback)
+}
+
+// RememberIn is Remember against the named store.
+func (m *Manager) RememberIn(ctx context.Context, name string, key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error) {
 	// Try to get from cache
-	value, err := m.Get(ctx, key)
-	if err == nil && value != nil {
+	value, err := m.GetIn(ctx, name, key)
+	if m.isHit(value, err) {
 		return value, nil
 	}
 
+	// Return a recent callback failure without retrying
+	if err := m.cachedError(ctx, name, key); err != nil {
+		return nil, err
+	}
+
 	// Execute callback
-	value, err = callback()
+	value, err = m.runCallback(ctx, key, callback)
 	if err != nil {
+		m.cacheError(ctx, name, key, err)
 		return nil, err
 	}
 
 	// Store in cache
-	if err := m.Put(ctx, key, value, ttl); err != nil {
+	if err := m.PutIn(ctx, name, key, value, ttl); err != nil {
 		// Log error but don't fail - we have the value
 		return value, nil
 	}
 
-	return value, nil
+	// Return the value as a later cache hit would
+	return m.normalize(name, value), nil
 }
 
 // RememberForever retrieves a value from the cache or executes the callback and stores the result forever.
 func (m *Manager) RememberForever(ctx context.Context, key string, callback func() (interface{}, error)) (interface{}, error) {
+	return m.RememberForeverIn(ctx, "", key, callback)
+}
+
+// RememberForeverIn is RememberForever against the named store.
+func (m *Manager) RememberForeverIn(ctx context.Context, name string, key string, callback func() (interface{}, error)) (interface{}, error) {
 	// Try to get from cache
-	value, err := m.Get(ctx, key)
-	if err == nil && value != nil {
+	value, err := m.GetIn(ctx, name, key)
+	if m.isHit(value, err) {
 		return value, nil
 	}
 
+	// Return a recent callback failure without retrying
+	if err := m.cachedError(ctx, name, key); err != nil {
+		return nil, err
+	}
+
 	// Execute callback
-	value, err = callback()
+	value, err = m.runCallback(ctx, key, callback)
 	if err != nil {
+		m.cacheError(ctx, name, key, err)
 		return nil, err
 	}
 
 	// Store in cache forever
-	if err := m.Forever(ctx, key, value); err != nil {
+	if err := m.ForeverIn(ctx, name, key, value); err != nil {
 		// Log error but don't fail - we have the value
 		return value, nil
 	}
 
-	return value, nil
+	// Return the value as a later cache hit would
+	return m.normalize(name, value), nil
+}
+
+// RememberIf is Remember with a freshness check: a cache hit is only returned
+// if accept reports true for the cached value. Otherwise the callback runs and
+// its result replaces the cached value, as on a miss.
+func (m *Manager) RememberIf(ctx context.Context, key string, ttl time.Duration, accept func(cached interface{}) bool, callback func() (interface{}, error)) (interface{}, error) {
+	// Try to get from cache
+	value, err := m.Get(ctx, key)
+	if m.isHit(value, err) && accept(value) {
+		return value, nil
+	}
+
+	// Return a recent callback failure without retrying
+	if err := m.cachedError(ctx, "", key); err != nil {
+		return nil, err
+	}
+
+	// Execute callback
+	value, err = m.runCallback(ctx, key, callback)
+	if err != nil {
+		m.cacheError(ctx, "", key, err)
+		return nil, err
+	}
+
+	// Store in cache
+	if err := m.Put(ctx, key, value, ttl); err != nil {
+		// Log error but don't fail - we have the value
+		return value, nil
+	}
+
+	// Return the value as a later cache hit would
+	return m.normalize("", value), nil
+}
+
+// isHit reports whether a Get result is a cache hit. A stored nil is a hit
+// unless MissReturnsError is disabled, where it can't be told apart from a miss.
+func (m *Manager) isHit(value interface{}, err error) bool {
+	if err != nil {
+		return false
+	}
+	return value != nil || m.config.missReturnsError()
+}
+
+// runCallback invokes a Remember callback, converting a panic into a
+// *PanicError when RecoverPanics is enabled. With MaxConcurrentCallbacks set,
+// it first waits for a free slot, returning ctx.Err() if ctx is done first.
+func (m *Manager) runCallback(ctx context.Context, key string, callback func() (interface{}, error)) (value interface{}, err error) {
+	if m.callbackSlots != nil {
+		select {
+		case m.callbackSlots <- struct{}{}:
+			defer func() { <-m.callbackSlots }()
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if m.config.RecoverPanics {
+		defer func() {
+			if r := recover(); r != nil {
+				value, err = nil, &PanicError{Key: key, Value: r, Stack: debug.Stack()}
+			}
+		}()
+	}
+	return callback()
+}
+
+// normalize returns value as the named store would read it back, so that
+// Remember yields the same type on a miss as on a later hit.
+func (m *Manager) normalize(name string, value interface{}) interface{} {
+	store, err := m.Store(name)
+	if err != nil {
+		return value
+	}
+	return normalizeFor(store, value)
+}
+
+// normalizeFor returns value as store would read it back.
+func normalizeFor(store cache.Store, value interface{}) interface{} {
+	normalizer, ok := capability[Normalizer](store)
+	if !ok {
+		return value
+	}
+	normalized, err := normalizer.Normalize(value)
+	if err != nil {
+		return value
+	}
+	return normalized
+}
+
+// errorKeyPrefix is the reserved namespace of cached callback errors.
+const errorKeyPrefix = "__dgcache:error:"
+
+// errorKey returns the key under which a callback error for key is cached.
+// Errors live in a reserved namespace so they can never be mistaken for a
+// value or collide with a key of the application.
+func errorKey(key string) string {
+	return errorKeyPrefix + key
+}
+
+// isErrorKey reports whether key holds a cached callback error.
+func isErrorKey(key string) bool {
+	return strings.HasPrefix(key, errorKeyPrefix)
+}
+
+// forgetErrors drops the cached callback errors of keys from the named store,
+// so the next Remember of those keys runs its callback again.
+func (m *Manager) forgetErrors(ctx context.Context, name string, store cache.Store, keys ...string) {
+	if m.config.ErrorTTL <= 0 || len(keys) == 0 {
+		return
+	}
+	errorKeys := make([]string, len(keys))
+	for i, key := range keys {
+		errorKeys[i] = errorKey(key)
+	}
+	forgetRequestCached(ctx, name, errorKeys...)
+	// Ignore errors - the cached error then only lives until ErrorTTL
+	_ = store.ForgetMultiple(ctx, errorKeys)
+}
+
+// cachedError returns a *CachedError if a callback failure for key is still
+// cached in the named store.
+func (m *Manager) cachedError(ctx context.Context, name, key string) error {
+	if m.config.ErrorTTL <= 0 {
+		return nil
+	}
+	message, err := m.GetIn(ctx, name, errorKey(key))
+	if err != nil || message == nil {
+		return nil
+	}
+	return &CachedError{Key: key, Message: fmt.Sprintf("%v", message)}
+}
+
+// cacheError stores a callback failure for key in the named store for
+// Config.ErrorTTL.
+func (m *Manager) cacheError(ctx context.Context, name, key string, err error) {
+	// A caller that gave up, possibly while waiting for a callback slot, says
+	// nothing about the loader
+	if m.config.ErrorTTL <= 0 || ctx.Err() != nil {
+		return
+	}
+	name = m.storeName(name)
+	store, storeErr := m.Store(name)
+	if storeErr != nil {
+		return
+	}
+	key = errorKey(key)
+	defer forgetRequestCached(ctx, name, key)
+	// Ignore errors - failing to cache the error only means the next call retries
+	_ = store.Put(ctx, key, err.Error(), m.config.ErrorTTL)
 }
 
 // Pull retrieves a value from the cache and then deletes it.
@@ -331,36 +1137,100 @@ func (m *Manager) Pull(ctx context.Context, key string) (interface{}, error) {
 	return value, nil
 }
 
-// GetPrefix returns the prefix of the default store.
+// GetPrefix returns the prefix of the default store, or "" if the default
+// store can't be created.
 func (m *Manager) GetPrefix() string {
-	return m.DefaultStore().GetPrefix()
+	store, err := m.Store("")
+	if err != nil {
+		return ""
+	}
+	return store.GetPrefix()
 }
 
-// SetPrefix sets the prefix of the default store.
+// SetPrefix sets the prefix of the default store. It does nothing if the
+// default store can't be created.
 func (m *Manager) SetPrefix(prefix string) {
-	m.DefaultStore().SetPrefix(prefix)
+	store, err := m.Store("")
+	if err != nil {
+		return
+	}
+	store.SetPrefix(prefix)
 }
 
-// Stop stops the cache manager gracefully.
+// Stop shuts the cache manager down gracefully.
 // This implements the Stoppable interface.
+//
+// Shutdown runs in order: no new refreshes are accepted, running refreshes are
+// cancelled and awaited until ctx is done, the invalidator is closed, the
+// metrics callback is unregistered, and finally every store is closed. Stores are closed even when
+// ctx expires first, in which case ctx.Err() is joined into the returned error.
 func (m *Manager) Stop(ctx context.Context) error {
-	return m.Close()
-}
+	var errs []error
+
+	// Stop refreshes first: they write through the stores about to be closed
+	if err := m.stopRefreshes(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("cache: waiting for refreshes: %w", err))
+	}
+
+	// Stop receiving invalidations before the memory stores they evict from
+	// are closed. The handler takes m.mu, so this happens outside the lock.
+	m.mu.Lock()
+	inv := m.invalidator
+	m.invalidator = nil
+	m.mu.Unlock()
+	if inv != nil {
+		if err := inv.Close(); err != nil {
+			errs = append(errs, fmt.Errorf("cache: closing invalidator: %w", err))
+		}
+	}
 
-// Close closes all cache stores and releases resources.
-func (m *Manager) Close() error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	var lastErr error
+	if m.metricCallback != nil {
+		if err := m.metricCallback.Unregister(); err != nil {
+			errs = append(errs, fmt.Errorf("cache: unregistering metrics: %w", err))
+		}
+		m.metricCallback = nil
+	}
+
 	for name, store := range m.stores {
 		if driver, ok := store.(cache.Driver); ok {
 			if err := driver.Close(); err != nil {
-				lastErr = err
+				errs = append(errs, m.wrapStoreError(name, "close", "", err))
 			}
 		}
 		delete(m.stores, name)
 	}
 
-	return lastErr
+	return errors.Join(errs...)
+}
+
+// Close closes all cache stores and releases resources.
+// It is Stop without a deadline; every store is closed and their failures are
+// joined into the returned error.
+func (m *Manager) Close() error {
+	return m.Stop(context.Background())
+}
+
+// HealthCheck pings every initialized store and returns the result per store name.
+// Stores that do not implement Pinger are reported as healthy.
+func (m *Manager) HealthCheck(ctx context.Context) map[string]error {
+	m.mu.RLock()
+	stores := make(map[string]cache.Store, len(m.stores))
+	for name, store := range m.stores {
+		stores[name] = store
+	}
+	m.mu.RUnlock()
+
+	results := make(map[string]error, len(stores))
+	for name, store := range stores {
+		if pinger, ok := capability[Pinger](store); ok {
+			results[name] = pinger.Ping(ctx)
+		} else {
+			results[name] = nil
+		}
+	}
+
+	return results
 }
@@ -1,13 +1,21 @@
 package dgcache_test
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
 	cache "github.com/donnigundala/dg-cache"
 	dgcache "github.com/donnigundala/dg-cache"
 	"github.com/donnigundala/dg-cache/drivers/memory"
+	contracts "github.com/donnigundala/dg-core/contracts/cache"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -73,7 +81,7 @@ func TestManager_TTL(t *testing.T) {
 
 	// Should be gone
 	val, err = manager.Get(ctx, "short")
-	assert.Equal(t, dgcache.ErrKeyNotFound, err)
+	assert.ErrorIs(t, err, dgcache.ErrKeyNotFound)
 	assert.Nil(t, val)
 }
 
@@ -120,6 +128,82 @@ func TestManager_Remember(t *testing.T) {
 	assert.Equal(t, 1, called) // Callback count should not increase
 }
 
+func TestManager_RememberIf(t *testing.T) {
+	manager := createManager(t)
+	ctx := context.Background()
+	called := 0
+
+	callback := func() (interface{}, error) {
+		called++
+		return fmt.Sprintf("v%d", called), nil
+	}
+	var seen []interface{}
+	accept := func(cached interface{}) bool {
+		seen = append(seen, cached)
+		return cached != "v1"
+	}
+
+	// A miss runs the callback without asking accept
+	val, err := manager.RememberIf(ctx, "rem_if", time.Minute, accept, callback)
+	require.NoError(t, err)
+	assert.Equal(t, "v1", val)
+	assert.Empty(t, seen)
+
+	// A rejected hit recomputes and replaces the cached value
+	val, err = manager.RememberIf(ctx, "rem_if", time.Minute, accept, callback)
+	require.NoError(t, err)
+	assert.Equal(t, "v2", val)
+	assert.Equal(t, []interface{}{"v1"}, seen)
+	cached, err := manager.Get(ctx, "rem_if")
+	require.NoError(t, err)
+	assert.Equal(t, "v2", cached)
+
+	// An accepted hit is returned as is
+	val, err = manager.RememberIf(ctx, "rem_if", time.Minute, accept, callback)
+	require.NoError(t, err)
+	assert.Equal(t, "v2", val)
+	assert.Equal(t, 2, called)
+}
+
+func TestManager_RememberIn(t *testing.T) {
+	cfg := dgcache.DefaultConfig().WithStore("computations", dgcache.StoreConfig{
+		Driver: "memory",
+		Prefix: "comp",
+	})
+	manager, err := dgcache.NewManager(cfg)
+	require.NoError(t, err)
+	ctx := context.Background()
+	called := 0
+
+	callback := func() (interface{}, error) {
+		called++
+		return "computed", nil
+	}
+
+	val, err := manager.RememberIn(ctx, "computations", "rem_key", time.Minute, callback)
+	require.NoError(t, err)
+	assert.Equal(t, "computed", val)
+	val, err = manager.RememberIn(ctx, "computations", "rem_key", time.Minute, callback)
+	require.NoError(t, err)
+	assert.Equal(t, "computed", val)
+	assert.Equal(t, 1, called)
+
+	val, err = manager.RememberForeverIn(ctx, "computations", "forever_key", callback)
+	require.NoError(t, err)
+	assert.Equal(t, "computed", val)
+	assert.Equal(t, 2, called)
+
+	// Both values live in the named store only
+	for _, key := range []string{"rem_key", "forever_key"} {
+		cached, err := manager.GetIn(ctx, "computations", key)
+		require.NoError(t, err)
+		assert.Equal(t, "computed", cached)
+
+		_, err = manager.Get(ctx, key)
+		assert.ErrorIs(t, err, dgcache.ErrKeyNotFound)
+	}
+}
+
 func TestManager_Pull(t *testing.T) {
 	manager := createManager(t)
 	ctx := context.Background()
@@ -184,3 +268,826 @@ func TestManager_MultipleStores(t *testing.T) {
 	val2, _ := store.Get(ctx, "key")
 	assert.Equal(t, "sec_val", val2)
 }
+
+func TestManager_NamedStoreOperations(t *testing.T) {
+	cfg := cache.DefaultConfig().WithStore("secondary", cache.StoreConfig{
+		Driver: "memory",
+		Prefix: "sec",
+	})
+	manager, err := cache.NewManager(cfg)
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	require.NoError(t, manager.PutIn(ctx, "secondary", "key", "sec_val", time.Minute))
+
+	has, err := manager.HasIn(ctx, "secondary", "key")
+	require.NoError(t, err)
+	assert.True(t, has)
+	value, err := manager.GetIn(ctx, "secondary", "key")
+	require.NoError(t, err)
+	assert.Equal(t, "sec_val", value)
+
+	// The default store is untouched, and "" selects it
+	has, err = manager.Has(ctx, "key")
+	require.NoError(t, err)
+	assert.False(t, has)
+	require.NoError(t, manager.PutIn(ctx, "", "key", "default_val", time.Minute))
+	value, err = manager.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, "default_val", value)
+
+	require.NoError(t, manager.ForgetIn(ctx, "secondary", "key"))
+	_, err = manager.GetIn(ctx, "secondary", "key")
+	assert.ErrorIs(t, err, cache.ErrKeyNotFound)
+	var cacheErr *cache.CacheError
+	require.ErrorAs(t, err, &cacheErr)
+	assert.Equal(t, "secondary", cacheErr.Store)
+
+	_, err = manager.HasIn(ctx, "unknown", "key")
+	assert.ErrorIs(t, err, cache.ErrStoreNotFound)
+}
+
+// failingDriver wraps a memory driver but reports an unhealthy backend.
+type failingDriver struct {
+	contracts.Driver
+}
+
+func (d *failingDriver) Ping(ctx context.Context) error {
+	return errors.New("backend unreachable")
+}
+
+func (d *failingDriver) Unwrap() contracts.Driver {
+	return d.Driver
+}
+
+func TestManager_HealthCheck(t *testing.T) {
+	cfg := dgcache.DefaultConfig().WithStore("broken", dgcache.StoreConfig{
+		Driver: "failing",
+	})
+
+	manager, err := dgcache.NewManager(cfg)
+	require.NoError(t, err)
+	manager.RegisterDriver("memory", memory.NewDriver)
+	manager.RegisterDriver("failing", func(config dgcache.StoreConfig) (contracts.Driver, error) {
+		d, err := memory.NewDriver(config)
+		if err != nil {
+			return nil, err
+		}
+		return &failingDriver{Driver: d}, nil
+	})
+
+	_, err = manager.Store("memory")
+	require.NoError(t, err)
+	_, err = manager.Store("broken")
+	require.NoError(t, err)
+
+	results := manager.HealthCheck(context.Background())
+	assert.Len(t, results, 2)
+	assert.NoError(t, results["memory"])
+	assert.EqualError(t, results["broken"], "backend unreachable")
+}
+
+// closeFailingDriver fails to close with its own error.
+type closeFailingDriver struct {
+	contracts.Driver
+	err error
+}
+
+func (d *closeFailingDriver) Close() error {
+	d.Driver.Close()
+	return d.err
+}
+
+func TestManager_CloseJoinsErrors(t *testing.T) {
+	errFirst := errors.New("first close failed")
+	errSecond := errors.New("second close failed")
+
+	cfg := dgcache.DefaultConfig().
+		WithStore("first", dgcache.StoreConfig{Driver: "closefail", Options: map[string]interface{}{"err": "first"}}).
+		WithStore("second", dgcache.StoreConfig{Driver: "closefail", Options: map[string]interface{}{"err": "second"}})
+
+	manager, err := dgcache.NewManager(cfg)
+	require.NoError(t, err)
+	manager.RegisterDriver("memory", memory.NewDriver)
+	manager.RegisterDriver("closefail", func(config dgcache.StoreConfig) (contracts.Driver, error) {
+		d, err := memory.NewDriver(config)
+		if err != nil {
+			return nil, err
+		}
+		closeErr := errFirst
+		if config.Options["err"] == "second" {
+			closeErr = errSecond
+		}
+		return &closeFailingDriver{Driver: d, err: closeErr}, nil
+	})
+
+	for _, name := range []string{"memory", "first", "second"} {
+		_, err = manager.Store(name)
+		require.NoError(t, err)
+	}
+
+	err = manager.Close()
+	require.Error(t, err)
+	assert.ErrorIs(t, err, errFirst)
+	assert.ErrorIs(t, err, errSecond)
+
+	var cacheErr *dgcache.CacheError
+	require.ErrorAs(t, err, &cacheErr)
+	assert.Equal(t, "close", cacheErr.Op)
+
+	// Every store was closed and released despite the failures
+	assert.NoError(t, manager.Close())
+}
+
+func TestManager_MustStore(t *testing.T) {
+	manager := createManager(t)
+
+	store := manager.MustStore("memory")
+	assert.NotNil(t, store)
+	assert.Same(t, store, manager.MustStore(""))
+
+	assert.Panics(t, func() {
+		manager.MustStore("unknown")
+	})
+}
+
+func TestManager_FallbackToDefault(t *testing.T) {
+	t.Run("unknown store errors by default", func(t *testing.T) {
+		manager := createManager(t)
+
+		_, err := manager.Store("feature-store")
+		assert.ErrorIs(t, err, dgcache.ErrStoreNotFound)
+	})
+
+	t.Run("unknown store falls back when enabled", func(t *testing.T) {
+		var logs bytes.Buffer
+		previous := slog.Default()
+		slog.SetDefault(slog.New(slog.NewTextHandler(&logs, nil)))
+		defer slog.SetDefault(previous)
+
+		manager, err := dgcache.NewManager(dgcache.DefaultConfig().WithFallbackToDefault(true))
+		require.NoError(t, err)
+		defer manager.Close()
+
+		store, err := manager.Store("feature-store")
+		require.NoError(t, err)
+		assert.Same(t, manager.MustStore(""), store)
+
+		// The warning is logged once per name
+		_, err = manager.Store("feature-store")
+		require.NoError(t, err)
+		assert.Equal(t, 1, strings.Count(logs.String(), "store=feature-store"))
+	})
+}
+
+func TestManager_Info(t *testing.T) {
+	manager := createManager(t)
+
+	info, err := manager.Info("")
+	require.NoError(t, err)
+	assert.Equal(t, "memory", info.Driver)
+	assert.Equal(t, "cache", info.Prefix)
+
+	_, err = manager.Info("unknown")
+	assert.ErrorIs(t, err, dgcache.ErrStoreNotFound)
+}
+
+func TestManager_Debug(t *testing.T) {
+	cfg := dgcache.DefaultConfig().
+		WithMetrics(true).
+		WithStore("sessions", dgcache.StoreConfig{Driver: "memory", Prefix: "sess"}).
+		WithStore("broken", dgcache.StoreConfig{Driver: "unknown"})
+	manager, err := dgcache.NewManager(cfg)
+	require.NoError(t, err)
+	manager.RegisterDriver("memory", memory.NewDriver)
+	defer manager.Close()
+
+	ctx := context.Background()
+	require.NoError(t, manager.PutIn(ctx, "sessions", "a", "1", time.Minute))
+	require.NoError(t, manager.PutIn(ctx, "sessions", "b", "2", time.Minute))
+	_, err = manager.GetIn(ctx, "sessions", "a")
+	require.NoError(t, err)
+
+	info := manager.Debug()
+	assert.Equal(t, "memory", info.DefaultStore)
+	require.Len(t, info.Stores, 3)
+
+	sessions := info.Stores["sessions"]
+	assert.Empty(t, sessions.Error)
+	assert.Equal(t, "memory", sessions.Driver)
+	assert.Equal(t, "sess", sessions.Prefix)
+	assert.Equal(t, 2, sessions.Stats.ItemCount)
+	assert.Positive(t, sessions.Stats.BytesUsed)
+	assert.Equal(t, 1.0, sessions.HitRate)
+	assert.Nil(t, sessions.Pool)
+
+	// A store that cannot be created still gets an entry
+	broken := info.Stores["broken"]
+	assert.Equal(t, "unknown", broken.Driver)
+	assert.Contains(t, broken.Error, dgcache.ErrDriverNotFound.Error())
+}
+
+func TestManager_MissReturnsError(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("default returns ErrKeyNotFound", func(t *testing.T) {
+		manager := createManager(t)
+
+		val, err := manager.Get(ctx, "missing")
+		assert.ErrorIs(t, err, dgcache.ErrKeyNotFound)
+		assert.Nil(t, val)
+	})
+
+	t.Run("disabled returns nil without error", func(t *testing.T) {
+		manager, err := dgcache.NewManager(dgcache.DefaultConfig().WithMissReturnsError(false))
+		require.NoError(t, err)
+		manager.RegisterDriver("memory", memory.NewDriver)
+
+		val, err := manager.Get(ctx, "missing")
+		assert.NoError(t, err)
+		assert.Nil(t, val)
+
+		require.NoError(t, manager.Put(ctx, "present", "value", time.Minute))
+		val, err = manager.Get(ctx, "present")
+		assert.NoError(t, err)
+		assert.Equal(t, "value", val)
+	})
+}
+
+func TestManager_GetMultipleOrdered(t *testing.T) {
+	ctx := context.Background()
+	manager := createManager(t)
+
+	require.NoError(t, manager.Put(ctx, "b", "2", time.Minute))
+	require.NoError(t, manager.Put(ctx, "a", "1", time.Minute))
+
+	results, err := manager.GetMultipleOrdered(ctx, []string{"b", "missing", "a"})
+	require.NoError(t, err)
+	assert.Equal(t, []dgcache.Result{
+		{Key: "b", Value: "2", Found: true},
+		{Key: "missing"},
+		{Key: "a", Value: "1", Found: true},
+	}, results)
+}
+
+func TestManager_ErrorContext(t *testing.T) {
+	ctx := context.Background()
+	manager := createManager(t)
+
+	_, err := manager.Get(ctx, "missing")
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, dgcache.ErrKeyNotFound))
+
+	var cacheErr *dgcache.CacheError
+	require.True(t, errors.As(err, &cacheErr))
+	assert.Equal(t, "memory", cacheErr.Store)
+	assert.Equal(t, "get", cacheErr.Op)
+	assert.Equal(t, "missing", cacheErr.Key)
+	assert.Equal(t, "cache: get 'missing' on store 'memory': cache: key not found", err.Error())
+
+	err = manager.Rename(ctx, "missing", "other")
+	require.True(t, errors.As(err, &cacheErr))
+	assert.Equal(t, "rename", cacheErr.Op)
+	assert.ErrorIs(t, err, dgcache.ErrKeyNotFound)
+}
+
+func TestManager_RememberErrorTTL(t *testing.T) {
+	manager, err := dgcache.NewManager(dgcache.DefaultConfig().WithErrorTTL(100 * time.Millisecond))
+	require.NoError(t, err)
+	manager.RegisterDriver("memory", memory.NewDriver)
+
+	ctx := context.Background()
+	called := 0
+	callback := func() (interface{}, error) {
+		called++
+		return nil, errors.New("upstream down")
+	}
+
+	// First call invokes the failing loader
+	_, err = manager.Remember(ctx, "failing", time.Minute, callback)
+	assert.EqualError(t, err, "upstream down")
+	assert.Equal(t, 1, called)
+
+	// Within the window the cached error is returned
+	_, err = manager.Remember(ctx, "failing", time.Minute, callback)
+	var cachedErr *dgcache.CachedError
+	require.ErrorAs(t, err, &cachedErr)
+	assert.Equal(t, "failing", cachedErr.Key)
+	assert.Equal(t, "upstream down", cachedErr.Message)
+	assert.Equal(t, 1, called)
+
+	// The cached error is never returned as a value
+	_, err = manager.Get(ctx, "failing")
+	assert.ErrorIs(t, err, dgcache.ErrKeyNotFound)
+
+	// After the window the loader runs again
+	time.Sleep(150 * time.Millisecond)
+	_, err = manager.Remember(ctx, "failing", time.Minute, callback)
+	assert.EqualError(t, err, "upstream down")
+	assert.Equal(t, 2, called)
+}
+
+func TestManager_RememberErrorTTLCleared(t *testing.T) {
+	manager, err := dgcache.NewManager(dgcache.DefaultConfig().WithErrorTTL(time.Minute))
+	require.NoError(t, err)
+	manager.RegisterDriver("memory", memory.NewDriver)
+
+	ctx := context.Background()
+	called := 0
+	failing := func() (interface{}, error) {
+		called++
+		return nil, errors.New("upstream down")
+	}
+	remember := func() error {
+		_, err := manager.Remember(ctx, "key", time.Minute, failing)
+		return err
+	}
+
+	// The cached error stays out of the application's keys and exports
+	require.NoError(t, manager.Put(ctx, "key:__error", "mine", time.Minute))
+	assert.EqualError(t, remember(), "upstream down")
+	val, err := manager.Get(ctx, "key:__error")
+	require.NoError(t, err)
+	assert.Equal(t, "mine", val)
+	store, err := manager.Store("")
+	require.NoError(t, err)
+	var exported bytes.Buffer
+	require.NoError(t, dgcache.ExportStore(ctx, store, &exported))
+	assert.NotContains(t, exported.String(), "upstream down")
+
+	// Forget drops the cached error, so the callback runs again
+	var cachedErr *dgcache.CachedError
+	require.ErrorAs(t, remember(), &cachedErr)
+	require.NoError(t, manager.Forget(ctx, "key"))
+	assert.EqualError(t, remember(), "upstream down")
+	assert.Equal(t, 2, called)
+
+	// So do ForgetMultiple and writing the key
+	require.NoError(t, manager.ForgetMultiple(ctx, []string{"key"}))
+	assert.EqualError(t, remember(), "upstream down")
+	assert.Equal(t, 3, called)
+	has, err := store.Has(ctx, "__dgcache:error:key")
+	require.NoError(t, err)
+	assert.True(t, has)
+	require.NoError(t, manager.Put(ctx, "key", "value", time.Minute))
+	has, err = store.Has(ctx, "__dgcache:error:key")
+	require.NoError(t, err)
+	assert.False(t, has)
+}
+
+func TestManager_GetWithFallback(t *testing.T) {
+	cfg := dgcache.DefaultConfig().
+		WithStore("l1", dgcache.StoreConfig{Driver: "memory", Prefix: "l1"}).
+		WithStore("l2", dgcache.StoreConfig{Driver: "memory", Prefix: "l2"}).
+		WithFallbackBackfillTTL(time.Minute)
+
+	manager, err := dgcache.NewManager(cfg)
+	require.NoError(t, err)
+	manager.RegisterDriver("memory", memory.NewDriver)
+
+	ctx := context.Background()
+	l1, err := manager.Store("l1")
+	require.NoError(t, err)
+	l2, err := manager.Store("l2")
+	require.NoError(t, err)
+
+	require.NoError(t, l2.Put(ctx, "key", "value", time.Minute))
+
+	// Found in the second store
+	val, err := manager.GetWithFallback(ctx, "key", "l1", "l2")
+	assert.NoError(t, err)
+	assert.Equal(t, "value", val)
+
+	// Backfilled into the first store
+	val, err = l1.Get(ctx, "key")
+	assert.NoError(t, err)
+	assert.Equal(t, "value", val)
+
+	// Missing everywhere
+	_, err = manager.GetWithFallback(ctx, "missing", "l1", "l2")
+	assert.ErrorIs(t, err, dgcache.ErrKeyNotFound)
+}
+
+func TestManager_GetWithFallbackReadRepair(t *testing.T) {
+	newManager := func(t *testing.T, rate float64) (*dgcache.Manager, contracts.Store, contracts.Store) {
+		cfg := dgcache.DefaultConfig().
+			WithStore("l1", dgcache.StoreConfig{Driver: "memory", Prefix: "l1"}).
+			WithStore("l2", dgcache.StoreConfig{Driver: "memory", Prefix: "l2"}).
+			WithFallbackBackfillTTL(time.Minute).
+			WithFallbackReadRepairRate(rate)
+		manager, err := dgcache.NewManager(cfg)
+		require.NoError(t, err)
+		t.Cleanup(func() { manager.Close() })
+		return manager, manager.MustStore("l1"), manager.MustStore("l2")
+	}
+	ctx := context.Background()
+
+	t.Run("converges to the last store", func(t *testing.T) {
+		manager, l1, l2 := newManager(t, 1)
+		require.NoError(t, l2.Put(ctx, "key", "v1", time.Minute))
+		val, err := manager.GetWithFallback(ctx, "key", "l1", "l2")
+		require.NoError(t, err)
+		assert.Equal(t, "v1", val)
+
+		// Another instance updates L2 behind L1's back
+		require.NoError(t, l2.Put(ctx, "key", "v2", time.Minute))
+		val, err = manager.GetWithFallback(ctx, "key", "l1", "l2")
+		require.NoError(t, err)
+		assert.Equal(t, "v2", val)
+		val, err = l1.Get(ctx, "key")
+		require.NoError(t, err)
+		assert.Equal(t, "v2", val)
+
+		// A key removed from L2 is removed from L1 too
+		require.NoError(t, l2.Forget(ctx, "key"))
+		_, err = manager.GetWithFallback(ctx, "key", "l1", "l2")
+		assert.ErrorIs(t, err, dgcache.ErrKeyNotFound)
+		has, err := l1.Has(ctx, "key")
+		require.NoError(t, err)
+		assert.False(t, has)
+	})
+
+	t.Run("disabled serves L1", func(t *testing.T) {
+		manager, l1, l2 := newManager(t, 0)
+		require.NoError(t, l1.Put(ctx, "key", "stale", time.Minute))
+		require.NoError(t, l2.Put(ctx, "key", "fresh", time.Minute))
+
+		val, err := manager.GetWithFallback(ctx, "key", "l1", "l2")
+		require.NoError(t, err)
+		assert.Equal(t, "stale", val)
+	})
+
+	t.Run("rate out of range is rejected", func(t *testing.T) {
+		_, err := dgcache.NewManager(dgcache.DefaultConfig().WithFallbackReadRepairRate(2))
+		assert.Error(t, err)
+	})
+}
+
+func TestManager_Transaction(t *testing.T) {
+	manager := createManager(t)
+	ctx := context.Background()
+
+	err := manager.Transaction(ctx, func(tx contracts.Store) error {
+		if err := tx.Put(ctx, "key1", "value1", time.Minute); err != nil {
+			return err
+		}
+		return tx.Put(ctx, "key2", "value2", time.Minute)
+	})
+	require.NoError(t, err)
+
+	val, err := manager.Get(ctx, "key2")
+	assert.NoError(t, err)
+	assert.Equal(t, "value2", val)
+}
+
+// recordingDriver is a middleware layer that logs its name on every Get.
+type recordingDriver struct {
+	contracts.Driver
+	name string
+	log  *[]string
+}
+
+func (d *recordingDriver) Get(ctx context.Context, key string) (interface{}, error) {
+	*d.log = append(*d.log, d.name)
+	return d.Driver.Get(ctx, key)
+}
+
+func (d *recordingDriver) Unwrap() contracts.Driver {
+	return d.Driver
+}
+
+func TestManager_Middleware(t *testing.T) {
+	cfg := dgcache.DefaultConfig().WithStore("memory", dgcache.StoreConfig{
+		Driver:     "memory",
+		Middleware: []string{"outer", "inner"},
+	})
+	manager, err := dgcache.NewManager(cfg)
+	require.NoError(t, err)
+	manager.RegisterDriver("memory", memory.NewDriver)
+
+	var log []string
+	for _, name := range []string{"outer", "inner"} {
+		name := name
+		manager.RegisterMiddleware(name, func(driver contracts.Driver) contracts.Driver {
+			return &recordingDriver{Driver: driver, name: name, log: &log}
+		})
+	}
+
+	ctx := context.Background()
+	require.NoError(t, manager.Put(ctx, "key", "value", time.Minute))
+
+	val, err := manager.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, "value", val)
+	assert.Equal(t, []string{"outer", "inner"}, log)
+
+	store, err := manager.Store("memory")
+	require.NoError(t, err)
+	outer, ok := store.(*recordingDriver)
+	require.True(t, ok)
+	assert.Equal(t, "outer", outer.name)
+	assert.Equal(t, "inner", outer.Driver.(*recordingDriver).name)
+}
+
+func TestManager_MiddlewareCapabilities(t *testing.T) {
+	cfg := dgcache.DefaultConfig().WithStore("memory", dgcache.StoreConfig{
+		Driver:     "failing",
+		Middleware: []string{"outer"},
+	})
+	manager, err := dgcache.NewManager(cfg)
+	require.NoError(t, err)
+	manager.RegisterDriver("failing", func(config dgcache.StoreConfig) (contracts.Driver, error) {
+		d, err := memory.NewDriver(config)
+		if err != nil {
+			return nil, err
+		}
+		return &failingDriver{Driver: d}, nil
+	})
+
+	var log []string
+	manager.RegisterMiddleware("outer", func(driver contracts.Driver) contracts.Driver {
+		return &recordingDriver{Driver: driver, name: "outer", log: &log}
+	})
+	ctx := context.Background()
+
+	// Capabilities of the wrapped driver are found through Unwrap
+	require.NoError(t, manager.Tags("users").Put(ctx, "user:1", "john", time.Minute))
+	keys, err := manager.KeysForTag(ctx, "users")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user:1"}, keys)
+
+	ok, err := manager.Expire(ctx, "user:1", time.Hour)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	results := manager.HealthCheck(ctx)
+	assert.EqualError(t, results["memory"], "backend unreachable")
+}
+
+func TestManager_MiddlewareUnknown(t *testing.T) {
+	cfg := dgcache.DefaultConfig().WithStore("memory", dgcache.StoreConfig{
+		Driver:     "memory",
+		Middleware: []string{"missing"},
+	})
+	manager, err := dgcache.NewManager(cfg)
+	require.NoError(t, err)
+	manager.RegisterDriver("memory", memory.NewDriver)
+
+	_, err = manager.Store("memory")
+	assert.Error(t, err)
+}
+
+func TestManager_RememberRecoverPanics(t *testing.T) {
+	manager, err := dgcache.NewManager(dgcache.DefaultConfig().WithRecoverPanics(true))
+	require.NoError(t, err)
+	manager.RegisterDriver("memory", memory.NewDriver)
+	ctx := context.Background()
+
+	val, err := manager.Remember(ctx, "key", time.Minute, func() (interface{}, error) {
+		panic("loader exploded")
+	})
+	assert.Nil(t, val)
+
+	var panicErr *dgcache.PanicError
+	require.ErrorAs(t, err, &panicErr)
+	assert.Contains(t, err.Error(), "loader exploded")
+	assert.Equal(t, "key", panicErr.Key)
+	assert.NotEmpty(t, panicErr.Stack)
+
+	has, _ := manager.Has(ctx, "key")
+	assert.False(t, has)
+
+	// Without the option the panic propagates
+	assert.Panics(t, func() {
+		_, _ = createManager(t).RememberForever(ctx, "key", func() (interface{}, error) {
+			panic("loader exploded")
+		})
+	})
+}
+
+func TestManager_RememberMaxConcurrentCallbacks(t *testing.T) {
+	manager, err := dgcache.NewManager(dgcache.DefaultConfig().WithMaxConcurrentCallbacks(2))
+	require.NoError(t, err)
+	manager.RegisterDriver("memory", memory.NewDriver)
+	ctx := context.Background()
+
+	var running, peak atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			val, err := manager.Remember(ctx, fmt.Sprintf("cold:%d", i), time.Minute, func() (interface{}, error) {
+				n := running.Add(1)
+				defer running.Add(-1)
+				for {
+					p := peak.Load()
+					if n <= p || peak.CompareAndSwap(p, n) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+				return i, nil
+			})
+			assert.NoError(t, err)
+			assert.EqualValues(t, i, val)
+		}(i)
+	}
+	wg.Wait()
+	assert.LessOrEqual(t, peak.Load(), int32(2))
+
+	// A caller that cannot get a slot gives up with its context
+	release := make(chan struct{})
+	started := make(chan struct{}, 2)
+	for i := 0; i < 2; i++ {
+		go func(i int) {
+			_, _ = manager.Remember(ctx, fmt.Sprintf("slow:%d", i), time.Minute, func() (interface{}, error) {
+				started <- struct{}{}
+				<-release
+				return nil, nil
+			})
+		}(i)
+	}
+	<-started
+	<-started
+	defer close(release)
+
+	waitCtx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	called := false
+	_, err = manager.Remember(waitCtx, "blocked", time.Minute, func() (interface{}, error) {
+		called = true
+		return "value", nil
+	})
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.False(t, called)
+
+	_, err = dgcache.NewManager(dgcache.DefaultConfig().WithMaxConcurrentCallbacks(-1))
+	assert.Error(t, err)
+}
+
+func TestManager_KeysForTag(t *testing.T) {
+	manager := createManager(t)
+	ctx := context.Background()
+
+	require.NoError(t, manager.Tags("users").Put(ctx, "user:1", "john", time.Minute))
+
+	keys, err := manager.KeysForTag(ctx, "users")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"user:1"}, keys)
+
+	require.NoError(t, manager.FlushTags(ctx, "users"))
+	has, _ := manager.Has(ctx, "user:1")
+	assert.False(t, has)
+
+	require.NoError(t, manager.Tags("users").Put(ctx, "user:2", "jane", time.Minute))
+	require.NoError(t, manager.FlushTagKeysOnly(ctx, "users"))
+	has, _ = manager.Has(ctx, "user:2")
+	assert.False(t, has)
+}
+
+func TestManager_WithBypass(t *testing.T) {
+	ctx := context.Background()
+	manager := createManager(t)
+
+	calls := 0
+	callback := func() (interface{}, error) {
+		calls++
+		return calls, nil
+	}
+
+	val, err := manager.Remember(ctx, "key", time.Minute, callback)
+	require.NoError(t, err)
+	assert.Equal(t, 1, val)
+
+	// A normal context returns the cached value
+	val, err = manager.Remember(ctx, "key", time.Minute, callback)
+	require.NoError(t, err)
+	assert.Equal(t, 1, val)
+	assert.Equal(t, 1, calls)
+
+	// A bypass context always runs the callback and refreshes the value
+	bypass := dgcache.WithBypass(ctx)
+	val, err = manager.Remember(bypass, "key", time.Minute, callback)
+	require.NoError(t, err)
+	assert.Equal(t, 2, val)
+	val, err = manager.RememberForever(bypass, "key", callback)
+	require.NoError(t, err)
+	assert.Equal(t, 3, val)
+
+	val, err = manager.Get(ctx, "key")
+	require.NoError(t, err)
+	assert.Equal(t, 3, val)
+
+	_, err = manager.Get(bypass, "key")
+	assert.ErrorIs(t, err, dgcache.ErrKeyNotFound)
+}
+
+func TestManager_DefaultStoreUnavailable(t *testing.T) {
+	cfg := dgcache.DefaultConfig().WithStore("memory", dgcache.StoreConfig{Driver: "unregistered"})
+	manager, err := dgcache.NewManager(cfg)
+	require.NoError(t, err)
+
+	assert.Nil(t, manager.DefaultStore())
+	_, err = manager.DefaultStoreErr()
+	assert.ErrorIs(t, err, dgcache.ErrDriverNotFound)
+
+	assert.NotPanics(t, func() {
+		assert.Equal(t, "", manager.GetPrefix())
+		manager.SetPrefix("app")
+	})
+}
+
+func TestManager_TagExisting(t *testing.T) {
+	ctx := context.Background()
+	manager := createManager(t)
+
+	require.NoError(t, manager.Put(ctx, "a", 1, time.Minute))
+	require.NoError(t, manager.Put(ctx, "b", 2, time.Minute))
+	require.NoError(t, manager.TagExisting(ctx, "batch", "a", "b"))
+
+	keys, err := manager.KeysForTag(ctx, "batch")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, keys)
+
+	require.NoError(t, manager.FlushTags(ctx, "batch"))
+	has, err := manager.Has(ctx, "a")
+	require.NoError(t, err)
+	assert.False(t, has)
+}
+
+func TestManager_GetWithMeta(t *testing.T) {
+	manager := createManager(t)
+	ctx := context.Background()
+
+	require.NoError(t, manager.Tags("users").Put(ctx, "user:1", "john", time.Minute))
+
+	value, meta, err := manager.GetWithMeta(ctx, "user:1")
+	require.NoError(t, err)
+	assert.Equal(t, "john", value)
+	assert.Equal(t, []string{"users"}, meta.Tags)
+	assert.False(t, meta.CreatedAt.IsZero())
+	assert.True(t, meta.ExpiresWithin(time.Minute))
+
+	_, _, err = manager.GetWithMeta(ctx, "missing")
+	assert.ErrorIs(t, err, dgcache.ErrKeyNotFound)
+}
+
+func TestManager_GetItem(t *testing.T) {
+	manager := createManager(t)
+	ctx := context.Background()
+
+	require.NoError(t, manager.Tags("users").Put(ctx, "user:1", "john", time.Minute))
+
+	item, err := manager.GetItem(ctx, "user:1")
+	require.NoError(t, err)
+	assert.Equal(t, "john", item.Value)
+	assert.Equal(t, []string{"users"}, item.Tags)
+	assert.False(t, item.ExpiresAt.IsZero())
+
+	_, err = manager.GetItem(ctx, "missing")
+	assert.ErrorIs(t, err, dgcache.ErrKeyNotFound)
+}
+
+func TestManager_HasMultiple(t *testing.T) {
+	manager := createManager(t)
+	ctx := context.Background()
+
+	require.NoError(t, manager.Put(ctx, "a", 1, time.Minute))
+	result, err := manager.HasMultiple(ctx, []string{"a", "b"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"a": true, "b": false}, result)
+}
+
+func TestManager_Hash(t *testing.T) {
+	manager := createManager(t)
+	ctx := context.Background()
+
+	require.NoError(t, manager.HSet(ctx, "settings", "theme", "dark"))
+	require.NoError(t, manager.HSet(ctx, "settings", "lang", "en"))
+
+	val, err := manager.HGet(ctx, "settings", "theme")
+	require.NoError(t, err)
+	assert.Equal(t, "dark", val)
+
+	all, err := manager.HGetAll(ctx, "settings")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"theme": "dark", "lang": "en"}, all)
+
+	require.NoError(t, manager.HDel(ctx, "settings", "theme"))
+	_, err = manager.HGet(ctx, "settings", "theme")
+	assert.ErrorIs(t, err, dgcache.ErrKeyNotFound)
+}
+
+func TestManager_IncrementWithTTL(t *testing.T) {
+	manager := createManager(t)
+	ctx := context.Background()
+
+	n, err := manager.IncrementWithTTL(ctx, "hits", 1, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), n)
+
+	n, err = manager.IncrementWithTTL(ctx, "hits", 1, time.Minute)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), n)
+}
@@ -0,0 +1,66 @@
+package dgcache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/donnigundala/dg-core/contracts/cache"
+)
+
+// Memoize turns fn into a cache-backed function. Each call goes through
+// c.Remember under MemoKey(namespace, args), so fn runs once per distinct
+// argument value until the entry expires after ttl. Pass several arguments as
+// a struct.
+//
+// fn should be pure: the cached result is returned for equal arguments no
+// matter what else changed. Results that come back from the store in another
+// form, such as a struct read back from Redis as a map, are converted to R
+// through JSON.
+func Memoize[A, R any](c cache.Cache, namespace string, ttl time.Duration, fn func(ctx context.Context, args A) (R, error)) func(ctx context.Context, args A) (R, error) {
+	return func(ctx context.Context, args A) (R, error) {
+		var result R
+		key, err := MemoKey(namespace, args)
+		if err != nil {
+			return result, err
+		}
+
+		value, err := c.Remember(ctx, key, ttl, func() (interface{}, error) {
+			computed, err := fn(ctx, args)
+			if err != nil {
+				return nil, err
+			}
+			return computed, nil
+		})
+		if err != nil || value == nil {
+			return result, err
+		}
+
+		if typed, ok := value.(R); ok {
+			return typed, nil
+		}
+		data, err := json.Marshal(value)
+		if err == nil {
+			err = json.Unmarshal(data, &result)
+		}
+		if err != nil {
+			return result, fmt.Errorf("cache: converting memoized %T to %T: %w", value, result, err)
+		}
+		return result, nil
+	}
+}
+
+// MemoKey returns the key Memoize caches a call with args under: namespace
+// followed by a hash of the JSON encoding of args. Use it to Forget a
+// memoized result.
+func MemoKey(namespace string, args interface{}) (string, error) {
+	data, err := json.Marshal(args)
+	if err != nil {
+		return "", fmt.Errorf("%w: memoize arguments: %w", ErrInvalidValue, err)
+	}
+	sum := sha256.Sum256(data)
+	return namespace + ":" + hex.EncodeToString(sum[:16]), nil
+}
@@ -0,0 +1,81 @@
+package dgcache_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	dgcache "github.com/donnigundala/dg-cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type priceQuery struct {
+	Product  string
+	Quantity int
+}
+
+type price struct {
+	Total float64
+}
+
+func TestMemoize(t *testing.T) {
+	manager := createManager(t)
+	ctx := context.Background()
+
+	calls := map[priceQuery]int{}
+	quote := dgcache.Memoize(manager, "quote", time.Minute, func(ctx context.Context, q priceQuery) (price, error) {
+		calls[q]++
+		return price{Total: float64(q.Quantity) * 2.5}, nil
+	})
+
+	for i := 0; i < 3; i++ {
+		p, err := quote(ctx, priceQuery{Product: "apple", Quantity: 2})
+		require.NoError(t, err)
+		assert.Equal(t, price{Total: 5}, p)
+
+		p, err = quote(ctx, priceQuery{Product: "apple", Quantity: 4})
+		require.NoError(t, err)
+		assert.Equal(t, price{Total: 10}, p)
+	}
+
+	// Each distinct argument set ran once
+	assert.Equal(t, map[priceQuery]int{
+		{Product: "apple", Quantity: 2}: 1,
+		{Product: "apple", Quantity: 4}: 1,
+	}, calls)
+
+	// The key is deterministic, so a result can be forgotten
+	key, err := dgcache.MemoKey("quote", priceQuery{Product: "apple", Quantity: 2})
+	require.NoError(t, err)
+	require.NoError(t, manager.Forget(ctx, key))
+	_, err = quote(ctx, priceQuery{Product: "apple", Quantity: 2})
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls[priceQuery{Product: "apple", Quantity: 2}])
+}
+
+func TestMemoize_Errors(t *testing.T) {
+	manager := createManager(t)
+	ctx := context.Background()
+
+	calls := 0
+	failing := dgcache.Memoize(manager, "failing", time.Minute, func(ctx context.Context, id int) (string, error) {
+		calls++
+		return "", errors.New("backend down")
+	})
+
+	// Failures are not cached
+	_, err := failing(ctx, 1)
+	assert.EqualError(t, err, "backend down")
+	_, err = failing(ctx, 1)
+	assert.Error(t, err)
+	assert.Equal(t, 2, calls)
+
+	// Arguments that can't be encoded are rejected
+	unencodable := dgcache.Memoize(manager, "chan", time.Minute, func(ctx context.Context, ch chan int) (int, error) {
+		return 0, nil
+	})
+	_, err = unencodable(ctx, make(chan int))
+	assert.ErrorIs(t, err, dgcache.ErrInvalidValue)
+}
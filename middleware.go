@@ -0,0 +1,79 @@
+package dgcache
+
+import (
+	"sync"
+
+	"github.com/donnigundala/dg-core/contracts/cache"
+)
+
+// Middleware decorates a driver, e.g. with logging, tracing, or a circuit breaker.
+// It typically returns a type that embeds the given driver and overrides some methods.
+type Middleware func(driver cache.Driver) cache.Driver
+
+var (
+	globalMiddleware   = make(map[string]Middleware)
+	globalMiddlewareMu sync.RWMutex
+)
+
+// RegisterMiddleware registers a middleware globally under the given name.
+// Stores enable it by listing the name in StoreConfig.Middleware.
+func RegisterMiddleware(name string, middleware Middleware) {
+	globalMiddlewareMu.Lock()
+	defer globalMiddlewareMu.Unlock()
+	globalMiddleware[name] = middleware
+}
+
+// RegisterMiddleware registers a middleware for this manager under the given name.
+func (m *Manager) RegisterMiddleware(name string, middleware Middleware) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.middleware[name] = middleware
+}
+
+// Unwrapper is implemented by middleware that wraps another driver. The
+// manager looks for optional capabilities such as TTLExtender or
+// cache.TaggedStore layer by layer through Unwrap, so a middleware only has
+// to forward the capabilities it wants to observe.
+type Unwrapper interface {
+	Unwrap() cache.Driver
+}
+
+// capability returns the outermost layer of store that implements T,
+// following Unwrap through middleware that does not.
+func capability[T any](store cache.Store) (T, bool) {
+	for {
+		if c, ok := store.(T); ok {
+			return c, true
+		}
+		unwrapper, ok := store.(Unwrapper)
+		if !ok {
+			var zero T
+			return zero, false
+		}
+		store = unwrapper.Unwrap()
+	}
+}
+
+// Sampler is implemented by middleware that can instrument only a fraction of
+// operations. Layers implementing it receive the store's SampleRate.
+type Sampler interface {
+	SetSampleRate(rate float64)
+}
+
+// applyMiddleware wraps driver with the middleware named in config.
+// The first name becomes the outermost layer, so it sees each call first.
+// Caller must hold the lock.
+func (m *Manager) applyMiddleware(driver cache.Driver, config StoreConfig) (cache.Driver, error) {
+	names := config.Middleware
+	for i := len(names) - 1; i >= 0; i-- {
+		middleware, ok := m.middleware[names[i]]
+		if !ok {
+			return nil, ErrInvalidConfig("unknown middleware '%s'", names[i])
+		}
+		driver = middleware(driver)
+		if sampler, ok := driver.(Sampler); ok && config.SampleRate != nil {
+			sampler.SetSampleRate(*config.SampleRate)
+		}
+	}
+	return driver, nil
+}
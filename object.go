@@ -0,0 +1,27 @@
+package dgcache
+
+import (
+	"context"
+	"time"
+)
+
+// Cacheable is implemented by types that declare their own cache key and TTL,
+// keeping the key and TTL conventions for a type in one place.
+type Cacheable interface {
+	// CacheKey returns the key the object is stored under.
+	CacheKey() string
+
+	// CacheTTL returns how long the object is cached; 0 means no expiry.
+	CacheTTL() time.Duration
+}
+
+// PutObject stores obj in the default cache store under its CacheKey with its CacheTTL.
+func (m *Manager) PutObject(ctx context.Context, obj Cacheable) error {
+	return m.Put(ctx, obj.CacheKey(), obj, obj.CacheTTL())
+}
+
+// GetObject retrieves the object stored under key into dest, which must be a
+// pointer, as GetAs does.
+func (m *Manager) GetObject(ctx context.Context, key string, dest Cacheable) error {
+	return m.GetAs(ctx, key, dest)
+}
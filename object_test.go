@@ -0,0 +1,43 @@
+package dgcache_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	dgcache "github.com/donnigundala/dg-cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type cachedProduct struct {
+	ID    int
+	Name  string
+	Price float64
+}
+
+func (p *cachedProduct) CacheKey() string        { return fmt.Sprintf("product:%d", p.ID) }
+func (p *cachedProduct) CacheTTL() time.Duration { return 10 * time.Minute }
+
+func TestManager_PutObject(t *testing.T) {
+	manager := createManager(t)
+	defer manager.Close()
+	ctx := context.Background()
+
+	product := &cachedProduct{ID: 42, Name: "Lamp", Price: 19.5}
+	require.NoError(t, manager.PutObject(ctx, product))
+
+	// Stored under its declared key with its declared TTL
+	values, err := manager.GetMultipleWithTTL(ctx, []string{"product:42"})
+	require.NoError(t, err)
+	require.Contains(t, values, "product:42")
+	assert.InDelta(t, 10*time.Minute, values["product:42"].TTL, float64(time.Second))
+
+	var restored cachedProduct
+	require.NoError(t, manager.GetObject(ctx, "product:42", &restored))
+	assert.Equal(t, *product, restored)
+
+	err = manager.GetObject(ctx, "product:43", &restored)
+	assert.ErrorIs(t, err, dgcache.ErrKeyNotFound)
+}
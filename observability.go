@@ -59,6 +59,22 @@ func (m *Manager) RegisterMetrics() error {
 		return err
 	}
 
+	m.metricExpired, err = meter.Int64ObservableCounter(
+		"cache.expirations",
+		metric.WithDescription("Total number of items removed because their TTL passed"),
+	)
+	if err != nil {
+		return err
+	}
+
+	m.metricSerializationErrors, err = meter.Int64ObservableCounter(
+		"cache.serialization_errors",
+		metric.WithDescription("Total number of values that failed to encode or decode"),
+	)
+	if err != nil {
+		return err
+	}
+
 	// Gauges for current state
 	m.metricItems, err = meter.Int64ObservableGauge(
 		"cache.items",
@@ -76,8 +92,50 @@ func (m *Manager) RegisterMetrics() error {
 		return err
 	}
 
+	// Connection pool metrics, for stores implementing PoolReporter
+	m.metricPoolHits, err = meter.Int64ObservableCounter(
+		"cache.pool.hits",
+		metric.WithDescription("Total number of times a free connection was found in the pool"),
+	)
+	if err != nil {
+		return err
+	}
+
+	m.metricPoolMisses, err = meter.Int64ObservableCounter(
+		"cache.pool.misses",
+		metric.WithDescription("Total number of times no free connection was found in the pool"),
+	)
+	if err != nil {
+		return err
+	}
+
+	m.metricPoolTimeouts, err = meter.Int64ObservableCounter(
+		"cache.pool.timeouts",
+		metric.WithDescription("Total number of waits for a connection that timed out"),
+	)
+	if err != nil {
+		return err
+	}
+
+	m.metricPoolConns, err = meter.Int64ObservableGauge(
+		"cache.pool.connections",
+		metric.WithDescription("Current number of connections in the pool"),
+	)
+	if err != nil {
+		return err
+	}
+
+	m.metricPoolIdleConns, err = meter.Int64ObservableGauge(
+		"cache.pool.idle_connections",
+		metric.WithDescription("Current number of idle connections in the pool"),
+	)
+	if err != nil {
+		return err
+	}
+
 	// Register callback to collect metrics from all stores
-	_, err = meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
+	// Stop unregisters the callback before closing the stores it reads
+	registration, err := meter.RegisterCallback(func(ctx context.Context, o metric.Observer) error {
 		m.mu.RLock()
 		defer m.mu.RUnlock()
 
@@ -92,11 +150,37 @@ func (m *Manager) RegisterMetrics() error {
 			o.ObserveInt64(m.metricSets, stats.Sets, attrs)
 			o.ObserveInt64(m.metricDeletes, stats.Deletes, attrs)
 			o.ObserveInt64(m.metricEvictions, stats.Evictions, attrs)
+			if counter, ok := capability[ExpirationCounter](store); ok {
+				o.ObserveInt64(m.metricExpired, counter.Expirations(), attrs)
+			}
+			if counter, ok := capability[SerializationErrorCounter](store); ok {
+				o.ObserveInt64(m.metricSerializationErrors, counter.SerializationErrors(), attrs)
+			}
 			o.ObserveInt64(m.metricItems, int64(stats.ItemCount), attrs)
 			o.ObserveInt64(m.metricBytes, stats.BytesUsed, attrs)
+			if reporter, ok := capability[PoolReporter](store); ok {
+				pool := reporter.PoolUsage()
+				o.ObserveInt64(m.metricPoolHits, int64(pool.Hits), attrs)
+				o.ObserveInt64(m.metricPoolMisses, int64(pool.Misses), attrs)
+				o.ObserveInt64(m.metricPoolTimeouts, int64(pool.Timeouts), attrs)
+				o.ObserveInt64(m.metricPoolConns, int64(pool.TotalConns), attrs)
+				o.ObserveInt64(m.metricPoolIdleConns, int64(pool.IdleConns), attrs)
+			}
 		}
 		return nil
-	}, m.metricHits, m.metricMisses, m.metricSets, m.metricDeletes, m.metricEvictions, m.metricItems, m.metricBytes)
+	}, m.metricHits, m.metricMisses, m.metricSets, m.metricDeletes, m.metricEvictions, m.metricExpired, m.metricSerializationErrors, m.metricItems, m.metricBytes,
+		m.metricPoolHits, m.metricPoolMisses, m.metricPoolTimeouts, m.metricPoolConns, m.metricPoolIdleConns)
+	if err != nil {
+		return err
+	}
 
-	return err
+	m.mu.Lock()
+	previous := m.metricCallback
+	m.metricCallback = registration
+	m.mu.Unlock()
+
+	if previous != nil {
+		return previous.Unregister()
+	}
+	return nil
 }
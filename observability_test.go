@@ -0,0 +1,87 @@
+package dgcache_test
+
+import (
+	"context"
+	"testing"
+
+	dgcache "github.com/donnigundala/dg-cache"
+	"github.com/donnigundala/dg-cache/drivers/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/embedded"
+	"go.opentelemetry.io/otel/metric/noop"
+)
+
+// callbackMeterProvider captures the callbacks registered by RegisterMetrics
+// so tests can trigger a collection.
+type callbackMeterProvider struct {
+	noop.MeterProvider
+	callbacks []metric.Callback
+}
+
+func (p *callbackMeterProvider) Meter(name string, opts ...metric.MeterOption) metric.Meter {
+	return &callbackMeter{provider: p}
+}
+
+type callbackMeter struct {
+	noop.Meter
+	provider *callbackMeterProvider
+}
+
+func (m *callbackMeter) RegisterCallback(f metric.Callback, instruments ...metric.Observable) (metric.Registration, error) {
+	m.provider.callbacks = append(m.provider.callbacks, f)
+	return noop.Registration{}, nil
+}
+
+// collect runs the registered callbacks and returns the stores that were observed.
+func (p *callbackMeterProvider) collect(t *testing.T) map[string]bool {
+	observer := &storeObserver{stores: make(map[string]bool)}
+	for _, callback := range p.callbacks {
+		require.NoError(t, callback(context.Background(), observer))
+	}
+	return observer.stores
+}
+
+// storeObserver records the cache.store attribute of every observation.
+type storeObserver struct {
+	embedded.Observer
+	stores map[string]bool
+}
+
+func (o *storeObserver) ObserveFloat64(metric.Float64Observable, float64, ...metric.ObserveOption) {}
+
+func (o *storeObserver) ObserveInt64(_ metric.Int64Observable, _ int64, opts ...metric.ObserveOption) {
+	attrs := metric.NewObserveConfig(opts).Attributes()
+	if name, ok := attrs.Value("cache.store"); ok {
+		o.stores[name.AsString()] = true
+	}
+}
+
+func TestManager_RegisterMetricsObservesLateStores(t *testing.T) {
+	provider := &callbackMeterProvider{}
+	previous := otel.GetMeterProvider()
+	otel.SetMeterProvider(provider)
+	defer otel.SetMeterProvider(previous)
+
+	cfg := dgcache.DefaultConfig().WithStore("late", dgcache.StoreConfig{
+		Driver: "memory",
+	})
+	manager, err := dgcache.NewManager(cfg)
+	require.NoError(t, err)
+	manager.RegisterDriver("memory", memory.NewDriver)
+
+	_, err = manager.Store("memory")
+	require.NoError(t, err)
+	require.NoError(t, manager.RegisterMetrics())
+	require.Len(t, provider.callbacks, 1)
+
+	assert.Equal(t, map[string]bool{"memory": true}, provider.collect(t))
+
+	// A store created after registration is picked up by the next collection
+	_, err = manager.Store("late")
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]bool{"memory": true, "late": true}, provider.collect(t))
+}
@@ -2,8 +2,10 @@ package dgcache
 
 import (
 	"fmt"
+	"reflect"
 
 	"github.com/donnigundala/dg-core/contracts/foundation"
+	"github.com/mitchellh/mapstructure"
 )
 
 // CacheServiceProvider implements the PluginProvider interface.
@@ -37,8 +39,86 @@ func (p *CacheServiceProvider) Dependencies() []string {
 	return []string{}
 }
 
+// configBinding is the container binding of the application's config repository.
+const configBinding = "config"
+
+// configGetter is a config repository that returns the raw value under a key,
+// such as the map[string]interface{} parsed from YAML or env.
+type configGetter interface {
+	Get(key string) interface{}
+}
+
+// configUnmarshaler is a config repository that decodes a key into a struct.
+type configUnmarshaler interface {
+	Unmarshal(key string, target interface{}) error
+}
+
+// configKey returns the config key of the Config field, from its config tag.
+func (p *CacheServiceProvider) configKey() string {
+	field, _ := reflect.TypeOf(p).Elem().FieldByName("Config")
+	if key := field.Tag.Get("config"); key != "" {
+		return key
+	}
+	return Binding
+}
+
+// loadConfig reads the cache configuration from the application's config
+// repository. It reports false if there is no repository or no cache key.
+func (p *CacheServiceProvider) loadConfig(app foundation.Application) (Config, bool, error) {
+	repository, err := app.Make(configBinding)
+	if err != nil || repository == nil {
+		return Config{}, false, nil
+	}
+
+	key := p.configKey()
+	var cfg Config
+	switch repository := repository.(type) {
+	case configUnmarshaler:
+		if err := repository.Unmarshal(key, &cfg); err != nil {
+			return Config{}, false, err
+		}
+	case configGetter:
+		raw := repository.Get(key)
+		if raw == nil {
+			return Config{}, false, nil
+		}
+		decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+			Result:     &cfg,
+			TagName:    "mapstructure",
+			DecodeHook: mapstructure.StringToTimeDurationHookFunc(),
+		})
+		if err != nil {
+			return Config{}, false, err
+		}
+		if err := decoder.Decode(raw); err != nil {
+			return Config{}, false, err
+		}
+	default:
+		return Config{}, false, nil
+	}
+
+	if cfg.DefaultStore == "" && len(cfg.Stores) == 0 {
+		return Config{}, false, nil
+	}
+	return cfg, true, nil
+}
+
 // Register registers the cache service provider.
+//
+// When Config is not set, it is loaded from the "cache" key of the
+// application's config repository, bound as "config" in the container, if
+// there is one; otherwise DefaultConfig is used.
 func (p *CacheServiceProvider) Register(app foundation.Application) error {
+	if p.Config.DefaultStore == "" {
+		cfg, found, err := p.loadConfig(app)
+		if err != nil {
+			return fmt.Errorf("failed to load cache config: %w", err)
+		}
+		if found {
+			p.Config = cfg
+		}
+	}
+
 	app.Singleton(Binding, func() (interface{}, error) {
 		// Use provided config or default
 		cfg := p.Config
@@ -2,9 +2,12 @@ package dgcache
 
 import (
 	"testing"
+	"time"
 
 	"github.com/donnigundala/dg-core/contracts/cache"
+	"github.com/donnigundala/dg-core/foundation"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCacheServiceProvider_Name(t *testing.T) {
@@ -59,3 +62,57 @@ func TestCacheServiceProvider_DriverFactories(t *testing.T) {
 	assert.NotNil(t, provider.DriverFactories)
 	assert.Contains(t, provider.DriverFactories, "memory")
 }
+
+// fakeConfigSource is a config repository holding parsed YAML-like values.
+type fakeConfigSource map[string]interface{}
+
+func (c fakeConfigSource) Get(key string) interface{} {
+	return c[key]
+}
+
+func TestCacheServiceProvider_LoadsConfig(t *testing.T) {
+	app := foundation.New(".")
+	app.Singleton("config", func() (interface{}, error) {
+		return fakeConfigSource{
+			"cache": map[string]interface{}{
+				"default_store": "sessions",
+				"prefix":        "app",
+				"error_ttl":     "30s",
+				"stores": map[string]interface{}{
+					"sessions": map[string]interface{}{"driver": "memory", "prefix": "sess"},
+					"pages":    map[string]interface{}{"driver": "memory"},
+				},
+			},
+		}, nil
+	})
+
+	provider := NewCacheServiceProvider(nil)
+	require.NoError(t, provider.Register(app))
+	require.NoError(t, provider.Boot(app))
+
+	assert.Equal(t, "sessions", provider.Config.DefaultStore)
+	assert.Equal(t, 30*time.Second, provider.Config.ErrorTTL)
+	assert.Len(t, provider.Config.Stores, 2)
+
+	instance, err := app.Make(Binding)
+	require.NoError(t, err)
+	manager := instance.(*Manager)
+	info, err := manager.Info("")
+	require.NoError(t, err)
+	assert.Equal(t, "sess", info.Prefix)
+
+	// Named stores from the loaded config are bound in the container
+	_, err = app.Make(Binding + ".pages")
+	assert.NoError(t, err)
+}
+
+func TestCacheServiceProvider_ExplicitConfigWins(t *testing.T) {
+	app := foundation.New(".")
+	app.Singleton("config", func() (interface{}, error) {
+		return fakeConfigSource{"cache": map[string]interface{}{"default_store": "other"}}, nil
+	})
+
+	provider := &CacheServiceProvider{Config: DefaultConfig()}
+	require.NoError(t, provider.Register(app))
+	assert.Equal(t, "memory", provider.Config.DefaultStore)
+}
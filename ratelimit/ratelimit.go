@@ -0,0 +1,66 @@
+// Package ratelimit provides a fixed-window rate limiter backed by a cache store.
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	dgcache "github.com/donnigundala/dg-cache"
+	"github.com/donnigundala/dg-core/contracts/cache"
+)
+
+// keyPrefix namespaces limiter counters from other cached data.
+const keyPrefix = "ratelimit:"
+
+// RateLimiter is a fixed-window rate limiter. Each key gets a counter that is
+// incremented on every call and expires when its window ends.
+type RateLimiter struct {
+	store cache.Store
+}
+
+// New creates a RateLimiter that keeps its counters in store.
+// The store must implement dgcache.TTLIncrementer or dgcache.Expirer.
+func New(store cache.Store) *RateLimiter {
+	return &RateLimiter{store: store}
+}
+
+// Allow records a hit for key and reports whether it is within limit for the
+// current window, along with the number of hits remaining in that window.
+// The window starts at the first hit and lasts for window.
+func (l *RateLimiter) Allow(ctx context.Context, key string, limit int, window time.Duration) (bool, int, error) {
+	count, err := l.hit(ctx, keyPrefix+key, window)
+	if err != nil {
+		return false, 0, err
+	}
+
+	remaining := int64(limit) - count
+	if remaining < 0 {
+		remaining = 0
+	}
+	return count <= int64(limit), int(remaining), nil
+}
+
+// hit increments the counter at key, starting a window of the given length
+// when the counter is created.
+func (l *RateLimiter) hit(ctx context.Context, key string, window time.Duration) (int64, error) {
+	// Atomic when the store supports it
+	if incrementer, ok := l.store.(dgcache.TTLIncrementer); ok {
+		return incrementer.IncrementWithTTL(ctx, key, 1, window)
+	}
+
+	expirer, ok := l.store.(dgcache.Expirer)
+	if !ok {
+		return 0, dgcache.ErrNotSupported
+	}
+	count, err := l.store.Increment(ctx, key, 1)
+	if err != nil {
+		return 0, err
+	}
+	// The first hit opens the window
+	if count == 1 {
+		if _, err := expirer.Expire(ctx, key, window); err != nil {
+			return 0, err
+		}
+	}
+	return count, nil
+}
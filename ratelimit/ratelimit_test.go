@@ -0,0 +1,52 @@
+package ratelimit_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dgcache "github.com/donnigundala/dg-cache"
+	"github.com/donnigundala/dg-cache/drivers/memory"
+	"github.com/donnigundala/dg-cache/ratelimit"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newLimiter(t *testing.T) *ratelimit.RateLimiter {
+	t.Helper()
+	driver, err := memory.NewDriver(dgcache.StoreConfig{Driver: "memory"})
+	require.NoError(t, err)
+	t.Cleanup(func() { driver.Close() })
+	return ratelimit.New(driver)
+}
+
+func TestRateLimiter_Allow(t *testing.T) {
+	ctx := context.Background()
+	limiter := newLimiter(t)
+	window := 100 * time.Millisecond
+
+	for want := 2; want >= 0; want-- {
+		allowed, remaining, err := limiter.Allow(ctx, "user:1", 3, window)
+		require.NoError(t, err)
+		assert.True(t, allowed)
+		assert.Equal(t, want, remaining)
+	}
+
+	// The fourth hit in the window is rejected
+	allowed, remaining, err := limiter.Allow(ctx, "user:1", 3, window)
+	require.NoError(t, err)
+	assert.False(t, allowed)
+	assert.Equal(t, 0, remaining)
+
+	// Other keys have their own window
+	allowed, _, err = limiter.Allow(ctx, "user:2", 3, window)
+	require.NoError(t, err)
+	assert.True(t, allowed)
+
+	// The limit resets once the window has passed
+	time.Sleep(window + 20*time.Millisecond)
+	allowed, remaining, err = limiter.Allow(ctx, "user:1", 3, window)
+	require.NoError(t, err)
+	assert.True(t, allowed)
+	assert.Equal(t, 2, remaining)
+}
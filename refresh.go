@@ -0,0 +1,113 @@
+package dgcache
+
+import (
+	"context"
+	"time"
+)
+
+// refreshJob is a background refresh started by ScheduleRefresh.
+type refreshJob struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// stop cancels the job and waits for its goroutine to exit.
+func (j *refreshJob) stop() {
+	j.cancel()
+	<-j.done
+}
+
+// ScheduleRefresh keeps key warm by calling loader immediately and then every
+// interval, storing each result in the default store with the given TTL.
+// A failed load leaves the cached value untouched until the next tick.
+//
+// Scheduling a key that is already scheduled replaces the previous refresh.
+// The returned function stops the refresh; Close stops all of them. Once the
+// manager is stopping, no refresh is started and the returned function is a no-op.
+// It panics if interval is not positive.
+func (m *Manager) ScheduleRefresh(key string, interval time.Duration, loader func() (interface{}, error), ttl time.Duration) (unschedule func()) {
+	if interval <= 0 {
+		panic("dgcache: non-positive interval for ScheduleRefresh")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	job := &refreshJob{cancel: cancel, done: make(chan struct{})}
+
+	m.refreshMu.Lock()
+	if m.stopping {
+		m.refreshMu.Unlock()
+		cancel()
+		return func() {}
+	}
+	if m.refreshes == nil {
+		m.refreshes = make(map[string]*refreshJob)
+	}
+	previous := m.refreshes[key]
+	m.refreshes[key] = job
+	m.refreshWG.Add(1)
+	m.refreshMu.Unlock()
+
+	if previous != nil {
+		previous.stop()
+	}
+
+	go m.runRefresh(ctx, job, key, interval, loader, ttl)
+
+	return func() {
+		m.refreshMu.Lock()
+		if m.refreshes[key] == job {
+			delete(m.refreshes, key)
+		}
+		m.refreshMu.Unlock()
+		job.stop()
+	}
+}
+
+// runRefresh loads and stores key until ctx is cancelled.
+func (m *Manager) runRefresh(ctx context.Context, job *refreshJob, key string, interval time.Duration, loader func() (interface{}, error), ttl time.Duration) {
+	defer m.refreshWG.Done()
+	defer close(job.done)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if value, err := m.runCallback(ctx, key, loader); err == nil && ctx.Err() == nil {
+			_ = m.Put(ctx, key, value, ttl)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// stopRefreshes rejects new refreshes, cancels the running ones and waits for
+// them to exit. It returns ctx.Err() if ctx is done first; a loader that is
+// still running then finishes in the background without storing its result.
+func (m *Manager) stopRefreshes(ctx context.Context) error {
+	m.refreshMu.Lock()
+	m.stopping = true
+	jobs := m.refreshes
+	m.refreshes = nil
+	m.refreshMu.Unlock()
+
+	for _, job := range jobs {
+		job.cancel()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		m.refreshWG.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,144 @@
+package dgcache_test
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_ScheduleRefresh(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("refreshes periodically", func(t *testing.T) {
+		manager := createManager(t)
+		defer manager.Close()
+
+		var calls atomic.Int64
+		unschedule := manager.ScheduleRefresh("hot", 10*time.Millisecond, func() (interface{}, error) {
+			return int(calls.Add(1)), nil
+		}, time.Minute)
+		defer unschedule()
+
+		assert.Eventually(t, func() bool {
+			val, err := manager.Get(ctx, "hot")
+			return err == nil && val.(int) >= 3
+		}, time.Second, 5*time.Millisecond)
+	})
+
+	t.Run("unschedule stops refreshing", func(t *testing.T) {
+		manager := createManager(t)
+		defer manager.Close()
+
+		var calls atomic.Int64
+		unschedule := manager.ScheduleRefresh("hot", 10*time.Millisecond, func() (interface{}, error) {
+			return int(calls.Add(1)), nil
+		}, time.Minute)
+
+		require.Eventually(t, func() bool { return calls.Load() >= 2 }, time.Second, 5*time.Millisecond)
+		unschedule()
+
+		stopped := calls.Load()
+		time.Sleep(50 * time.Millisecond)
+		assert.Equal(t, stopped, calls.Load())
+	})
+
+	t.Run("close stops refreshing", func(t *testing.T) {
+		manager := createManager(t)
+
+		var calls atomic.Int64
+		manager.ScheduleRefresh("a", 10*time.Millisecond, func() (interface{}, error) {
+			calls.Add(1)
+			return "a", nil
+		}, time.Minute)
+		manager.ScheduleRefresh("b", 10*time.Millisecond, func() (interface{}, error) {
+			calls.Add(1)
+			return "b", nil
+		}, time.Minute)
+
+		require.Eventually(t, func() bool { return calls.Load() >= 4 }, time.Second, 5*time.Millisecond)
+		require.NoError(t, manager.Close())
+
+		stopped := calls.Load()
+		time.Sleep(50 * time.Millisecond)
+		assert.Equal(t, stopped, calls.Load())
+	})
+
+	t.Run("rescheduling replaces the previous refresh", func(t *testing.T) {
+		manager := createManager(t)
+		defer manager.Close()
+
+		var old atomic.Int64
+		manager.ScheduleRefresh("hot", 10*time.Millisecond, func() (interface{}, error) {
+			old.Add(1)
+			return "old", nil
+		}, time.Minute)
+		require.Eventually(t, func() bool { return old.Load() >= 1 }, time.Second, 5*time.Millisecond)
+
+		manager.ScheduleRefresh("hot", 10*time.Millisecond, func() (interface{}, error) {
+			return "new", nil
+		}, time.Minute)
+
+		stopped := old.Load()
+		time.Sleep(50 * time.Millisecond)
+		assert.Equal(t, stopped, old.Load())
+
+		val, err := manager.Get(ctx, "hot")
+		require.NoError(t, err)
+		assert.Equal(t, "new", val)
+	})
+}
+
+func TestManager_StopWaitsForRefreshes(t *testing.T) {
+	t.Run("refresher exits within the deadline", func(t *testing.T) {
+		manager := createManager(t)
+
+		var calls atomic.Int64
+		manager.ScheduleRefresh("hot", 10*time.Millisecond, func() (interface{}, error) {
+			calls.Add(1)
+			return "hot", nil
+		}, time.Minute)
+		require.Eventually(t, func() bool { return calls.Load() >= 2 }, time.Second, 5*time.Millisecond)
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		require.NoError(t, manager.Stop(ctx))
+
+		stopped := calls.Load()
+		time.Sleep(50 * time.Millisecond)
+		assert.Equal(t, stopped, calls.Load())
+
+		// No new work is accepted once stopping
+		manager.ScheduleRefresh("late", 10*time.Millisecond, func() (interface{}, error) {
+			calls.Add(1)
+			return "late", nil
+		}, time.Minute)
+		time.Sleep(50 * time.Millisecond)
+		assert.Equal(t, stopped, calls.Load())
+	})
+
+	t.Run("close respects the context timeout", func(t *testing.T) {
+		manager := createManager(t)
+
+		started := make(chan struct{})
+		release := make(chan struct{})
+		defer close(release)
+		manager.ScheduleRefresh("slow", time.Minute, func() (interface{}, error) {
+			close(started)
+			<-release
+			return "slow", nil
+		}, time.Minute)
+		<-started
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		begin := time.Now()
+		err := manager.Stop(ctx)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+		assert.Less(t, time.Since(begin), time.Second)
+	})
+}
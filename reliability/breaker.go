@@ -41,6 +41,12 @@ type ThresholdBreaker struct {
 	failureThreshold int
 	resetTimeout     time.Duration
 	lastFailureTime  time.Time
+
+	// openTimeout is how long the breaker currently stays open. It starts at
+	// resetTimeout and grows with each failed probe when backoff is set.
+	openTimeout       time.Duration
+	backoffMultiplier float64
+	maxOpenTimeout    time.Duration
 }
 
 // NewThresholdBreaker creates a new ThresholdBreaker.
@@ -49,16 +55,36 @@ func NewThresholdBreaker(threshold int, timeout time.Duration) *ThresholdBreaker
 		state:            StateClosed,
 		failureThreshold: threshold,
 		resetTimeout:     timeout,
+		openTimeout:      timeout,
 	}
 }
 
+// SetProbeBackoff makes every failed half-open probe multiply the time the
+// breaker stays open by multiplier, up to maxTimeout (0 means no cap), so a
+// flaky backend is probed less and less often. A successful probe restores
+// the configured timeout. A multiplier of 1 or less disables backoff, which
+// is the default.
+func (b *ThresholdBreaker) SetProbeBackoff(multiplier float64, maxTimeout time.Duration) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.backoffMultiplier = multiplier
+	b.maxOpenTimeout = maxTimeout
+}
+
+// OpenTimeout returns how long the breaker stays open before its next probe.
+func (b *ThresholdBreaker) OpenTimeout() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.openTimeout
+}
+
 // Allow checks if the request is allowed.
 func (b *ThresholdBreaker) Allow() bool {
 	b.mu.Lock()
 	defer b.mu.Unlock()
 
 	if b.state == StateOpen {
-		if time.Since(b.lastFailureTime) > b.resetTimeout {
+		if time.Since(b.lastFailureTime) > b.openTimeout {
 			b.state = StateHalfOpen
 			return true
 		}
@@ -76,6 +102,7 @@ func (b *ThresholdBreaker) Success() {
 	if b.state == StateHalfOpen {
 		b.state = StateClosed
 		b.failures = 0
+		b.openTimeout = b.resetTimeout
 	} else if b.state == StateClosed {
 		b.failures = 0
 	}
@@ -95,5 +122,127 @@ func (b *ThresholdBreaker) Failure() {
 	} else if b.state == StateHalfOpen {
 		b.state = StateOpen
 		b.lastFailureTime = time.Now()
+		b.backOff()
+	}
+}
+
+// backOff lengthens openTimeout after a failed probe.
+func (b *ThresholdBreaker) backOff() {
+	if b.backoffMultiplier <= 1 {
+		return
+	}
+	b.openTimeout = time.Duration(float64(b.openTimeout) * b.backoffMultiplier)
+	if b.maxOpenTimeout > 0 && b.openTimeout > b.maxOpenTimeout {
+		b.openTimeout = b.maxOpenTimeout
+	}
+}
+
+// RollingBreaker trips when the failure rate over a sliding window of recent
+// requests reaches a threshold. Unlike ThresholdBreaker, occasional failures
+// mixed in with successes do not add up to trip it.
+type RollingBreaker struct {
+	mu sync.Mutex
+
+	state           State
+	outcomes        []bool // ring buffer; true marks a failure
+	next            int
+	count           int
+	failures        int
+	failureRate     float64
+	minRequests     int
+	resetTimeout    time.Duration
+	lastFailureTime time.Time
+}
+
+// NewRollingBreaker creates a RollingBreaker that tracks the last windowSize
+// requests and opens once at least minRequests have been seen and the share
+// of failures among them reaches failureRate (0 to 1).
+func NewRollingBreaker(windowSize int, failureRate float64, minRequests int, timeout time.Duration) *RollingBreaker {
+	if windowSize < 1 {
+		windowSize = 1
+	}
+	return &RollingBreaker{
+		state:        StateClosed,
+		outcomes:     make([]bool, windowSize),
+		failureRate:  failureRate,
+		minRequests:  minRequests,
+		resetTimeout: timeout,
+	}
+}
+
+// Allow checks if the request is allowed.
+func (b *RollingBreaker) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == StateOpen {
+		if time.Since(b.lastFailureTime) > b.resetTimeout {
+			b.state = StateHalfOpen
+			return true
+		}
+		return false
+	}
+
+	return true
+}
+
+// Success reports a success.
+func (b *RollingBreaker) Success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateHalfOpen:
+		b.state = StateClosed
+		b.reset()
+	case StateClosed:
+		b.record(false)
+	}
+}
+
+// Failure reports a failure.
+func (b *RollingBreaker) Failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case StateHalfOpen:
+		b.trip()
+	case StateClosed:
+		b.record(true)
+		if b.count >= b.minRequests && float64(b.failures) >= b.failureRate*float64(b.count) {
+			b.trip()
+		}
+	}
+}
+
+// record adds an outcome to the window, evicting the oldest once it is full.
+func (b *RollingBreaker) record(failed bool) {
+	if b.count == len(b.outcomes) {
+		if b.outcomes[b.next] {
+			b.failures--
+		}
+	} else {
+		b.count++
+	}
+	b.outcomes[b.next] = failed
+	if failed {
+		b.failures++
+	}
+	b.next = (b.next + 1) % len(b.outcomes)
+}
+
+// trip opens the breaker and starts a fresh window for when it closes again.
+func (b *RollingBreaker) trip() {
+	b.state = StateOpen
+	b.lastFailureTime = time.Now()
+	b.reset()
+}
+
+// reset clears the window.
+func (b *RollingBreaker) reset() {
+	for i := range b.outcomes {
+		b.outcomes[i] = false
 	}
+	b.next, b.count, b.failures = 0, 0, 0
 }
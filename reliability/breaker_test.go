@@ -3,9 +3,11 @@ package reliability
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
+	dgcache "github.com/donnigundala/dg-cache"
 	"github.com/donnigundala/dg-core/contracts/cache"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -94,6 +96,112 @@ func TestThresholdBreaker(t *testing.T) {
 	assert.True(t, breaker.Allow())
 }
 
+func TestThresholdBreaker_ProbeBackoff(t *testing.T) {
+	breaker := NewThresholdBreaker(1, 20*time.Millisecond)
+	breaker.SetProbeBackoff(2, 70*time.Millisecond)
+
+	// failProbe waits out the open period and fails the probe, returning
+	// a time no later than the failure
+	failProbe := func() time.Time {
+		assert.Eventually(t, breaker.Allow, time.Second, time.Millisecond)
+		failedAt := time.Now()
+		breaker.Failure()
+		return failedAt
+	}
+
+	breaker.Failure()
+	assert.False(t, breaker.Allow())
+	assert.Equal(t, 20*time.Millisecond, breaker.OpenTimeout())
+
+	// Each failed probe doubles the open period, up to the cap
+	failedAt := failProbe()
+	assert.Equal(t, 40*time.Millisecond, breaker.OpenTimeout())
+	probedAt := failProbe()
+	assert.GreaterOrEqual(t, probedAt.Sub(failedAt), 40*time.Millisecond)
+	assert.Equal(t, 70*time.Millisecond, breaker.OpenTimeout())
+	failedAt = probedAt
+	probedAt = failProbe()
+	assert.GreaterOrEqual(t, probedAt.Sub(failedAt), 70*time.Millisecond)
+	assert.Equal(t, 70*time.Millisecond, breaker.OpenTimeout())
+
+	// A successful probe restores the configured timeout
+	assert.Eventually(t, breaker.Allow, time.Second, time.Millisecond)
+	breaker.Success()
+	assert.Equal(t, 20*time.Millisecond, breaker.OpenTimeout())
+
+	breaker.Failure()
+	assert.False(t, breaker.Allow())
+	time.Sleep(30 * time.Millisecond)
+	assert.True(t, breaker.Allow())
+}
+
+func TestRollingBreaker(t *testing.T) {
+	t.Run("mixed outcomes below the rate stay closed", func(t *testing.T) {
+		breaker := NewRollingBreaker(10, 0.5, 4, 100*time.Millisecond)
+
+		// Many failures in total, but never half of the window
+		for i := 0; i < 30; i++ {
+			breaker.Success()
+			breaker.Success()
+			breaker.Failure()
+			assert.True(t, breaker.Allow())
+		}
+	})
+
+	t.Run("trips once the rate is reached", func(t *testing.T) {
+		breaker := NewRollingBreaker(10, 0.5, 4, 100*time.Millisecond)
+
+		for i := 0; i < 10; i++ {
+			breaker.Success()
+		}
+		// 4 failures out of the last 10 is below 50%
+		for i := 0; i < 4; i++ {
+			breaker.Failure()
+		}
+		assert.True(t, breaker.Allow())
+
+		// The 5th pushes the window to 5/10
+		breaker.Failure()
+		assert.False(t, breaker.Allow())
+
+		// Half-open after the timeout, and a success closes it
+		time.Sleep(150 * time.Millisecond)
+		assert.True(t, breaker.Allow())
+		breaker.Success()
+		assert.True(t, breaker.Allow())
+
+		// The window starts fresh after closing
+		breaker.Failure()
+		assert.True(t, breaker.Allow())
+	})
+
+	t.Run("waits for the minimum volume", func(t *testing.T) {
+		breaker := NewRollingBreaker(10, 0.5, 4, time.Second)
+
+		// 100% failures, but only 3 requests
+		breaker.Failure()
+		breaker.Failure()
+		breaker.Failure()
+		assert.True(t, breaker.Allow())
+
+		breaker.Failure()
+		assert.False(t, breaker.Allow())
+	})
+
+	t.Run("half-open failure reopens", func(t *testing.T) {
+		breaker := NewRollingBreaker(4, 0.5, 2, 50*time.Millisecond)
+
+		breaker.Failure()
+		breaker.Failure()
+		assert.False(t, breaker.Allow())
+
+		time.Sleep(80 * time.Millisecond)
+		assert.True(t, breaker.Allow())
+		breaker.Failure()
+		assert.False(t, breaker.Allow())
+	})
+}
+
 func TestCircuitBreakerDriver(t *testing.T) {
 	mockDriver := new(MockDriver)
 	breaker := NewThresholdBreaker(1, 1*time.Second)
@@ -116,3 +224,82 @@ func TestCircuitBreakerDriver(t *testing.T) {
 	_, err = driver.Get(ctx, "key3")
 	assert.Equal(t, ErrCircuitOpen, err)
 }
+
+func TestCircuitBreakerDriver_CallerContext(t *testing.T) {
+	mockDriver := new(MockDriver)
+	driver := NewCircuitBreakerDriver(mockDriver, NewThresholdBreaker(1, time.Minute))
+
+	// A caller that gave up is not a backend failure
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	mockDriver.On("Get", cancelled, "key").Return(nil, context.Canceled)
+	_, err := driver.Get(cancelled, "key")
+	assert.ErrorIs(t, err, context.Canceled)
+
+	expired, cancelExpired := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancelExpired()
+	mockDriver.On("Get", expired, "key").Return(nil, context.DeadlineExceeded)
+	_, err = driver.Get(expired, "key")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	ctx := context.Background()
+	mockDriver.On("Get", ctx, "key").Return("value", nil).Once()
+	_, err = driver.Get(ctx, "key")
+	assert.NoError(t, err)
+
+	// A timeout while the caller's context is live is the backend's fault,
+	// like any other error
+	mockDriver.On("Get", ctx, "slow").Return(nil, context.DeadlineExceeded)
+	_, err = driver.Get(ctx, "slow")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	_, err = driver.Get(ctx, "key")
+	assert.Equal(t, ErrCircuitOpen, err)
+
+	driver = NewCircuitBreakerDriver(mockDriver, NewThresholdBreaker(1, time.Minute))
+	mockDriver.On("Get", ctx, "broken").Return(nil, errors.New("connection refused"))
+	_, err = driver.Get(ctx, "broken")
+	assert.Error(t, err)
+	_, err = driver.Get(ctx, "key")
+	assert.Equal(t, ErrCircuitOpen, err)
+}
+
+func TestCircuitBreakerDriver_ValidationErrors(t *testing.T) {
+	mockDriver := new(MockDriver)
+	driver := NewCircuitBreakerDriver(mockDriver, NewThresholdBreaker(1, time.Minute))
+	ctx := context.Background()
+
+	// Errors about the request itself never open the circuit
+	for _, reqErr := range []error{
+		fmt.Errorf("%w: %q cannot be serialized with json", dgcache.ErrInvalidValue, "chan"),
+		dgcache.ErrTTLOutOfRange,
+		dgcache.ErrReadOnly,
+		dgcache.ErrNotANumber,
+		dgcache.ErrOverflow,
+	} {
+		mockDriver.On("Put", ctx, "key", mock.Anything, time.Minute).Return(reqErr).Twice()
+		for range 2 {
+			err := driver.Put(ctx, "key", "value", time.Minute)
+			assert.ErrorIs(t, err, reqErr)
+		}
+	}
+	mockDriver.On("Get", ctx, "key").Return("value", nil)
+	val, err := driver.Get(ctx, "key")
+	assert.NoError(t, err)
+	assert.Equal(t, "value", val)
+}
+
+func TestCircuitBreakerMiddleware(t *testing.T) {
+	cfg := dgcache.DefaultConfig().WithStore("mock", dgcache.StoreConfig{
+		Driver:     "mock",
+		Middleware: []string{"circuit_breaker"},
+	})
+	manager, err := dgcache.NewManager(cfg)
+	assert.NoError(t, err)
+	manager.RegisterDriver("mock", func(config dgcache.StoreConfig) (cache.Driver, error) {
+		return new(MockDriver), nil
+	})
+
+	store, err := manager.Store("mock")
+	assert.NoError(t, err)
+	assert.IsType(t, &CircuitBreakerDriver{}, store)
+}
@@ -2,12 +2,19 @@ package reliability
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	dgcache "github.com/donnigundala/dg-cache"
 	"github.com/donnigundala/dg-core/contracts/cache"
 )
 
+func init() {
+	dgcache.RegisterMiddleware("circuit_breaker", func(driver cache.Driver) cache.Driver {
+		return NewCircuitBreakerDriver(driver, NewThresholdBreaker(5, 1*time.Minute))
+	})
+}
+
 // CircuitBreakerDriver wraps a cache driver with a circuit breaker.
 type CircuitBreakerDriver struct {
 	cache.Driver
@@ -28,7 +35,7 @@ func (d *CircuitBreakerDriver) Get(ctx context.Context, key string) (interface{}
 		return nil, ErrCircuitOpen
 	}
 	val, err := d.Driver.Get(ctx, key)
-	d.report(err)
+	d.report(ctx, err)
 	return val, err
 }
 
@@ -37,7 +44,7 @@ func (d *CircuitBreakerDriver) Put(ctx context.Context, key string, value interf
 		return ErrCircuitOpen
 	}
 	err := d.Driver.Put(ctx, key, value, ttl)
-	d.report(err)
+	d.report(ctx, err)
 	return err
 }
 
@@ -46,7 +53,7 @@ func (d *CircuitBreakerDriver) Forget(ctx context.Context, key string) error {
 		return ErrCircuitOpen
 	}
 	err := d.Driver.Forget(ctx, key)
-	d.report(err)
+	d.report(ctx, err)
 	return err
 }
 
@@ -55,15 +62,397 @@ func (d *CircuitBreakerDriver) Flush(ctx context.Context) error {
 		return ErrCircuitOpen
 	}
 	err := d.Driver.Flush(ctx)
-	d.report(err)
+	d.report(ctx, err)
 	return err
 }
 
-// report updates the breaker state based on the error.
-func (d *CircuitBreakerDriver) report(err error) {
-	if err != nil && err != dgcache.ErrKeyNotFound {
-		d.breaker.Failure()
-	} else {
+// Unwrap returns the wrapped driver.
+func (d *CircuitBreakerDriver) Unwrap() cache.Driver {
+	return d.Driver
+}
+
+// Ping forwards to the wrapped driver if it supports health checks.
+// Pings bypass the breaker so health checks always reach the backend.
+func (d *CircuitBreakerDriver) Ping(ctx context.Context) error {
+	if pinger, ok := d.Driver.(dgcache.Pinger); ok {
+		return pinger.Ping(ctx)
+	}
+	return nil
+}
+
+// Info forwards to the wrapped driver if it supports introspection.
+func (d *CircuitBreakerDriver) Info() dgcache.DriverInfo {
+	if introspectable, ok := d.Driver.(dgcache.Introspectable); ok {
+		return introspectable.Info()
+	}
+	return dgcache.DriverInfo{Driver: d.Name(), Prefix: d.GetPrefix()}
+}
+
+// SerializationErrors forwards to the wrapped driver, or returns 0 if it does
+// not count serialization errors.
+func (d *CircuitBreakerDriver) SerializationErrors() int64 {
+	if counter, ok := d.Driver.(dgcache.SerializationErrorCounter); ok {
+		return counter.SerializationErrors()
+	}
+	return 0
+}
+
+// PoolUsage forwards to the wrapped driver, or returns zero stats if it has
+// no connection pool.
+func (d *CircuitBreakerDriver) PoolUsage() dgcache.PoolStats {
+	if reporter, ok := d.Driver.(dgcache.PoolReporter); ok {
+		return reporter.PoolUsage()
+	}
+	return dgcache.PoolStats{}
+}
+
+// Transaction forwards to the wrapped driver if it supports transactions.
+func (d *CircuitBreakerDriver) Transaction(ctx context.Context, fn func(tx cache.Store) error) error {
+	transactional, ok := d.Driver.(dgcache.Transactional)
+	if !ok {
+		return dgcache.ErrNotSupported
+	}
+	if !d.breaker.Allow() {
+		return ErrCircuitOpen
+	}
+	err := transactional.Transaction(ctx, fn)
+	d.report(ctx, err)
+	return err
+}
+
+// Normalize forwards to the wrapped driver if it supports normalization.
+func (d *CircuitBreakerDriver) Normalize(value interface{}) (interface{}, error) {
+	if normalizer, ok := d.Driver.(dgcache.Normalizer); ok {
+		return normalizer.Normalize(value)
+	}
+	return value, nil
+}
+
+// KeysForTag forwards to the wrapped driver if it supports tag introspection.
+func (d *CircuitBreakerDriver) KeysForTag(ctx context.Context, tag string) ([]string, error) {
+	introspectable, ok := d.Driver.(dgcache.TagIntrospectable)
+	if !ok {
+		return nil, dgcache.ErrNotSupported
+	}
+	if !d.breaker.Allow() {
+		return nil, ErrCircuitOpen
+	}
+	keys, err := introspectable.KeysForTag(ctx, tag)
+	d.report(ctx, err)
+	return keys, err
+}
+
+// FlushTags forwards to the wrapped driver if it supports tag introspection.
+func (d *CircuitBreakerDriver) FlushTags(ctx context.Context, tags ...string) error {
+	introspectable, ok := d.Driver.(dgcache.TagIntrospectable)
+	if !ok {
+		return dgcache.ErrNotSupported
+	}
+	if !d.breaker.Allow() {
+		return ErrCircuitOpen
+	}
+	err := introspectable.FlushTags(ctx, tags...)
+	d.report(ctx, err)
+	return err
+}
+
+// GetWithMeta forwards to the wrapped driver if it supports reading metadata.
+func (d *CircuitBreakerDriver) GetWithMeta(ctx context.Context, key string) (interface{}, dgcache.ItemMeta, error) {
+	reader, ok := d.Driver.(dgcache.MetaReader)
+	if !ok {
+		return nil, dgcache.ItemMeta{}, dgcache.ErrNotSupported
+	}
+	if !d.breaker.Allow() {
+		return nil, dgcache.ItemMeta{}, ErrCircuitOpen
+	}
+	value, meta, err := reader.GetWithMeta(ctx, key)
+	d.report(ctx, err)
+	return value, meta, err
+}
+
+// GetItem forwards to the wrapped driver if it can return whole entries.
+func (d *CircuitBreakerDriver) GetItem(ctx context.Context, key string) (*dgcache.Item, error) {
+	itemStore, ok := d.Driver.(dgcache.ItemStore)
+	if !ok {
+		return nil, dgcache.ErrNotSupported
+	}
+	if !d.breaker.Allow() {
+		return nil, ErrCircuitOpen
+	}
+	item, err := itemStore.GetItem(ctx, key)
+	d.report(ctx, err)
+	return item, err
+}
+
+// GetField forwards to the wrapped driver if it can read single fields.
+func (d *CircuitBreakerDriver) GetField(ctx context.Context, key, path string) (interface{}, error) {
+	reader, ok := d.Driver.(dgcache.FieldReader)
+	if !ok {
+		return nil, dgcache.ErrNotSupported
+	}
+	if !d.breaker.Allow() {
+		return nil, ErrCircuitOpen
+	}
+	value, err := reader.GetField(ctx, key, path)
+	d.report(ctx, err)
+	return value, err
+}
+
+// FlushTagKeysOnly forwards to the wrapped driver if it supports flushing tag keys only.
+func (d *CircuitBreakerDriver) FlushTagKeysOnly(ctx context.Context, tags ...string) error {
+	flusher, ok := d.Driver.(dgcache.TagKeysFlusher)
+	if !ok {
+		return dgcache.ErrNotSupported
+	}
+	if !d.breaker.Allow() {
+		return ErrCircuitOpen
+	}
+	err := flusher.FlushTagKeysOnly(ctx, tags...)
+	d.report(ctx, err)
+	return err
+}
+
+// TagExisting forwards to the wrapped driver if it supports editing tags.
+func (d *CircuitBreakerDriver) TagExisting(ctx context.Context, tag string, keys ...string) error {
+	editor, ok := d.Driver.(dgcache.TagEditor)
+	if !ok {
+		return dgcache.ErrNotSupported
+	}
+	if !d.breaker.Allow() {
+		return ErrCircuitOpen
+	}
+	err := editor.TagExisting(ctx, tag, keys...)
+	d.report(ctx, err)
+	return err
+}
+
+// Untag forwards to the wrapped driver if it supports editing tags.
+func (d *CircuitBreakerDriver) Untag(ctx context.Context, key string, tags ...string) error {
+	editor, ok := d.Driver.(dgcache.TagEditor)
+	if !ok {
+		return dgcache.ErrNotSupported
+	}
+	if !d.breaker.Allow() {
+		return ErrCircuitOpen
+	}
+	err := editor.Untag(ctx, key, tags...)
+	d.report(ctx, err)
+	return err
+}
+
+// Export forwards to the wrapped driver if it supports exporting.
+func (d *CircuitBreakerDriver) Export(ctx context.Context, fn func(item dgcache.Item) error) error {
+	exporter, ok := d.Driver.(dgcache.Exporter)
+	if !ok {
+		return dgcache.ErrNotSupported
+	}
+	if !d.breaker.Allow() {
+		return ErrCircuitOpen
+	}
+	err := exporter.Export(ctx, fn)
+	d.report(ctx, err)
+	return err
+}
+
+// IncrementWithTTL forwards to the wrapped driver if it supports it.
+func (d *CircuitBreakerDriver) IncrementWithTTL(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	incrementer, ok := d.Driver.(dgcache.TTLIncrementer)
+	if !ok {
+		return 0, dgcache.ErrNotSupported
+	}
+	if !d.breaker.Allow() {
+		return 0, ErrCircuitOpen
+	}
+	n, err := incrementer.IncrementWithTTL(ctx, key, delta, ttl)
+	d.report(ctx, err)
+	return n, err
+}
+
+// SwapAll forwards to the wrapped driver if it supports atomic swaps.
+func (d *CircuitBreakerDriver) SwapAll(ctx context.Context, items map[string]interface{}, ttl time.Duration) error {
+	swapper, ok := d.Driver.(dgcache.Swapper)
+	if !ok {
+		return dgcache.ErrNotSupported
+	}
+	if !d.breaker.Allow() {
+		return ErrCircuitOpen
+	}
+	err := swapper.SwapAll(ctx, items, ttl)
+	d.report(ctx, err)
+	return err
+}
+
+// GetMultipleWithTTL forwards to the wrapped driver if it can report TTLs.
+func (d *CircuitBreakerDriver) GetMultipleWithTTL(ctx context.Context, keys []string) (map[string]dgcache.ValueTTL, error) {
+	reader, ok := d.Driver.(dgcache.TTLReader)
+	if !ok {
+		return nil, dgcache.ErrNotSupported
+	}
+	if !d.breaker.Allow() {
+		return nil, ErrCircuitOpen
+	}
+	values, err := reader.GetMultipleWithTTL(ctx, keys)
+	d.report(ctx, err)
+	return values, err
+}
+
+// HasMultiple forwards to the wrapped driver if it can check several keys at once.
+func (d *CircuitBreakerDriver) HasMultiple(ctx context.Context, keys []string) (map[string]bool, error) {
+	checker, ok := d.Driver.(dgcache.MultiChecker)
+	if !ok {
+		return nil, dgcache.ErrNotSupported
+	}
+	if !d.breaker.Allow() {
+		return nil, ErrCircuitOpen
+	}
+	result, err := checker.HasMultiple(ctx, keys)
+	d.report(ctx, err)
+	return result, err
+}
+
+// Expire forwards to the wrapped driver if it supports setting TTLs.
+func (d *CircuitBreakerDriver) Expire(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	expirer, ok := d.Driver.(dgcache.Expirer)
+	if !ok {
+		return false, dgcache.ErrNotSupported
+	}
+	if !d.breaker.Allow() {
+		return false, ErrCircuitOpen
+	}
+	ok, err := expirer.Expire(ctx, key, ttl)
+	d.report(ctx, err)
+	return ok, err
+}
+
+// ExtendTTL forwards to the wrapped driver if it supports extending TTLs.
+func (d *CircuitBreakerDriver) ExtendTTL(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	extender, ok := d.Driver.(dgcache.TTLExtender)
+	if !ok {
+		return false, dgcache.ErrNotSupported
+	}
+	if !d.breaker.Allow() {
+		return false, ErrCircuitOpen
+	}
+	extended, err := extender.ExtendTTL(ctx, key, ttl)
+	d.report(ctx, err)
+	return extended, err
+}
+
+// FlushExcept forwards to the wrapped driver if it supports selective flushes.
+func (d *CircuitBreakerDriver) FlushExcept(ctx context.Context, patterns ...string) error {
+	flusher, ok := d.Driver.(dgcache.SelectiveFlusher)
+	if !ok {
+		return dgcache.ErrNotSupported
+	}
+	if !d.breaker.Allow() {
+		return ErrCircuitOpen
+	}
+	err := flusher.FlushExcept(ctx, patterns...)
+	d.report(ctx, err)
+	return err
+}
+
+// Rename forwards to the wrapped driver if it supports renaming keys.
+func (d *CircuitBreakerDriver) Rename(ctx context.Context, oldKey, newKey string) error {
+	renamer, ok := d.Driver.(dgcache.Renamer)
+	if !ok {
+		return dgcache.ErrNotSupported
+	}
+	if !d.breaker.Allow() {
+		return ErrCircuitOpen
+	}
+	err := renamer.Rename(ctx, oldKey, newKey)
+	d.report(ctx, err)
+	return err
+}
+
+// HGet forwards to the wrapped driver if it supports hashes.
+func (d *CircuitBreakerDriver) HGet(ctx context.Context, key, field string) (interface{}, error) {
+	hashes, ok := d.Driver.(dgcache.HashStore)
+	if !ok {
+		return nil, dgcache.ErrNotSupported
+	}
+	if !d.breaker.Allow() {
+		return nil, ErrCircuitOpen
+	}
+	value, err := hashes.HGet(ctx, key, field)
+	d.report(ctx, err)
+	return value, err
+}
+
+// HSet forwards to the wrapped driver if it supports hashes.
+func (d *CircuitBreakerDriver) HSet(ctx context.Context, key, field string, value interface{}) error {
+	hashes, ok := d.Driver.(dgcache.HashStore)
+	if !ok {
+		return dgcache.ErrNotSupported
+	}
+	if !d.breaker.Allow() {
+		return ErrCircuitOpen
+	}
+	err := hashes.HSet(ctx, key, field, value)
+	d.report(ctx, err)
+	return err
+}
+
+// HGetAll forwards to the wrapped driver if it supports hashes.
+func (d *CircuitBreakerDriver) HGetAll(ctx context.Context, key string) (map[string]interface{}, error) {
+	hashes, ok := d.Driver.(dgcache.HashStore)
+	if !ok {
+		return nil, dgcache.ErrNotSupported
+	}
+	if !d.breaker.Allow() {
+		return nil, ErrCircuitOpen
+	}
+	fields, err := hashes.HGetAll(ctx, key)
+	d.report(ctx, err)
+	return fields, err
+}
+
+// HDel forwards to the wrapped driver if it supports hashes.
+func (d *CircuitBreakerDriver) HDel(ctx context.Context, key string, fields ...string) error {
+	hashes, ok := d.Driver.(dgcache.HashStore)
+	if !ok {
+		return dgcache.ErrNotSupported
+	}
+	if !d.breaker.Allow() {
+		return ErrCircuitOpen
+	}
+	err := hashes.HDel(ctx, key, fields...)
+	d.report(ctx, err)
+	return err
+}
+
+// report updates the breaker state based on the error. Errors that
+// isFailure does not count, such as a miss, are successes. An error caused by
+// the caller's own context, such as a client that disconnected, says nothing
+// about the backend and is not reported at all; a timeout the backend hits
+// while the caller's context is still live is a failure.
+func (d *CircuitBreakerDriver) report(ctx context.Context, err error) {
+	switch {
+	case !isFailure(err):
 		d.breaker.Success()
+	case ctx.Err() != nil && (errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)):
+	default:
+		d.breaker.Failure()
+	}
+}
+
+// isFailure reports whether err says the backend is unhealthy. Misses and
+// errors about the request itself, such as a value that cannot be serialized,
+// a TTL outside the store's limits, or a write to a read-only store, prove
+// the backend answered and do not count.
+func isFailure(err error) bool {
+	switch {
+	case err == nil,
+		errors.Is(err, dgcache.ErrKeyNotFound),
+		errors.Is(err, dgcache.ErrFieldNotFound),
+		errors.Is(err, dgcache.ErrInvalidValue),
+		errors.Is(err, dgcache.ErrTTLOutOfRange),
+		errors.Is(err, dgcache.ErrReadOnly),
+		errors.Is(err, dgcache.ErrNotANumber),
+		errors.Is(err, dgcache.ErrOverflow),
+		errors.Is(err, dgcache.ErrWrongType):
+		return false
 	}
+	return true
 }
@@ -0,0 +1,142 @@
+package dgcache
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/donnigundala/dg-core/contracts/cache"
+)
+
+// requestCacheKey is the context key carrying a request cache.
+type requestCacheKey struct{}
+
+// requestCache memoizes Manager reads for the lifetime of one request.
+type requestCache struct {
+	mu      sync.Mutex
+	entries map[requestEntryKey]*requestEntry
+}
+
+// requestEntryKey identifies a key in a store.
+type requestEntryKey struct {
+	store string
+	key   string
+}
+
+// requestEntry is the result of a read, available once done is closed.
+type requestEntry struct {
+	done  chan struct{}
+	value interface{}
+	err   error
+}
+
+// WithRequestCache returns a context whose Manager reads are memoized for the
+// lifetime of the context, typically one request. Get and GetIn read each key
+// from the store once; later and concurrent reads of the same key share that
+// result, misses included. Writes made through the Manager with the context
+// drop the keys they touch, so the next read sees the new value.
+//
+// Writes made by other processes, through a store directly or through a
+// TaggedStore are not seen until the context is discarded. Memoized values are
+// shared by every reader and must not be modified.
+//
+// Calling WithRequestCache on a context that already has a request cache
+// returns it unchanged.
+func WithRequestCache(ctx context.Context) context.Context {
+	if requestCacheFrom(ctx) != nil {
+		return ctx
+	}
+	return context.WithValue(ctx, requestCacheKey{}, &requestCache{
+		entries: make(map[requestEntryKey]*requestEntry),
+	})
+}
+
+// requestCacheFrom returns the request cache of ctx, or nil if it has none.
+func requestCacheFrom(ctx context.Context) *requestCache {
+	c, _ := ctx.Value(requestCacheKey{}).(*requestCache)
+	return c
+}
+
+// get returns the memoized result for key in store, calling load on the first
+// read. Concurrent reads wait for the first one. Errors other than a miss are
+// returned to the waiting readers but not kept, so the next read retries.
+func (c *requestCache) get(store, key string, load func() (interface{}, error)) (interface{}, error) {
+	k := requestEntryKey{store: store, key: key}
+
+	c.mu.Lock()
+	if e, ok := c.entries[k]; ok {
+		c.mu.Unlock()
+		<-e.done
+		return e.value, e.err
+	}
+	e := &requestEntry{done: make(chan struct{})}
+	c.entries[k] = e
+	c.mu.Unlock()
+
+	e.value, e.err = load()
+	close(e.done)
+
+	if e.err != nil && !errors.Is(e.err, ErrKeyNotFound) {
+		c.mu.Lock()
+		if c.entries[k] == e {
+			delete(c.entries, k)
+		}
+		c.mu.Unlock()
+	}
+	return e.value, e.err
+}
+
+// forget drops keys of store.
+func (c *requestCache) forget(store string, keys ...string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, key := range keys {
+		delete(c.entries, requestEntryKey{store: store, key: key})
+	}
+}
+
+// flush drops every key of store.
+func (c *requestCache) flush(store string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for k := range c.entries {
+		if k.store == store {
+			delete(c.entries, k)
+		}
+	}
+}
+
+// getRequestCached reads key from the named store through the request cache
+// of ctx, if it has one. Bypassed reads are never memoized.
+func getRequestCached(ctx context.Context, name string, store cache.Store, key string) (interface{}, error) {
+	c := requestCacheFrom(ctx)
+	if c == nil || isBypassed(ctx) {
+		return getOrBypass(ctx, store, key)
+	}
+	return c.get(name, key, func() (interface{}, error) {
+		return store.Get(ctx, key)
+	})
+}
+
+// forgetRequestCached drops keys of the named store from the request cache of ctx.
+func forgetRequestCached(ctx context.Context, name string, keys ...string) {
+	if c := requestCacheFrom(ctx); c != nil {
+		c.forget(name, keys...)
+	}
+}
+
+// forgetRequestCachedItems drops the keys of items from the request cache of ctx.
+func forgetRequestCachedItems(ctx context.Context, name string, items map[string]interface{}) {
+	if c := requestCacheFrom(ctx); c != nil {
+		for key := range items {
+			c.forget(name, key)
+		}
+	}
+}
+
+// flushRequestCached drops every key of the named store from the request cache of ctx.
+func flushRequestCached(ctx context.Context, name string) {
+	if c := requestCacheFrom(ctx); c != nil {
+		c.flush(name)
+	}
+}
@@ -0,0 +1,64 @@
+package dgcache_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dgcache "github.com/donnigundala/dg-cache"
+	"github.com/donnigundala/dg-cache/drivers/memory"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_WithRequestCache(t *testing.T) {
+	manager, err := dgcache.NewManager(dgcache.DefaultConfig().WithMetrics(true))
+	require.NoError(t, err)
+	manager.RegisterDriver("memory", memory.NewDriver)
+	defer manager.Close()
+
+	background := context.Background()
+	require.NoError(t, manager.Put(background, "user:1", "john", time.Minute))
+	reads := func() int64 {
+		stats := manager.Stats()
+		return stats.Hits + stats.Misses
+	}
+
+	ctx := dgcache.WithRequestCache(background)
+	assert.Equal(t, ctx, dgcache.WithRequestCache(ctx))
+
+	for i := 0; i < 3; i++ {
+		value, err := manager.Get(ctx, "user:1")
+		require.NoError(t, err)
+		assert.Equal(t, "john", value)
+	}
+	assert.Equal(t, int64(1), reads())
+
+	// Misses are memoized too
+	for i := 0; i < 2; i++ {
+		_, err := manager.Get(ctx, "user:2")
+		assert.ErrorIs(t, err, dgcache.ErrKeyNotFound)
+	}
+	assert.Equal(t, int64(2), reads())
+
+	// A write through the manager drops the memoized key
+	require.NoError(t, manager.Put(ctx, "user:2", "jane", time.Minute))
+	value, err := manager.Get(ctx, "user:2")
+	require.NoError(t, err)
+	assert.Equal(t, "jane", value)
+	assert.Equal(t, int64(3), reads())
+
+	require.NoError(t, manager.Forget(ctx, "user:1"))
+	_, err = manager.Get(ctx, "user:1")
+	assert.ErrorIs(t, err, dgcache.ErrKeyNotFound)
+
+	// Other contexts read the store every time
+	before := reads()
+	_, _ = manager.Get(background, "user:2")
+	_, _ = manager.Get(background, "user:2")
+	assert.Equal(t, before+2, reads())
+
+	// A bypassed read is never memoized
+	_, err = manager.Get(dgcache.WithBypass(ctx), "user:2")
+	assert.ErrorIs(t, err, dgcache.ErrKeyNotFound)
+}
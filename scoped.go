@@ -0,0 +1,388 @@
+package dgcache
+
+import (
+	"context"
+	"time"
+
+	"github.com/donnigundala/dg-core/contracts/cache"
+)
+
+// Option configures a scoped cache view created by Manager.With.
+type Option func(*scopedCache)
+
+// Store makes the view use the named store instead of the default store.
+func Store(name string) Option {
+	return func(s *scopedCache) {
+		s.store = name
+	}
+}
+
+// Prefix namespaces every key of the view as "prefix:key".
+func Prefix(prefix string) Option {
+	return func(s *scopedCache) {
+		s.prefix = prefix
+	}
+}
+
+// DefaultTTL is used by the view for writes given a zero TTL.
+// Forever and RememberForever still store without expiration.
+func DefaultTTL(ttl time.Duration) Option {
+	return func(s *scopedCache) {
+		s.ttl = ttl
+	}
+}
+
+// With returns a lightweight view of the Manager that applies a fixed store,
+// key prefix, and default TTL to every operation. The view shares the Manager's
+// stores; it is cheap to create and safe to pass to a subsystem. Keyed
+// operations run through the Manager with the prefixed key, so they share its
+// request cache, invalidation publishing and ErrorTTL caching.
+//
+// Flush on a prefixed view returns ErrNotSupported, since the store can't be
+// flushed without affecting keys outside the prefix; use tags instead.
+func (m *Manager) With(opts ...Option) cache.Cache {
+	s := &scopedCache{m: m}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
+}
+
+// scopedCache is the view returned by Manager.With.
+type scopedCache struct {
+	m      *Manager
+	store  string
+	prefix string
+	ttl    time.Duration
+}
+
+// Verify scopedCache implements Cache interface
+var _ cache.Cache = (*scopedCache)(nil)
+
+// resolve returns the view's store wrapped to apply the prefix and default TTL.
+func (s *scopedCache) resolve() (*scopedStore, error) {
+	store, err := s.m.Store(s.store)
+	if err != nil {
+		return nil, err
+	}
+	return &scopedStore{Store: store, prefix: s.prefix, ttl: s.ttl}, nil
+}
+
+// wrapError adds the view's store, op, and key to err.
+func (s *scopedCache) wrapError(op, key string, err error) error {
+	name := s.store
+	if name == "" {
+		name = s.m.defaultStore
+	}
+	return s.m.wrapStoreError(name, op, key, err)
+}
+
+// key returns the store key for a view key.
+func (s *scopedCache) key(key string) string {
+	if s.prefix == "" {
+		return key
+	}
+	return s.prefix + ":" + key
+}
+
+// ttlOrDefault returns ttl, or the default TTL if ttl is zero.
+func (s *scopedCache) ttlOrDefault(ttl time.Duration) time.Duration {
+	if ttl == 0 {
+		return s.ttl
+	}
+	return ttl
+}
+
+// Get retrieves a value from the view.
+func (s *scopedCache) Get(ctx context.Context, key string) (interface{}, error) {
+	return s.m.GetIn(ctx, s.store, s.key(key))
+}
+
+// GetMultiple retrieves multiple values from the view.
+func (s *scopedCache) GetMultiple(ctx context.Context, keys []string) (map[string]interface{}, error) {
+	store, err := s.resolve()
+	if err != nil {
+		return nil, s.wrapError("get_multiple", "", err)
+	}
+	values, err := store.GetMultiple(ctx, keys)
+	return values, s.wrapError("get_multiple", "", err)
+}
+
+// Put stores a value in the view, using the default TTL if ttl is zero.
+func (s *scopedCache) Put(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	return s.m.PutIn(ctx, s.store, s.key(key), value, s.ttlOrDefault(ttl))
+}
+
+// PutMultiple stores multiple values in the view, using the default TTL if ttl is zero.
+func (s *scopedCache) PutMultiple(ctx context.Context, items map[string]interface{}, ttl time.Duration) error {
+	prefixed := make(map[string]interface{}, len(items))
+	for key, value := range items {
+		prefixed[s.key(key)] = value
+	}
+	return s.m.PutMultipleIn(ctx, s.store, prefixed, s.ttlOrDefault(ttl))
+}
+
+// Increment increments a value in the view.
+func (s *scopedCache) Increment(ctx context.Context, key string, value int64) (int64, error) {
+	return s.m.IncrementIn(ctx, s.store, s.key(key), value)
+}
+
+// Decrement decrements a value in the view.
+func (s *scopedCache) Decrement(ctx context.Context, key string, value int64) (int64, error) {
+	return s.m.DecrementIn(ctx, s.store, s.key(key), value)
+}
+
+// Forever stores a value in the view indefinitely.
+func (s *scopedCache) Forever(ctx context.Context, key string, value interface{}) error {
+	return s.m.ForeverIn(ctx, s.store, s.key(key), value)
+}
+
+// Forget removes a value from the view.
+func (s *scopedCache) Forget(ctx context.Context, key string) error {
+	return s.m.ForgetIn(ctx, s.store, s.key(key))
+}
+
+// ForgetMultiple removes multiple values from the view.
+func (s *scopedCache) ForgetMultiple(ctx context.Context, keys []string) error {
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = s.key(key)
+	}
+	return s.m.ForgetMultipleIn(ctx, s.store, prefixed)
+}
+
+// Flush removes all items from the view's store. It returns ErrNotSupported
+// when the view has a prefix.
+func (s *scopedCache) Flush(ctx context.Context) error {
+	store, err := s.resolve()
+	if err != nil {
+		return s.wrapError("flush", "", err)
+	}
+	return s.wrapError("flush", "", store.Flush(ctx))
+}
+
+// Has checks if a key exists in the view.
+func (s *scopedCache) Has(ctx context.Context, key string) (bool, error) {
+	store, err := s.resolve()
+	if err != nil {
+		return false, s.wrapError("has", key, err)
+	}
+	ok, err := store.Has(ctx, key)
+	return ok, s.wrapError("has", key, err)
+}
+
+// Missing checks if a key does not exist in the view.
+func (s *scopedCache) Missing(ctx context.Context, key string) (bool, error) {
+	store, err := s.resolve()
+	if err != nil {
+		return false, s.wrapError("missing", key, err)
+	}
+	ok, err := store.Missing(ctx, key)
+	return ok, s.wrapError("missing", key, err)
+}
+
+// GetPrefix returns the view's key prefix.
+func (s *scopedCache) GetPrefix() string {
+	return s.prefix
+}
+
+// SetPrefix sets the view's key prefix.
+func (s *scopedCache) SetPrefix(prefix string) {
+	s.prefix = prefix
+}
+
+// Stats returns the statistics of the view's store.
+func (s *scopedCache) Stats() cache.Stats {
+	store, err := s.resolve()
+	if err != nil {
+		return cache.Stats{}
+	}
+	return store.Stats()
+}
+
+// Tags returns a tagged store that applies the view's prefix and default TTL.
+func (s *scopedCache) Tags(tags ...string) cache.TaggedStore {
+	store, err := s.resolve()
+	if err != nil {
+		panic("failed to get scoped store: " + err.Error())
+	}
+	taggable, ok := capability[cache.TaggedStore](store.Store)
+	if !ok {
+		panic("scoped cache store does not support tagging")
+	}
+	return store.tagged(taggable.Tags(tags...))
+}
+
+// Remember retrieves a value from the view or executes the callback and stores
+// the result, using the default TTL if ttl is zero.
+func (s *scopedCache) Remember(ctx context.Context, key string, ttl time.Duration, callback func() (interface{}, error)) (interface{}, error) {
+	return s.m.RememberIn(ctx, s.store, s.key(key), s.ttlOrDefault(ttl), callback)
+}
+
+// RememberForever retrieves a value from the view or executes the callback and stores the result forever.
+func (s *scopedCache) RememberForever(ctx context.Context, key string, callback func() (interface{}, error)) (interface{}, error) {
+	return s.m.RememberForeverIn(ctx, s.store, s.key(key), callback)
+}
+
+// Pull retrieves a value from the view and then deletes it.
+func (s *scopedCache) Pull(ctx context.Context, key string) (interface{}, error) {
+	value, err := s.Get(ctx, key)
+	if err != nil {
+		return nil, err
+	}
+
+	// Delete the key (ignore errors)
+	_ = s.Forget(ctx, key)
+
+	return value, nil
+}
+
+// scopedStore wraps a store to prefix keys and apply a default TTL.
+type scopedStore struct {
+	cache.Store
+	prefix string
+	ttl    time.Duration
+}
+
+// key returns the store key for a view key.
+func (s *scopedStore) key(key string) string {
+	if s.prefix == "" {
+		return key
+	}
+	return s.prefix + ":" + key
+}
+
+// keys returns the store keys for view keys.
+func (s *scopedStore) keys(keys []string) []string {
+	if s.prefix == "" {
+		return keys
+	}
+	prefixed := make([]string, len(keys))
+	for i, key := range keys {
+		prefixed[i] = s.key(key)
+	}
+	return prefixed
+}
+
+// ttlOrDefault returns ttl, or the default TTL if ttl is zero.
+func (s *scopedStore) ttlOrDefault(ttl time.Duration) time.Duration {
+	if ttl == 0 {
+		return s.ttl
+	}
+	return ttl
+}
+
+// tagged wraps a tagged store with the same prefix and default TTL.
+func (s *scopedStore) tagged(store cache.TaggedStore) cache.TaggedStore {
+	return &scopedTaggedStore{
+		scopedStore: &scopedStore{Store: store, prefix: s.prefix, ttl: s.ttl},
+		tagged:      store,
+	}
+}
+
+// Get retrieves the prefixed key.
+func (s *scopedStore) Get(ctx context.Context, key string) (interface{}, error) {
+	return s.Store.Get(ctx, s.key(key))
+}
+
+// GetMultiple retrieves the prefixed keys, returning them without the prefix.
+func (s *scopedStore) GetMultiple(ctx context.Context, keys []string) (map[string]interface{}, error) {
+	values, err := s.Store.GetMultiple(ctx, s.keys(keys))
+	if err != nil || s.prefix == "" {
+		return values, err
+	}
+	result := make(map[string]interface{}, len(values))
+	for _, key := range keys {
+		if value, ok := values[s.key(key)]; ok {
+			result[key] = value
+		}
+	}
+	return result, nil
+}
+
+// Put stores the prefixed key, using the default TTL if ttl is zero.
+func (s *scopedStore) Put(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	return s.Store.Put(ctx, s.key(key), value, s.ttlOrDefault(ttl))
+}
+
+// PutMultiple stores the prefixed keys, using the default TTL if ttl is zero.
+func (s *scopedStore) PutMultiple(ctx context.Context, items map[string]interface{}, ttl time.Duration) error {
+	if s.prefix != "" {
+		prefixed := make(map[string]interface{}, len(items))
+		for key, value := range items {
+			prefixed[s.key(key)] = value
+		}
+		items = prefixed
+	}
+	return s.Store.PutMultiple(ctx, items, s.ttlOrDefault(ttl))
+}
+
+// Increment increments the prefixed key.
+func (s *scopedStore) Increment(ctx context.Context, key string, value int64) (int64, error) {
+	return s.Store.Increment(ctx, s.key(key), value)
+}
+
+// Decrement decrements the prefixed key.
+func (s *scopedStore) Decrement(ctx context.Context, key string, value int64) (int64, error) {
+	return s.Store.Decrement(ctx, s.key(key), value)
+}
+
+// Forever stores the prefixed key indefinitely.
+func (s *scopedStore) Forever(ctx context.Context, key string, value interface{}) error {
+	return s.Store.Forever(ctx, s.key(key), value)
+}
+
+// Forget removes the prefixed key.
+func (s *scopedStore) Forget(ctx context.Context, key string) error {
+	return s.Store.Forget(ctx, s.key(key))
+}
+
+// ForgetMultiple removes the prefixed keys.
+func (s *scopedStore) ForgetMultiple(ctx context.Context, keys []string) error {
+	return s.Store.ForgetMultiple(ctx, s.keys(keys))
+}
+
+// Flush flushes the store, unless a prefix is set: the store can't be flushed
+// without affecting keys outside of it.
+func (s *scopedStore) Flush(ctx context.Context) error {
+	if s.prefix != "" {
+		return ErrNotSupported
+	}
+	return s.Store.Flush(ctx)
+}
+
+// Has checks if the prefixed key exists.
+func (s *scopedStore) Has(ctx context.Context, key string) (bool, error) {
+	return s.Store.Has(ctx, s.key(key))
+}
+
+// Missing checks if the prefixed key does not exist.
+func (s *scopedStore) Missing(ctx context.Context, key string) (bool, error) {
+	return s.Store.Missing(ctx, s.key(key))
+}
+
+// GetPrefix returns the key prefix.
+func (s *scopedStore) GetPrefix() string {
+	return s.prefix
+}
+
+// SetPrefix sets the key prefix.
+func (s *scopedStore) SetPrefix(prefix string) {
+	s.prefix = prefix
+}
+
+// scopedTaggedStore is a tagged store returned by a scoped view's Tags.
+type scopedTaggedStore struct {
+	*scopedStore
+	tagged cache.TaggedStore
+}
+
+// Tags adds more tags, keeping the prefix and default TTL.
+func (s *scopedTaggedStore) Tags(tags ...string) cache.TaggedStore {
+	return s.scopedStore.tagged(s.tagged.Tags(tags...))
+}
+
+// Flush removes the items associated with the tags.
+func (s *scopedTaggedStore) Flush(ctx context.Context) error {
+	return s.tagged.Flush(ctx)
+}
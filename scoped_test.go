@@ -0,0 +1,122 @@
+package dgcache_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	dgcache "github.com/donnigundala/dg-cache"
+	"github.com/donnigundala/dg-cache/drivers/memory"
+	contracts "github.com/donnigundala/dg-core/contracts/cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ttlRecordingDriver records the TTL of every Put by key.
+type ttlRecordingDriver struct {
+	contracts.Driver
+	ttls map[string]time.Duration
+}
+
+func (d *ttlRecordingDriver) Put(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	d.ttls[key] = ttl
+	return d.Driver.Put(ctx, key, value, ttl)
+}
+
+func TestManager_With(t *testing.T) {
+	cfg := dgcache.DefaultConfig().
+		WithStore("memory", dgcache.StoreConfig{Driver: "memory"}).
+		WithStore("sessions", dgcache.StoreConfig{Driver: "memory", Middleware: []string{"record"}})
+	manager, err := dgcache.NewManager(cfg)
+	require.NoError(t, err)
+	manager.RegisterDriver("memory", memory.NewDriver)
+
+	ttls := make(map[string]time.Duration)
+	manager.RegisterMiddleware("record", func(driver contracts.Driver) contracts.Driver {
+		return &ttlRecordingDriver{Driver: driver, ttls: ttls}
+	})
+
+	ctx := context.Background()
+	view := manager.With(dgcache.Store("sessions"), dgcache.Prefix("sess"), dgcache.DefaultTTL(30*time.Minute))
+
+	require.NoError(t, view.Put(ctx, "abc", "user-1", 0))
+	require.NoError(t, view.Put(ctx, "short", "user-2", time.Minute))
+
+	sessions, err := manager.Store("sessions")
+	require.NoError(t, err)
+	val, err := sessions.Get(ctx, "sess:abc")
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", val)
+	assert.Equal(t, 30*time.Minute, ttls["sess:abc"])
+	assert.Equal(t, time.Minute, ttls["sess:short"])
+
+	// The default store is untouched
+	has, err := manager.Has(ctx, "sess:abc")
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	val, err = view.Get(ctx, "abc")
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", val)
+
+	values, err := view.GetMultiple(ctx, []string{"abc", "short", "missing"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"abc": "user-1", "short": "user-2"}, values)
+
+	val, err = view.Remember(ctx, "lazy", 0, func() (interface{}, error) {
+		return "loaded", nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "loaded", val)
+	assert.Equal(t, 30*time.Minute, ttls["sess:lazy"])
+
+	// Tagged writes are prefixed too
+	tagged := manager.With(dgcache.Prefix("sess"))
+	require.NoError(t, tagged.Tags("users").Put(ctx, "tagged", "value", 0))
+	has, err = manager.Has(ctx, "sess:tagged")
+	require.NoError(t, err)
+	assert.True(t, has)
+	require.NoError(t, tagged.Tags("users").Flush(ctx))
+	has, err = tagged.Has(ctx, "tagged")
+	require.NoError(t, err)
+	assert.False(t, has)
+
+	assert.ErrorIs(t, view.Flush(ctx), dgcache.ErrNotSupported)
+
+	_, err = view.Get(ctx, "missing")
+	var cacheErr *dgcache.CacheError
+	require.ErrorAs(t, err, &cacheErr)
+	assert.Equal(t, "sessions", cacheErr.Store)
+}
+
+func TestManager_WithSharesManagerBookkeeping(t *testing.T) {
+	manager, err := dgcache.NewManager(dgcache.DefaultConfig().WithErrorTTL(time.Minute))
+	require.NoError(t, err)
+	manager.RegisterDriver("memory", memory.NewDriver)
+	view := manager.With(dgcache.Prefix("sess"))
+
+	// A forget through the view evicts the request cache the manager reads
+	ctx := dgcache.WithRequestCache(context.Background())
+	require.NoError(t, view.Put(ctx, "abc", "user-1", 0))
+	val, err := manager.Get(ctx, "sess:abc")
+	require.NoError(t, err)
+	assert.Equal(t, "user-1", val)
+	require.NoError(t, view.Forget(ctx, "abc"))
+	_, err = manager.Get(ctx, "sess:abc")
+	assert.ErrorIs(t, err, dgcache.ErrKeyNotFound)
+
+	// Callback failures are cached under the prefixed key
+	called := 0
+	callback := func() (interface{}, error) {
+		called++
+		return nil, errors.New("upstream down")
+	}
+	_, err = view.Remember(ctx, "failing", 0, callback)
+	assert.EqualError(t, err, "upstream down")
+	_, err = view.Remember(ctx, "failing", 0, callback)
+	var cachedErr *dgcache.CachedError
+	require.ErrorAs(t, err, &cachedErr)
+	assert.Equal(t, "sess:failing", cachedErr.Key)
+	assert.Equal(t, 1, called)
+}
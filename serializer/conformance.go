@@ -0,0 +1,82 @@
+package serializer
+
+import "time"
+
+// ConformanceCase is a value that every serializer is expected to round-trip.
+type ConformanceCase struct {
+	// Name identifies the case.
+	Name string
+
+	// Value is the value passed to Marshal.
+	Value interface{}
+}
+
+// ConformanceResult is the outcome of round-tripping a single case.
+type ConformanceResult struct {
+	// Case is the case that was round-tripped.
+	Case ConformanceCase
+
+	// Value is the result of unmarshaling into an interface{}, the way cache drivers decode values.
+	Value interface{}
+
+	// Err is the Marshal or Unmarshal error, if any.
+	Err error
+}
+
+// conformanceInner is a nested struct used by the conformance cases.
+type conformanceInner struct {
+	ID   int
+	Tags []string
+}
+
+// conformanceOuter is a struct with a nested struct used by the conformance cases.
+type conformanceOuter struct {
+	Name  string
+	Inner conformanceInner
+}
+
+// ConformanceCases returns the shared set of values every serializer is run through.
+func ConformanceCases() []ConformanceCase {
+	return []ConformanceCase{
+		{Name: "string", Value: "hello"},
+		{Name: "int", Value: 42},
+		{Name: "int64", Value: int64(1 << 40)},
+		{Name: "float64", Value: 3.5},
+		{Name: "bool", Value: true},
+		{Name: "struct", Value: conformanceInner{ID: 1, Tags: []string{"a"}}},
+		{Name: "nested_struct", Value: conformanceOuter{Name: "outer", Inner: conformanceInner{ID: 2}}},
+		{Name: "slice", Value: []string{"a", "b"}},
+		{Name: "map", Value: map[string]string{"k": "v"}},
+		{Name: "nil", Value: nil},
+		{Name: "time", Value: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{Name: "empty_string", Value: ""},
+		{Name: "empty_slice", Value: []string{}},
+		{Name: "empty_map", Value: map[string]string{}},
+	}
+}
+
+// Conformance round-trips every conformance case through s and returns the results in order.
+//
+// Values are decoded into an interface{}, so the result type depends on the encoding:
+// JSON decodes every number as float64 and every struct as map[string]interface{},
+// while msgpack keeps integer types (using the smallest fitting width).
+func Conformance(s Serializer) []ConformanceResult {
+	cases := ConformanceCases()
+	results := make([]ConformanceResult, 0, len(cases))
+
+	for _, c := range cases {
+		result := ConformanceResult{Case: c}
+
+		data, err := s.Marshal(c.Value)
+		if err != nil {
+			result.Err = err
+			results = append(results, result)
+			continue
+		}
+
+		result.Err = s.Unmarshal(data, &result.Value)
+		results = append(results, result)
+	}
+
+	return results
+}
@@ -0,0 +1,120 @@
+package serializer
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/donnigundala/dg-cache/compression"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// allSerializers returns every serializer shipped with the package.
+func allSerializers() []Serializer {
+	gzip := compression.NewGzipCompressor(compression.DefaultCompression)
+	return []Serializer{
+		NewJSONSerializer(),
+		NewMsgpackSerializer(),
+		NewCompressedSerializer(NewJSONSerializer(), gzip),
+		NewCompressedSerializer(NewMsgpackSerializer(), gzip),
+	}
+}
+
+// expectedConformance documents the value each serializer (by name) yields per case
+// when decoded into an interface{}.
+var expectedConformance = map[string]map[string]interface{}{
+	"json": {
+		"string":        "hello",
+		"int":           float64(42), // JSON numbers always decode as float64
+		"int64":         float64(1 << 40),
+		"float64":       3.5,
+		"bool":          true,
+		"struct":        map[string]interface{}{"ID": float64(1), "Tags": []interface{}{"a"}},
+		"nested_struct": map[string]interface{}{"Name": "outer", "Inner": map[string]interface{}{"ID": float64(2), "Tags": nil}},
+		"slice":         []interface{}{"a", "b"},
+		"map":           map[string]interface{}{"k": "v"},
+		"nil":           nil,
+		"time":          time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC), // restored via the type registry
+		"empty_string":  "",
+		"empty_slice":   []interface{}{},
+		"empty_map":     map[string]interface{}{},
+	},
+	"msgpack": {
+		"string":        "hello",
+		"int":           int8(42), // msgpack uses the smallest fitting integer
+		"int64":         int64(1 << 40),
+		"float64":       3.5,
+		"bool":          true,
+		"struct":        map[string]interface{}{"ID": int8(1), "Tags": []interface{}{"a"}},
+		"nested_struct": map[string]interface{}{"Name": "outer", "Inner": map[string]interface{}{"ID": int8(2), "Tags": nil}},
+		"slice":         []interface{}{"a", "b"},
+		"map":           map[string]interface{}{"k": "v"},
+		"nil":           nil,
+		"time":          time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC),
+		"empty_string":  "",
+		"empty_slice":   []interface{}{},
+		"empty_map":     map[string]interface{}{},
+	},
+}
+
+func TestConformance(t *testing.T) {
+	for _, s := range allSerializers() {
+		expected := expectedConformance[s.Name()]
+
+		for _, result := range Conformance(s) {
+			t.Run(s.Name()+"/"+result.Case.Name, func(t *testing.T) {
+				assert.NoError(t, result.Err)
+
+				want := expected[result.Case.Name]
+				if wantTime, ok := want.(time.Time); ok {
+					gotTime, ok := result.Value.(time.Time)
+					if assert.True(t, ok, "expected time.Time, got %T", result.Value) {
+						assert.True(t, wantTime.Equal(gotTime))
+					}
+					return
+				}
+				assert.Equal(t, want, result.Value)
+			})
+		}
+	}
+}
+
+func TestStreamingSerializers(t *testing.T) {
+	streaming := []StreamingSerializer{
+		NewJSONSerializer(),
+		NewRawJSONSerializer(),
+		NewJSONSerializer().UseNumber(),
+		NewMsgpackSerializer(),
+		NewRawMsgpackSerializer(),
+	}
+
+	for _, s := range streaming {
+		for _, c := range ConformanceCases() {
+			t.Run(s.Name()+"/"+c.Name, func(t *testing.T) {
+				data, err := s.Marshal(c.Value)
+				require.NoError(t, err)
+				var want interface{}
+				require.NoError(t, s.Unmarshal(data, &want))
+
+				// MarshalTo output reads back with Unmarshal
+				var buf bytes.Buffer
+				require.NoError(t, s.MarshalTo(&buf, c.Value))
+				var got interface{}
+				require.NoError(t, s.Unmarshal(buf.Bytes(), &got))
+				assert.Equal(t, want, got)
+
+				// Marshal output reads back with UnmarshalFrom
+				got = nil
+				require.NoError(t, s.UnmarshalFrom(bytes.NewReader(data), &got))
+				assert.Equal(t, want, got)
+			})
+		}
+	}
+
+	t.Run("unsupported map key", func(t *testing.T) {
+		var buf bytes.Buffer
+		err := NewJSONSerializer().MarshalTo(&buf, map[[2]int]string{{1, 2}: "x"})
+		assert.ErrorIs(t, err, ErrUnsupportedMapKey)
+	})
+}
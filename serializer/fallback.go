@@ -0,0 +1,52 @@
+package serializer
+
+import "errors"
+
+// FallbackSerializer writes with a primary serializer and reads with the first
+// of the primary and the fallback readers that accepts the data. It lets a
+// store move to a new format while values in the old one are still readable.
+//
+// Data valid in several formats decodes with the first that accepts it. In
+// particular a single-digit JSON number is also a one-byte msgpack integer.
+type FallbackSerializer struct {
+	primary Serializer
+	readers []Serializer
+}
+
+// NewFallbackSerializer creates a serializer that marshals with primary and
+// unmarshals with primary, then each of readers in order.
+func NewFallbackSerializer(primary Serializer, readers ...Serializer) *FallbackSerializer {
+	return &FallbackSerializer{
+		primary: primary,
+		readers: readers,
+	}
+}
+
+// Marshal marshals v with the primary serializer.
+func (s *FallbackSerializer) Marshal(v interface{}) ([]byte, error) {
+	return s.primary.Marshal(v)
+}
+
+// Unmarshal tries the primary serializer and then each reader, returning all
+// their errors joined if none accepts the data.
+func (s *FallbackSerializer) Unmarshal(data []byte, v interface{}) error {
+	err := s.primary.Unmarshal(data, v)
+	if err == nil {
+		return nil
+	}
+
+	errs := []error{err}
+	for _, reader := range s.readers {
+		if err := reader.Unmarshal(data, v); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		return nil
+	}
+	return errors.Join(errs...)
+}
+
+// Name returns the name of the primary serializer.
+func (s *FallbackSerializer) Name() string {
+	return s.primary.Name()
+}
@@ -0,0 +1,35 @@
+package serializer
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFallbackSerializer(t *testing.T) {
+	legacy := NewJSONSerializer()
+	serializer := NewFallbackSerializer(NewMsgpackSerializer(), legacy)
+	assert.Equal(t, "msgpack", serializer.Name())
+
+	// Values written in the legacy format are still readable
+	for _, value := range []interface{}{"hello", 1234.0, map[string]interface{}{"foo": "bar"}} {
+		data, err := legacy.Marshal(value)
+		require.NoError(t, err)
+
+		var result interface{}
+		require.NoError(t, serializer.Unmarshal(data, &result))
+		assert.Equal(t, value, result)
+	}
+
+	// New values are written with the primary format
+	data, err := serializer.Marshal("hello")
+	require.NoError(t, err)
+	var result string
+	require.NoError(t, NewMsgpackSerializer().Unmarshal(data, &result))
+	assert.Equal(t, "hello", result)
+
+	// Data no serializer accepts fails
+	var invalid interface{}
+	assert.Error(t, serializer.Unmarshal([]byte{0xc1}, &invalid))
+}
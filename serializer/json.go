@@ -1,24 +1,70 @@
 package serializer
 
 import (
+	"bytes"
+	"encoding"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"reflect"
 )
 
+// ErrUnsupportedMapKey is returned by the JSON serializer for maps whose key
+// type cannot be represented as a JSON object key.
+var ErrUnsupportedMapKey = errors.New("serializer: unsupported map key type")
+
+// textMarshalerType is used to detect map keys that encode via MarshalText.
+var textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+
 // JSONSerializer implements the Serializer interface using JSON encoding.
 // It provides human-readable serialization with type preservation.
-type JSONSerializer struct{}
+type JSONSerializer struct {
+	raw       bool
+	useNumber bool
+}
 
 // NewJSONSerializer creates a new JSON serializer.
 func NewJSONSerializer() *JSONSerializer {
 	return &JSONSerializer{}
 }
 
+// NewRawJSONSerializer creates a JSON serializer that stores values as plain
+// JSON without the type envelope, for interop with non-Go consumers.
+// Values can no longer be restored to their original Go types: complex values
+// decode as map[string]interface{} or []interface{}.
+func NewRawJSONSerializer() *JSONSerializer {
+	return &JSONSerializer{raw: true}
+}
+
+// UseNumber returns a copy of the serializer that decodes numbers into an
+// interface{} as json.Number instead of float64, so integers beyond 2^53
+// round-trip without losing precision.
+func (s *JSONSerializer) UseNumber() *JSONSerializer {
+	c := *s
+	c.useNumber = true
+	return &c
+}
+
 // Marshal converts a Go value to JSON bytes with type information.
 func (s *JSONSerializer) Marshal(v interface{}) ([]byte, error) {
-	// Handle nil values
-	if v == nil {
-		return json.Marshal(nil)
+	encoded, err := json.Marshal(s.wrap(v))
+	if err != nil {
+		return nil, jsonError(err, v)
+	}
+	return encoded, nil
+}
+
+// MarshalTo writes v to w as JSON, followed by a newline as json.Encoder writes.
+func (s *JSONSerializer) MarshalTo(w io.Writer, v interface{}) error {
+	return jsonError(json.NewEncoder(w).Encode(s.wrap(v)), v)
+}
+
+// wrap returns the value to encode for v: v itself, or v in an Envelope.
+func (s *JSONSerializer) wrap(v interface{}) interface{} {
+	// Handle nil values, and raw mode which never wraps
+	if v == nil || s.raw {
+		return v
 	}
 
 	// For simple types (string, int, bool, etc.), store directly without envelope
@@ -27,19 +73,69 @@ func (s *JSONSerializer) Marshal(v interface{}) ([]byte, error) {
 	case string, int, int8, int16, int32, int64,
 		uint, uint8, uint16, uint32, uint64,
 		float32, float64, bool:
-		return json.Marshal(v)
+		return v
 	}
 
 	// For complex types, wrap with type information
-	envelope := Envelope{
-		Type:  reflect.TypeOf(v).String(),
+	return Envelope{
+		Type:  typeName(reflect.TypeOf(v)),
 		Value: v,
 	}
-	return json.Marshal(envelope)
+}
+
+// jsonError reports an encoding failure of v caused by a map key type JSON
+// cannot represent as ErrUnsupportedMapKey.
+func jsonError(err error, v interface{}) error {
+	if err != nil && v != nil {
+		if key := unsupportedMapKey(reflect.TypeOf(v), map[reflect.Type]bool{}); key != nil {
+			return fmt.Errorf("%w: %s (json object keys must be strings, integers or encoding.TextMarshaler; use msgpack instead)", ErrUnsupportedMapKey, key)
+		}
+	}
+	return err
+}
+
+// unsupportedMapKey returns the first map key type reachable from t that JSON
+// cannot encode, or nil. Values behind interfaces are not inspected.
+func unsupportedMapKey(t reflect.Type, seen map[reflect.Type]bool) reflect.Type {
+	if seen[t] {
+		return nil
+	}
+	seen[t] = true
+
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Slice, reflect.Array:
+		return unsupportedMapKey(t.Elem(), seen)
+	case reflect.Map:
+		key := t.Key()
+		switch key.Kind() {
+		case reflect.String,
+			reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		default:
+			if !key.Implements(textMarshalerType) && !reflect.PointerTo(key).Implements(textMarshalerType) {
+				return key
+			}
+		}
+		return unsupportedMapKey(t.Elem(), seen)
+	case reflect.Struct:
+		for i := 0; i < t.NumField(); i++ {
+			if field := t.Field(i); field.IsExported() {
+				if key := unsupportedMapKey(field.Type, seen); key != nil {
+					return key
+				}
+			}
+		}
+	}
+	return nil
 }
 
 // Unmarshal converts JSON bytes back to a Go value.
 func (s *JSONSerializer) Unmarshal(data []byte, v interface{}) error {
+	// Raw mode never writes envelopes, so a "type" field is ordinary data
+	if s.raw {
+		return s.decode(data, v)
+	}
+
 	// 1. Try to unmarshal as an Envelope first
 	// We use a temporary struct with RawMessage to defer unmarshaling of the value
 	type tempEnvelope struct {
@@ -48,13 +144,58 @@ func (s *JSONSerializer) Unmarshal(data []byte, v interface{}) error {
 	}
 
 	var temp tempEnvelope
-	if err := json.Unmarshal(data, &temp); err == nil && temp.Type != "" {
+	if err := s.decode(data, &temp); err == nil && temp.Type != "" {
+		// Restore registered types (e.g., time.Time) when decoding into an interface{}
+		decode := func(target interface{}) error {
+			return s.decode(temp.Value, target)
+		}
+		if handled, err := restoreRegistered(temp.Type, v, decode); handled {
+			return err
+		}
+
 		// It's a valid envelope, unmarshal the inner value into v
-		return json.Unmarshal(temp.Value, v)
+		return s.decode(temp.Value, v)
 	}
 
 	// 2. Fallback: Unmarshal directly (for simple types or backward compatibility)
-	return json.Unmarshal(data, v)
+	return s.decode(data, v)
+}
+
+// UnmarshalFrom reads one JSON value from r into v. Raw serializers decode
+// straight from r; enveloped values are read whole first, since the envelope
+// has to be recognized before the inner value can be decoded.
+func (s *JSONSerializer) UnmarshalFrom(r io.Reader, v interface{}) error {
+	dec := json.NewDecoder(r)
+	if s.useNumber {
+		dec.UseNumber()
+	}
+	if s.raw {
+		return dec.Decode(v)
+	}
+
+	var data json.RawMessage
+	if err := dec.Decode(&data); err != nil {
+		return err
+	}
+	return s.Unmarshal(data, v)
+}
+
+// decode unmarshals data into v, honoring UseNumber.
+func (s *JSONSerializer) decode(data []byte, v interface{}) error {
+	if !s.useNumber {
+		return json.Unmarshal(data, v)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	// Reject trailing data like json.Unmarshal does
+	if err := dec.Decode(&struct{}{}); !errors.Is(err, io.EOF) {
+		return errors.New("json: invalid data after top-level value")
+	}
+	return nil
 }
 
 // Name returns the serializer name.
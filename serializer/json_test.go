@@ -102,8 +102,8 @@ func TestJSONSerializer_Struct(t *testing.T) {
 	}
 
 	// Check type information
-	if envelope.Type != "serializer.User" {
-		t.Errorf("Expected type 'serializer.User', got '%s'", envelope.Type)
+	if envelope.Type != "github.com/donnigundala/dg-cache/serializer.User" {
+		t.Errorf("Expected type 'github.com/donnigundala/dg-cache/serializer.User', got '%s'", envelope.Type)
 	}
 
 	// Check values
@@ -261,3 +261,72 @@ func TestJSONSerializer_EmptyValues(t *testing.T) {
 		})
 	}
 }
+
+func TestJSONSerializer_Raw(t *testing.T) {
+	s := NewRawJSONSerializer()
+
+	type user struct {
+		Name string `json:"name"`
+		Age  int    `json:"age"`
+	}
+
+	data, err := s.Marshal(user{Name: "john", Age: 30})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+	if string(data) != `{"name":"john","age":30}` {
+		t.Errorf("Expected plain JSON, got %s", data)
+	}
+
+	// A "type" field is ordinary data in raw mode
+	data, _ = json.Marshal(map[string]interface{}{"type": "order", "value": 1})
+	var result interface{}
+	if err := s.Unmarshal(data, &result); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	m, ok := result.(map[string]interface{})
+	if !ok || m["type"] != "order" {
+		t.Errorf("Expected map with type field, got %v", result)
+	}
+}
+
+func TestJSONSerializer_UseNumber(t *testing.T) {
+	const big = int64(9007199254740993) // 2^53 + 1
+
+	for name, s := range map[string]*JSONSerializer{
+		"envelope": NewJSONSerializer().UseNumber(),
+		"raw":      NewRawJSONSerializer().UseNumber(),
+	} {
+		t.Run(name, func(t *testing.T) {
+			data, err := s.Marshal(big)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+
+			var result interface{}
+			if err := s.Unmarshal(data, &result); err != nil {
+				t.Fatalf("Unmarshal failed: %v", err)
+			}
+			n, ok := result.(json.Number)
+			if !ok {
+				t.Fatalf("Expected json.Number, got %T", result)
+			}
+			if i, err := n.Int64(); err != nil || i != big {
+				t.Errorf("Expected %d, got %s", big, n)
+			}
+
+			if err := s.Unmarshal([]byte("1 2"), &result); err == nil {
+				t.Error("Expected error for trailing data")
+			}
+		})
+	}
+
+	// The original serializer is unchanged
+	var result interface{}
+	if err := NewJSONSerializer().Unmarshal([]byte("1"), &result); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if _, ok := result.(float64); !ok {
+		t.Errorf("Expected float64 without UseNumber, got %T", result)
+	}
+}
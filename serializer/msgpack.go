@@ -1,25 +1,74 @@
 package serializer
 
 import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"math"
 	"reflect"
 
 	"github.com/vmihailenco/msgpack/v5"
 )
 
+// Enveloped msgpack data starts with a two-byte format tag: msgpackTag, which
+// msgpack never uses, so it cannot be mistaken for the start of a value, then
+// msgpackDirect or msgpackEnveloped. Raw serializers write no tag.
+const (
+	msgpackTag       byte = 0xc1
+	msgpackDirect    byte = 'd'
+	msgpackEnveloped byte = 'e'
+)
+
 // MsgpackSerializer implements the Serializer interface using MessagePack encoding.
 // It provides faster, more compact serialization compared to JSON.
-type MsgpackSerializer struct{}
+//
+// Unless raw, it tags its output as a plain value or an Envelope, so Unmarshal
+// decodes it one way only. Untagged data from older versions is decoded as an
+// envelope if it looks like one, and directly otherwise.
+type MsgpackSerializer struct {
+	raw bool
+}
 
 // NewMsgpackSerializer creates a new msgpack serializer.
 func NewMsgpackSerializer() *MsgpackSerializer {
 	return &MsgpackSerializer{}
 }
 
+// NewRawMsgpackSerializer creates a msgpack serializer that stores values
+// without the type envelope, for interop with non-Go consumers.
+// Values can no longer be restored to their original Go types: complex values
+// decode as map[string]interface{} or []interface{}.
+func NewRawMsgpackSerializer() *MsgpackSerializer {
+	return &MsgpackSerializer{raw: true}
+}
+
 // Marshal converts a Go value to msgpack bytes with type information.
 func (s *MsgpackSerializer) Marshal(v interface{}) ([]byte, error) {
-	// Handle nil values
-	if v == nil {
-		return msgpack.Marshal(nil)
+	var buf bytes.Buffer
+	if err := s.MarshalTo(&buf, v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// MarshalTo writes v to w as msgpack.
+func (s *MsgpackSerializer) MarshalTo(w io.Writer, v interface{}) error {
+	value, format := s.wrap(v)
+	if !s.raw {
+		if _, err := w.Write([]byte{msgpackTag, format}); err != nil {
+			return err
+		}
+	}
+	return msgpack.NewEncoder(w).Encode(value)
+}
+
+// wrap returns the value to encode for v, either v itself or v in an
+// Envelope, and the format tag saying which.
+func (s *MsgpackSerializer) wrap(v interface{}) (interface{}, byte) {
+	// Handle nil values, and raw mode which never wraps
+	if v == nil || s.raw {
+		return v, msgpackDirect
 	}
 
 	// For simple types, store directly without envelope
@@ -27,31 +76,207 @@ func (s *MsgpackSerializer) Marshal(v interface{}) ([]byte, error) {
 	case string, int, int8, int16, int32, int64,
 		uint, uint8, uint16, uint32, uint64,
 		float32, float64, bool:
-		return msgpack.Marshal(v)
+		return v, msgpackDirect
 	}
 
 	// For complex types, wrap with type information
-	envelope := Envelope{
-		Type:  reflect.TypeOf(v).String(),
+	return Envelope{
+		Type:  typeName(reflect.TypeOf(v)),
 		Value: v,
-	}
-	return msgpack.Marshal(envelope)
+	}, msgpackEnveloped
 }
 
 // Unmarshal converts msgpack bytes back to a Go value.
 func (s *MsgpackSerializer) Unmarshal(data []byte, v interface{}) error {
-	// Try to unmarshal directly first (for simple types)
-	if err := msgpack.Unmarshal(data, v); err == nil {
-		return nil
+	format, payload, tagged := splitMsgpackTag(data)
+
+	// Raw mode never writes envelopes, so a "type" field is ordinary data
+	if s.raw {
+		return unmarshalMsgpack(payload, v)
 	}
 
-	// If that fails, try to unmarshal as an envelope
-	var envelope Envelope
-	envelope.Value = v
-	return msgpack.Unmarshal(data, &envelope)
+	if tagged {
+		switch format {
+		case msgpackDirect:
+			return unmarshalMsgpack(payload, v)
+		case msgpackEnveloped:
+			return unmarshalEnvelope(payload, v, true)
+		}
+		return fmt.Errorf("serializer: unknown msgpack format tag %#x", format)
+	}
+
+	// Untagged data predates the tag: try the envelope, then decode directly
+	return unmarshalEnvelope(payload, v, false)
+}
+
+// splitMsgpackTag splits the format tag off data. Data without a tag is
+// returned whole.
+func splitMsgpackTag(data []byte) (format byte, payload []byte, tagged bool) {
+	if len(data) >= 2 && data[0] == msgpackTag {
+		return data[1], data[2:], true
+	}
+	return 0, data, false
+}
+
+// unmarshalEnvelope decodes an Envelope from data and its value into v. When
+// data is not an envelope, it fails if strict and decodes data into v directly
+// otherwise.
+func unmarshalEnvelope(data []byte, v interface{}, strict bool) error {
+	// We use a temporary struct with RawMessage to defer unmarshaling of the value.
+	// Decoding directly into an interface{} would otherwise return the envelope itself.
+	type tempEnvelope struct {
+		Type  string             `msgpack:"type"`
+		Value msgpack.RawMessage `msgpack:"value"`
+	}
+
+	var temp tempEnvelope
+	if err := unmarshalMsgpack(data, &temp); err != nil || temp.Type == "" {
+		if !strict {
+			return unmarshalMsgpack(data, v)
+		}
+		if err == nil {
+			err = errNotEnvelope
+		}
+		return err
+	}
+
+	// Restore registered types (e.g., time.Time) when decoding into an interface{}
+	decode := func(target interface{}) error {
+		return unmarshalMsgpack(temp.Value, target)
+	}
+	if handled, err := restoreRegistered(temp.Type, v, decode); handled {
+		return err
+	}
+	return unmarshalMsgpack(temp.Value, v)
+}
+
+// UnmarshalFrom reads one msgpack value from r into v. Raw serializers
+// decode straight from r; enveloped values are read whole first, since the
+// envelope has to be recognized before the inner value can be decoded.
+func (s *MsgpackSerializer) UnmarshalFrom(r io.Reader, v interface{}) error {
+	var head [2]byte
+	if _, err := io.ReadFull(r, head[:1]); err != nil {
+		return err
+	}
+	if head[0] == msgpackTag {
+		if _, err := io.ReadFull(r, head[1:]); err != nil {
+			return err
+		}
+	} else {
+		// Untagged: put the byte back in front of the value
+		r = io.MultiReader(bytes.NewReader(head[:1]), r)
+	}
+
+	dec := msgpack.NewDecoder(r)
+	dec.SetMapDecoder(decodeMap)
+	if s.raw {
+		return dec.Decode(v)
+	}
+
+	data, err := dec.DecodeRaw()
+	if err != nil {
+		return err
+	}
+	if head[0] == msgpackTag {
+		data = append(head[:], data...)
+	}
+	return s.Unmarshal(data, v)
 }
 
 // Name returns the serializer name.
 func (s *MsgpackSerializer) Name() string {
 	return "msgpack"
 }
+
+var (
+	// errTrailingData is returned when data holds more than one msgpack value.
+	errTrailingData = errors.New("serializer: trailing data after msgpack value")
+
+	// errNotEnvelope is returned when data tagged as enveloped has no type.
+	errNotEnvelope = errors.New("serializer: msgpack data tagged as an envelope has no type")
+)
+
+// unmarshalMsgpack decodes data into v, decoding maps inside an interface{}
+// with decodeMap so non-string keys are kept. Data left over after the value
+// is an error, so bytes in another format are not taken for their first byte.
+func unmarshalMsgpack(data []byte, v interface{}) error {
+	r := bytes.NewReader(data)
+	dec := msgpack.NewDecoder(r)
+	dec.SetMapDecoder(decodeMap)
+	if err := dec.Decode(v); err != nil {
+		return err
+	}
+	if r.Len() > 0 {
+		return errTrailingData
+	}
+	return nil
+}
+
+// decodeMap decodes a map into an interface{} as map[string]interface{} when
+// all keys are strings, and as map[interface{}]interface{} otherwise, with
+// integer keys as int64 (uint64 above math.MaxInt64).
+// The default decoder fails on maps with non-string keys.
+func decodeMap(d *msgpack.Decoder) (interface{}, error) {
+	n, err := d.DecodeMapLen()
+	if err != nil || n == -1 {
+		return nil, err
+	}
+
+	strMap := make(map[string]interface{}, n)
+	var anyMap map[interface{}]interface{}
+	for i := 0; i < n; i++ {
+		key, err := d.DecodeInterface()
+		if err != nil {
+			return nil, err
+		}
+		value, err := d.DecodeInterface()
+		if err != nil {
+			return nil, err
+		}
+
+		if s, ok := key.(string); ok && anyMap == nil {
+			strMap[s] = value
+			continue
+		}
+		if key != nil && !reflect.TypeOf(key).Comparable() {
+			return nil, fmt.Errorf("msgpack: unhashable map key of type %T", key)
+		}
+		key = normalizeIntKey(key)
+		if anyMap == nil {
+			anyMap = make(map[interface{}]interface{}, n)
+			for k, v := range strMap {
+				anyMap[k] = v
+			}
+		}
+		anyMap[key] = value
+	}
+
+	if anyMap != nil {
+		return anyMap, nil
+	}
+	return strMap, nil
+}
+
+// normalizeIntKey widens the compact integer types msgpack decodes into, so
+// integer keys can be looked up without knowing their encoded width.
+func normalizeIntKey(key interface{}) interface{} {
+	switch k := key.(type) {
+	case int8:
+		return int64(k)
+	case int16:
+		return int64(k)
+	case int32:
+		return int64(k)
+	case uint8:
+		return int64(k)
+	case uint16:
+		return int64(k)
+	case uint32:
+		return int64(k)
+	case uint64:
+		if k <= math.MaxInt64 {
+			return int64(k)
+		}
+	}
+	return key
+}
@@ -1,6 +1,7 @@
 package serializer
 
 import (
+	"bytes"
 	"testing"
 
 	"github.com/vmihailenco/msgpack/v5"
@@ -82,13 +83,17 @@ func TestMsgpackSerializer_Struct(t *testing.T) {
 	var resultUser User
 	envelope.Value = &resultUser
 
-	if err := msgpack.Unmarshal(data, &envelope); err != nil {
+	format, payload, tagged := splitMsgpackTag(data)
+	if !tagged || format != msgpackEnveloped {
+		t.Fatalf("Expected envelope format tag, got %x", data[:2])
+	}
+	if err := msgpack.Unmarshal(payload, &envelope); err != nil {
 		t.Fatalf("Unmarshal failed: %v", err)
 	}
 
 	// Check type information
-	if envelope.Type != "serializer.User" {
-		t.Errorf("Expected type 'serializer.User', got '%s'", envelope.Type)
+	if envelope.Type != "github.com/donnigundala/dg-cache/serializer.User" {
+		t.Errorf("Expected type 'github.com/donnigundala/dg-cache/serializer.User', got '%s'", envelope.Type)
 	}
 
 	// Check values
@@ -113,7 +118,11 @@ func TestMsgpackSerializer_Slice(t *testing.T) {
 	var result []int
 	envelope.Value = &result
 
-	if err := msgpack.Unmarshal(data, &envelope); err != nil {
+	format, payload, tagged := splitMsgpackTag(data)
+	if !tagged || format != msgpackEnveloped {
+		t.Fatalf("Expected envelope format tag, got %x", data[:2])
+	}
+	if err := msgpack.Unmarshal(payload, &envelope); err != nil {
 		t.Fatalf("Unmarshal failed: %v", err)
 	}
 
@@ -153,7 +162,11 @@ func TestMsgpackSerializer_Map(t *testing.T) {
 	var result map[string]interface{}
 	envelope.Value = &result
 
-	if err := msgpack.Unmarshal(bytes, &envelope); err != nil {
+	format, payload, tagged := splitMsgpackTag(bytes)
+	if !tagged || format != msgpackEnveloped {
+		t.Fatalf("Expected envelope format tag, got %x", bytes[:2])
+	}
+	if err := msgpack.Unmarshal(payload, &envelope); err != nil {
 		t.Fatalf("Unmarshal failed: %v", err)
 	}
 
@@ -232,3 +245,106 @@ func BenchmarkMsgpack_Unmarshal(b *testing.B) {
 		_ = s.Unmarshal(data, &result)
 	}
 }
+
+func TestMsgpackSerializer_Raw(t *testing.T) {
+	s := NewRawMsgpackSerializer()
+
+	data, err := s.Marshal([]string{"a", "b"})
+	if err != nil {
+		t.Fatalf("Marshal failed: %v", err)
+	}
+
+	expected, _ := msgpack.Marshal([]string{"a", "b"})
+	if !bytes.Equal(data, expected) {
+		t.Errorf("Expected plain msgpack %x, got %x", expected, data)
+	}
+
+	var result interface{}
+	if err := s.Unmarshal(data, &result); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if slice, ok := result.([]interface{}); !ok || len(slice) != 2 {
+		t.Errorf("Expected []interface{} of 2, got %T %v", result, result)
+	}
+}
+
+func TestMsgpackSerializer_FormatTag(t *testing.T) {
+	s := NewMsgpackSerializer()
+
+	type User struct {
+		ID   int
+		Name string
+	}
+
+	tests := []struct {
+		name   string
+		value  interface{}
+		format byte
+	}{
+		{"string", "hello", msgpackDirect},
+		{"int", 42, msgpackDirect},
+		{"nil", nil, msgpackDirect},
+		{"struct", User{ID: 1, Name: "John"}, msgpackEnveloped},
+		{"map", map[string]interface{}{"type": "int", "value": 5}, msgpackEnveloped},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := s.Marshal(tt.value)
+			if err != nil {
+				t.Fatalf("Marshal failed: %v", err)
+			}
+			format, _, tagged := splitMsgpackTag(data)
+			if !tagged || format != tt.format {
+				t.Fatalf("Expected format %q, got tagged=%v format %q", tt.format, tagged, format)
+			}
+		})
+	}
+
+	// A value tagged as direct is never taken for an envelope, even if it looks like one
+	payload, _ := msgpack.Marshal(map[string]interface{}{"type": "int", "value": 5})
+	var direct interface{}
+	if err := s.Unmarshal(append([]byte{msgpackTag, msgpackDirect}, payload...), &direct); err != nil {
+		t.Fatalf("Unmarshal failed: %v", err)
+	}
+	if m, ok := direct.(map[string]interface{}); !ok || m["type"] != "int" {
+		t.Errorf("Expected the map itself, got %T %v", direct, direct)
+	}
+
+	// A struct decodes into its type, and a primitive into a struct fails outright
+	data, _ := s.Marshal(User{ID: 1, Name: "John"})
+	var user User
+	if err := s.Unmarshal(data, &user); err != nil || user.Name != "John" {
+		t.Errorf("Expected John, got %+v (%v)", user, err)
+	}
+	data, _ = s.Marshal("John")
+	if err := s.Unmarshal(data, &user); err == nil {
+		t.Error("Expected an error decoding a string into a struct")
+	}
+
+	// Data tagged as an envelope must be one
+	var result interface{}
+	if err := s.Unmarshal([]byte{msgpackTag, msgpackEnveloped}, &result); err == nil {
+		t.Error("Expected an error for an empty envelope")
+	}
+	plain, _ := msgpack.Marshal("not an envelope")
+	if err := s.Unmarshal(append([]byte{msgpackTag, msgpackEnveloped}, plain...), &result); err == nil {
+		t.Error("Expected an error for a tagged envelope that is a string")
+	}
+}
+
+func TestMsgpackSerializer_UntaggedData(t *testing.T) {
+	s := NewMsgpackSerializer()
+
+	// Values written before the format tag still decode
+	legacy, _ := msgpack.Marshal(Envelope{Type: "[]int", Value: []int{1, 2}})
+	var slice []int
+	if err := s.Unmarshal(legacy, &slice); err != nil || len(slice) != 2 {
+		t.Errorf("Expected [1 2], got %v (%v)", slice, err)
+	}
+
+	legacy, _ = msgpack.Marshal("hello")
+	var str string
+	if err := s.Unmarshal(legacy, &str); err != nil || str != "hello" {
+		t.Errorf("Expected hello, got %q (%v)", str, err)
+	}
+}
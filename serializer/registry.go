@@ -0,0 +1,99 @@
+package serializer
+
+import (
+	"fmt"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// registry maps envelope type names to Go types that are restored when
+// unmarshaling into an interface{}. legacyRegistry maps the unqualified names
+// written by earlier versions, or nil when two registered types share one.
+var (
+	registry       = map[string]reflect.Type{}
+	legacyRegistry = map[string]reflect.Type{}
+	registryMu     sync.RWMutex
+)
+
+func init() {
+	RegisterType(time.Time{})
+}
+
+// RegisterType registers the type of value so that enveloped values of that type
+// are restored as that type when unmarshaled into an interface{}, instead of a
+// generic map or string. time.Time is registered by default.
+//
+// Types are told apart by their package path, so two packages' "models.User"
+// types can both be registered. Values enveloped by earlier versions, which
+// wrote the unqualified name, are still restored unless two registered types
+// share that name. RegisterType panics if a different type, such as one
+// declared inside a function, has the same qualified name.
+func RegisterType(value interface{}) {
+	t := reflect.TypeOf(value)
+	name := typeName(t)
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if existing, ok := registry[name]; ok && existing != t {
+		panic(fmt.Sprintf("serializer: RegisterType: %s is already registered as a different type", name))
+	}
+	registry[name] = t
+
+	legacy := t.String()
+	if existing, ok := legacyRegistry[legacy]; ok && existing != t {
+		legacyRegistry[legacy] = nil
+	} else if !ok {
+		legacyRegistry[legacy] = t
+	}
+}
+
+// typeName returns the envelope name of t: named types are qualified by their
+// full package path, and composite types spell out their elements the same way.
+func typeName(t reflect.Type) string {
+	if t.Name() != "" {
+		if t.PkgPath() == "" {
+			return t.String()
+		}
+		return t.PkgPath() + "." + t.Name()
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return "*" + typeName(t.Elem())
+	case reflect.Slice:
+		return "[]" + typeName(t.Elem())
+	case reflect.Array:
+		return fmt.Sprintf("[%d]%s", t.Len(), typeName(t.Elem()))
+	case reflect.Map:
+		return "map[" + typeName(t.Key()) + "]" + typeName(t.Elem())
+	default:
+		return t.String()
+	}
+}
+
+// restoreRegistered decodes an enveloped value into its registered type when v is
+// a *interface{}. It reports whether the type was registered and handled.
+func restoreRegistered(typeName string, v interface{}, decode func(target interface{}) error) (bool, error) {
+	target, ok := v.(*interface{})
+	if !ok {
+		return false, nil
+	}
+
+	registryMu.RLock()
+	t, ok := registry[typeName]
+	if !ok {
+		t = legacyRegistry[typeName]
+		ok = t != nil
+	}
+	registryMu.RUnlock()
+	if !ok {
+		return false, nil
+	}
+
+	value := reflect.New(t)
+	if err := decode(value.Interface()); err != nil {
+		return true, err
+	}
+	*target = value.Elem().Interface()
+	return true, nil
+}
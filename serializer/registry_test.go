@@ -0,0 +1,148 @@
+package serializer
+
+import (
+	"encoding/json"
+	"math/rand"
+	randv2 "math/rand/v2"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type registryEvent struct {
+	Name string
+	At   time.Time
+}
+
+func TestSerializers_TimeRoundTrip(t *testing.T) {
+	RegisterType(registryEvent{})
+
+	now := time.Date(2025, 6, 1, 12, 30, 0, 0, time.UTC)
+	event := registryEvent{Name: "deploy", At: now}
+
+	for _, s := range []Serializer{NewJSONSerializer(), NewMsgpackSerializer()} {
+		t.Run(s.Name()+"/time", func(t *testing.T) {
+			data, err := s.Marshal(now)
+			require.NoError(t, err)
+
+			var result interface{}
+			require.NoError(t, s.Unmarshal(data, &result))
+
+			restored, ok := result.(time.Time)
+			require.True(t, ok, "expected time.Time, got %T", result)
+			assert.True(t, now.Equal(restored))
+		})
+
+		t.Run(s.Name()+"/struct_with_time", func(t *testing.T) {
+			data, err := s.Marshal(event)
+			require.NoError(t, err)
+
+			// Registered type restored through interface{}
+			var result interface{}
+			require.NoError(t, s.Unmarshal(data, &result))
+			restored, ok := result.(registryEvent)
+			require.True(t, ok, "expected registryEvent, got %T", result)
+			assert.Equal(t, event.Name, restored.Name)
+			assert.True(t, event.At.Equal(restored.At))
+
+			// Typed destination
+			var typed registryEvent
+			require.NoError(t, s.Unmarshal(data, &typed))
+			assert.True(t, event.At.Equal(typed.At))
+		})
+	}
+}
+
+func TestSerializers_NonStringMapKeys(t *testing.T) {
+	RegisterType(map[int]string{})
+	byID := map[int]string{1: "one", 2: "two", 300: "three hundred"}
+
+	// Registered int-keyed maps are restored exactly by both serializers
+	for _, s := range []Serializer{NewJSONSerializer(), NewMsgpackSerializer()} {
+		t.Run(s.Name()+"/registered", func(t *testing.T) {
+			data, err := s.Marshal(byID)
+			require.NoError(t, err)
+
+			var result interface{}
+			require.NoError(t, s.Unmarshal(data, &result))
+			assert.Equal(t, byID, result)
+		})
+	}
+
+	t.Run("msgpack/unregistered", func(t *testing.T) {
+		s := NewMsgpackSerializer()
+		data, err := s.Marshal(map[int8]string{1: "one", -2: "minus two"})
+		require.NoError(t, err)
+
+		var result interface{}
+		require.NoError(t, s.Unmarshal(data, &result))
+		assert.Equal(t, map[interface{}]interface{}{int64(1): "one", int64(-2): "minus two"}, result)
+	})
+
+	t.Run("json/unregistered", func(t *testing.T) {
+		// JSON object keys are strings, so without the registered type the keys come back stringified
+		s := NewJSONSerializer()
+		data, err := s.Marshal(map[int8]string{1: "one", -2: "minus two"})
+		require.NoError(t, err)
+
+		var result interface{}
+		require.NoError(t, s.Unmarshal(data, &result))
+		assert.Equal(t, map[string]interface{}{"1": "one", "-2": "minus two"}, result)
+	})
+
+	t.Run("json/unsupported key", func(t *testing.T) {
+		type grid struct {
+			Cells map[[2]int]string
+		}
+
+		for _, s := range []*JSONSerializer{NewJSONSerializer(), NewRawJSONSerializer()} {
+			_, err := s.Marshal(grid{Cells: map[[2]int]string{{0, 1}: "x"}})
+			assert.ErrorIs(t, err, ErrUnsupportedMapKey)
+			assert.ErrorContains(t, err, "[2]int")
+		}
+	})
+}
+
+func TestRegisterType_QualifiedNames(t *testing.T) {
+	RegisterType(registryEvent{})
+	RegisterType([]registryEvent{})
+	assert.Equal(t, "github.com/donnigundala/dg-cache/serializer.registryEvent", typeName(reflect.TypeOf(registryEvent{})))
+	assert.Equal(t, "map[string][]*time.Time", typeName(reflect.TypeOf(map[string][]*time.Time{})))
+
+	event := registryEvent{Name: "deploy"}
+	for _, s := range []Serializer{NewJSONSerializer(), NewMsgpackSerializer()} {
+		t.Run(s.Name(), func(t *testing.T) {
+			data, err := s.Marshal([]registryEvent{event})
+			require.NoError(t, err)
+			var result interface{}
+			require.NoError(t, s.Unmarshal(data, &result))
+			assert.Equal(t, []registryEvent{event}, result)
+		})
+	}
+
+	// Envelopes written with the unqualified name are still restored
+	legacy, err := json.Marshal(Envelope{Type: "serializer.registryEvent", Value: event})
+	require.NoError(t, err)
+	var result interface{}
+	require.NoError(t, NewJSONSerializer().Unmarshal(legacy, &result))
+	assert.Equal(t, event, result)
+}
+
+func TestRegisterType_Conflicts(t *testing.T) {
+	// Same unqualified name in two packages: both register, the legacy name
+	// no longer resolves
+	RegisterType(rand.Rand{})
+	RegisterType(randv2.Rand{})
+	registryMu.RLock()
+	assert.Contains(t, registry, "math/rand.Rand")
+	assert.Contains(t, registry, "math/rand/v2.Rand")
+	assert.Nil(t, legacyRegistry["rand.Rand"])
+	registryMu.RUnlock()
+
+	// A different type under the same qualified name cannot be told apart
+	type registryEvent struct{ ID int }
+	assert.Panics(t, func() { RegisterType(registryEvent{}) })
+}
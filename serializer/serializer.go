@@ -1,5 +1,7 @@
 package serializer
 
+import "io"
+
 // Serializer handles marshaling and unmarshaling of cache values.
 // Implementations must be thread-safe.
 type Serializer interface {
@@ -14,6 +16,23 @@ type Serializer interface {
 	Name() string
 }
 
+// StreamingSerializer is a Serializer that can also encode to an io.Writer
+// and decode from an io.Reader, so large values need not be built up in a
+// byte slice first. The JSON and msgpack serializers implement it.
+//
+// Values written by MarshalTo are readable by Unmarshal and vice versa.
+type StreamingSerializer interface {
+	Serializer
+
+	// MarshalTo encodes v to w. On error, w may have received partial output.
+	MarshalTo(w io.Writer, v interface{}) error
+
+	// UnmarshalFrom decodes a single value from r into the value pointed to by v.
+	// The decoder may read past the end of the value, so r should not be
+	// reused for other data afterwards.
+	UnmarshalFrom(r io.Reader, v interface{}) error
+}
+
 // Envelope wraps values with type information for safe deserialization.
 // This allows the cache to store the type alongside the value.
 type Envelope struct {
@@ -0,0 +1,52 @@
+package serializer
+
+import (
+	"testing"
+
+	"github.com/donnigundala/dg-cache/compression"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionedSerializer(t *testing.T) {
+	v1 := NewVersionedSerializer(NewJSONSerializer(), 1)
+	v2 := NewVersionedSerializer(NewJSONSerializer(), 2)
+
+	data, err := v1.Marshal(map[string]string{"foo": "bar"})
+	require.NoError(t, err)
+
+	var result map[string]string
+	require.NoError(t, v1.Unmarshal(data, &result))
+	assert.Equal(t, map[string]string{"foo": "bar"}, result)
+
+	// Another version is a mismatch
+	assert.ErrorIs(t, v2.Unmarshal(data, &result), ErrSchemaMismatch)
+
+	// So is data written without a version
+	plain, err := NewJSONSerializer().Marshal(map[string]string{"foo": "bar"})
+	require.NoError(t, err)
+	assert.ErrorIs(t, v1.Unmarshal(plain, &result), ErrSchemaMismatch)
+	assert.ErrorIs(t, v1.Unmarshal([]byte{0xc1, 'v'}, &result), ErrSchemaMismatch)
+
+	// Counters carry no version and still decode
+	var counter int64
+	require.NoError(t, v2.Unmarshal([]byte("42"), &counter))
+	assert.Equal(t, int64(42), counter)
+
+	assert.Equal(t, "json", v1.Name())
+}
+
+func TestVersionedSerializer_Compressed(t *testing.T) {
+	comp := compression.NewGzipCompressor(compression.DefaultCompression)
+	v1 := NewVersionedSerializer(NewCompressedSerializer(NewMsgpackSerializer(), comp), 1)
+
+	data, err := v1.Marshal([]string{"a", "b"})
+	require.NoError(t, err)
+
+	var result []string
+	require.NoError(t, v1.Unmarshal(data, &result))
+	assert.Equal(t, []string{"a", "b"}, result)
+
+	v3 := NewVersionedSerializer(NewCompressedSerializer(NewMsgpackSerializer(), comp), 3)
+	assert.ErrorIs(t, v3.Unmarshal(data, &result), ErrSchemaMismatch)
+}
@@ -1,4 +1,274 @@
 package dgcache
 
+import (
+	"context"
+	"time"
+
+	"github.com/donnigundala/dg-core/contracts/cache"
+)
+
 // The redundant interface definitions have been removed.
 // We now use cache.Store, cache.TaggedStore, and cache.Driver from dg-core.
+//
+// The interfaces below are optional capabilities a store may implement in
+// addition to cache.Store. The Manager detects them with type assertions.
+
+// Pinger is implemented by stores that can verify their backend is reachable.
+type Pinger interface {
+	// Ping checks the connection to the underlying backend.
+	Ping(ctx context.Context) error
+}
+
+// TTLExtender is implemented by stores that can extend a key's expiry without
+// ever shortening it.
+type TTLExtender interface {
+	// ExtendTTL sets the key to expire after ttl only if that is later than its
+	// current expiry. It reports whether the expiry was changed. Keys that are
+	// missing or have no expiry are left untouched.
+	ExtendTTL(ctx context.Context, key string, ttl time.Duration) (bool, error)
+}
+
+// Expirer is implemented by stores that can set the TTL of an existing key.
+type Expirer interface {
+	// Expire sets the TTL of key, replacing any existing expiry.
+	// It returns false if the key does not exist.
+	Expire(ctx context.Context, key string, ttl time.Duration) (bool, error)
+}
+
+// NoExpiry is the TTL reported for keys that never expire.
+const NoExpiry time.Duration = -1
+
+// ValueTTL is a cached value with its remaining time to live, which is
+// NoExpiry for keys without an expiry.
+type ValueTTL struct {
+	Value interface{}
+	TTL   time.Duration
+}
+
+// TTLReader is implemented by stores that can return values together with
+// their remaining TTL.
+type TTLReader interface {
+	// GetMultipleWithTTL retrieves the keys that exist with their remaining
+	// TTL. Missing keys are left out of the result.
+	GetMultipleWithTTL(ctx context.Context, keys []string) (map[string]ValueTTL, error)
+}
+
+// ItemMeta describes a cached value.
+type ItemMeta struct {
+	// TTL is the remaining time to live, NoExpiry for keys without an expiry.
+	TTL time.Duration
+
+	// Tags are the tags of the key, sorted. Nil if the store does not report them.
+	Tags []string
+
+	// CreatedAt is when the value was written. Zero if the store does not record it.
+	CreatedAt time.Time
+}
+
+// ExpiresWithin reports whether the value expires within d, to tell a
+// near-expiry hit from a fresh one.
+func (m ItemMeta) ExpiresWithin(d time.Duration) bool {
+	return m.TTL != NoExpiry && m.TTL <= d
+}
+
+// MetaReader is implemented by stores that can return a value together with
+// its metadata.
+type MetaReader interface {
+	// GetWithMeta retrieves a value like Get, along with its metadata.
+	GetWithMeta(ctx context.Context, key string) (interface{}, ItemMeta, error)
+}
+
+// FieldReader is implemented by stores that can read one field of a cached
+// object.
+type FieldReader interface {
+	// GetField returns the field of the value at key found by following a
+	// dotted path such as "address.city" or "$.items.0.name" through maps and
+	// slices. Returns ErrFieldNotFound if the path does not exist.
+	GetField(ctx context.Context, key, path string) (interface{}, error)
+}
+
+// ItemStore is implemented by stores that can return a whole cache entry.
+type ItemStore interface {
+	// GetItem returns a copy of the entry for key, with its value, expiry and
+	// tags. Changing the copy does not change the cache. Stores that don't
+	// record tags or write times leave them empty.
+	GetItem(ctx context.Context, key string) (*Item, error)
+}
+
+// MultiChecker is implemented by stores that can check the existence of
+// several keys in one call.
+type MultiChecker interface {
+	// HasMultiple reports for every key whether it exists. Expired keys
+	// report false.
+	HasMultiple(ctx context.Context, keys []string) (map[string]bool, error)
+}
+
+// TTLIncrementer is implemented by stores that can increment a counter and
+// set its expiry in one atomic step.
+type TTLIncrementer interface {
+	// IncrementWithTTL adds delta to the value of key. The TTL is applied only
+	// when the increment creates the key, so later increments never push the
+	// expiry back. A non-positive ttl creates the key without expiry.
+	IncrementWithTTL(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error)
+}
+
+// HashStore is implemented by stores that can keep a group of fields under a
+// single key, such as a Redis hash. Field values are serialized like any
+// other cached value.
+type HashStore interface {
+	// HGet returns the value of field in the hash at key, or ErrKeyNotFound
+	// if the hash or the field does not exist.
+	HGet(ctx context.Context, key, field string) (interface{}, error)
+
+	// HSet sets field in the hash at key, creating the hash if needed.
+	HSet(ctx context.Context, key, field string, value interface{}) error
+
+	// HGetAll returns every field of the hash at key. A missing hash yields
+	// an empty map.
+	HGetAll(ctx context.Context, key string) (map[string]interface{}, error)
+
+	// HDel removes fields from the hash at key. The hash is deleted once it
+	// has no fields left.
+	HDel(ctx context.Context, key string, fields ...string) error
+}
+
+// DriverInfo describes the effective configuration of a store.
+type DriverInfo struct {
+	// Driver is the driver name (e.g., "redis", "memory").
+	Driver string
+
+	// Serializer is the serializer name, empty if values are stored as-is.
+	Serializer string
+
+	// Compression is the compression algorithm, empty if disabled.
+	Compression string
+
+	// Prefix is the key prefix in use.
+	Prefix string
+}
+
+// Introspectable is implemented by stores that can report their configuration.
+type Introspectable interface {
+	// Info returns the store's effective configuration.
+	Info() DriverInfo
+}
+
+// PoolStats describes a store's connection pool.
+type PoolStats struct {
+	// Hits is the number of times a free connection was found in the pool.
+	Hits uint32
+
+	// Misses is the number of times a new connection had to be opened.
+	Misses uint32
+
+	// Timeouts is the number of times waiting for a connection timed out.
+	Timeouts uint32
+
+	// TotalConns is the number of open connections.
+	TotalConns uint32
+
+	// IdleConns is the number of idle connections.
+	IdleConns uint32
+
+	// StaleConns is the number of stale connections removed from the pool.
+	StaleConns uint32
+}
+
+// PoolReporter is implemented by stores backed by a connection pool.
+type PoolReporter interface {
+	// PoolUsage returns the current state of the connection pool.
+	PoolUsage() PoolStats
+}
+
+// Swapper is implemented by stores that can replace their entire contents
+// atomically, so readers see either the old or the new dataset and never a
+// partially built one.
+type Swapper interface {
+	// SwapAll removes every key in the store and stores items with ttl in
+	// one atomic step.
+	SwapAll(ctx context.Context, items map[string]interface{}, ttl time.Duration) error
+}
+
+// ExpirationCounter is implemented by stores that count items removed because
+// their TTL passed, separately from the capacity evictions in Stats.Evictions.
+type ExpirationCounter interface {
+	// Expirations returns the number of items removed on expiry.
+	Expirations() int64
+}
+
+// SerializationErrorCounter is implemented by stores that count values which
+// failed to encode on write or decode on read.
+type SerializationErrorCounter interface {
+	// SerializationErrors returns the number of values that failed to encode or decode.
+	SerializationErrors() int64
+}
+
+// SelectiveFlusher is implemented by stores that can flush all keys except
+// those matching a set of patterns.
+type SelectiveFlusher interface {
+	// FlushExcept removes every key that does not match any of the given
+	// glob patterns (see MatchKey). Patterns match keys without the store prefix.
+	FlushExcept(ctx context.Context, patterns ...string) error
+}
+
+// Renamer is implemented by stores that can atomically rename a key.
+type Renamer interface {
+	// Rename moves the value at oldKey to newKey, overwriting newKey if it exists.
+	// The remaining TTL and tag associations are preserved.
+	// Returns ErrKeyNotFound if oldKey does not exist.
+	Rename(ctx context.Context, oldKey, newKey string) error
+}
+
+// Transactional is implemented by stores that can apply a group of writes
+// atomically.
+type Transactional interface {
+	// Transaction runs fn with a store that stages its writes. If fn returns
+	// nil, all staged writes are applied together; otherwise none are applied
+	// and fn's error is returned. The tx store must not be used after fn returns.
+	Transaction(ctx context.Context, fn func(tx cache.Store) error) error
+}
+
+// Normalizer is implemented by stores whose reads return a different
+// representation than was written, e.g. a struct put into Redis reads back
+// as a map.
+type Normalizer interface {
+	// Normalize returns value as it would read back from the store after
+	// being written.
+	Normalize(value interface{}) (interface{}, error)
+}
+
+// TagIntrospectable is implemented by stores that can list and flush tagged
+// keys without constructing a TaggedStore.
+type TagIntrospectable interface {
+	// KeysForTag returns the keys, without the store prefix, associated with tag.
+	KeysForTag(ctx context.Context, tag string) ([]string, error)
+
+	// FlushTags removes all keys associated with any of the tags.
+	FlushTags(ctx context.Context, tags ...string) error
+}
+
+// TagKeysFlusher is implemented by stores that can delete the keys of a tag
+// while keeping the tag itself, for tags whose membership is rebuilt after
+// every invalidation.
+type TagKeysFlusher interface {
+	// FlushTagKeysOnly removes all keys associated with any of the tags but
+	// keeps the tags.
+	FlushTagKeysOnly(ctx context.Context, tags ...string) error
+}
+
+// TagEditor is implemented by stores that can change the tags of existing keys
+// without rewriting their values.
+type TagEditor interface {
+	// TagExisting associates the existing keys with tag. Absent keys are skipped.
+	TagExisting(ctx context.Context, tag string, keys ...string) error
+
+	// Untag removes key from the given tags without deleting it.
+	Untag(ctx context.Context, key string, tags ...string) error
+}
+
+// Exporter is implemented by stores that can enumerate their items for ExportStore.
+type Exporter interface {
+	// Export calls fn for every unexpired item, with its key (without the store
+	// prefix), value, expiry, and tags. It stops at the first error from fn.
+	Export(ctx context.Context, fn func(item Item) error) error
+}
@@ -0,0 +1,598 @@
+package dgcache
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand/v2"
+	"sync/atomic"
+	"time"
+
+	"github.com/donnigundala/dg-core/contracts/cache"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/metric"
+	"go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func init() {
+	RegisterMiddleware("tracing", func(driver cache.Driver) cache.Driver {
+		return NewTracingDriver(driver)
+	})
+}
+
+// labelKey is the context key carrying an operation label.
+type labelKey struct{}
+
+// WithLabel returns a context whose cache operations are reported under label
+// instead of their key. Use a logical name such as "user_profile_lookup" to
+// keep span and metric attributes low-cardinality.
+func WithLabel(ctx context.Context, label string) context.Context {
+	return context.WithValue(ctx, labelKey{}, label)
+}
+
+// LabelFromContext returns the label set by WithLabel, if any.
+func LabelFromContext(ctx context.Context) (string, bool) {
+	label, ok := ctx.Value(labelKey{}).(string)
+	return label, ok && label != ""
+}
+
+// TracingDriver wraps a cache driver with an OpenTelemetry span and a
+// cache.operation.duration histogram sample per operation.
+//
+// Spans carry the key as cache.key, or the context label as cache.label when
+// one is set with WithLabel. The histogram never records keys, only the label.
+//
+// With a sample rate below 1, only that fraction of operations gets a span and
+// a histogram sample; the rest go straight to the wrapped driver.
+type TracingDriver struct {
+	cache.Driver
+	tracer   trace.Tracer
+	duration metric.Float64Histogram
+
+	// sampleRate holds the float64 bits of the sampled fraction of operations.
+	sampleRate atomic.Uint64
+}
+
+// NewTracingDriver wraps driver using the global tracer and meter providers.
+func NewTracingDriver(driver cache.Driver) *TracingDriver {
+	meter := otel.GetMeterProvider().Meter(instrumentationName)
+	duration, err := meter.Float64Histogram(
+		"cache.operation.duration",
+		metric.WithDescription("Duration of cache operations"),
+		metric.WithUnit("s"),
+	)
+	if err != nil {
+		duration, _ = noop.Meter{}.Float64Histogram("cache.operation.duration")
+	}
+
+	d := &TracingDriver{
+		Driver:   driver,
+		tracer:   otel.Tracer(instrumentationName),
+		duration: duration,
+	}
+	d.SetSampleRate(1)
+	return d
+}
+
+// SetSampleRate sets the fraction of operations to instrument, clamped to
+// [0, 1]. It implements Sampler, so the store's sample_rate is applied.
+func (d *TracingDriver) SetSampleRate(rate float64) {
+	d.sampleRate.Store(math.Float64bits(min(max(rate, 0), 1)))
+}
+
+// Unwrap returns the wrapped driver.
+func (d *TracingDriver) Unwrap() cache.Driver {
+	return d.Driver
+}
+
+// sampled reports whether the next operation is instrumented.
+func (d *TracingDriver) sampled() bool {
+	rate := math.Float64frombits(d.sampleRate.Load())
+	return rate >= 1 || (rate > 0 && rand.Float64() < rate)
+}
+
+// tracedOp is an operation in progress.
+type tracedOp struct {
+	ctx     context.Context
+	span    trace.Span
+	attrs   []attribute.KeyValue
+	start   time.Time
+	sampled bool
+}
+
+// start begins a span for op. key is empty for operations on several keys.
+// An operation left out by sampling gets a no-op span and is not recorded.
+func (d *TracingDriver) start(ctx context.Context, op, key string) *tracedOp {
+	if !d.sampled() {
+		return &tracedOp{ctx: ctx, span: trace.SpanFromContext(context.Background())}
+	}
+
+	attrs := []attribute.KeyValue{
+		attribute.String("cache.operation", op),
+		attribute.String("cache.driver", d.Driver.Name()),
+	}
+	label, labeled := LabelFromContext(ctx)
+	if labeled {
+		attrs = append(attrs, attribute.String("cache.label", label))
+	}
+
+	// Only spans see the raw key, and only when no label replaces it
+	spanAttrs := attrs
+	if !labeled && key != "" {
+		spanAttrs = append(attrs[:len(attrs):len(attrs)], attribute.String("cache.key", key))
+	}
+
+	ctx, span := d.tracer.Start(ctx, "cache."+op,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(spanAttrs...),
+	)
+	return &tracedOp{ctx: ctx, span: span, attrs: attrs, start: time.Now(), sampled: true}
+}
+
+// end finishes the span and records the duration. A miss is not an error.
+func (d *TracingDriver) end(op *tracedOp, err error) {
+	if !op.sampled {
+		return
+	}
+	if err != nil && !errors.Is(err, ErrKeyNotFound) {
+		op.span.RecordError(err)
+		op.span.SetStatus(codes.Error, err.Error())
+	}
+	op.span.End()
+	d.duration.Record(op.ctx, time.Since(op.start).Seconds(), metric.WithAttributes(op.attrs...))
+}
+
+// Get retrieves a value, recording whether it was a hit.
+func (d *TracingDriver) Get(ctx context.Context, key string) (interface{}, error) {
+	op := d.start(ctx, "get", key)
+	value, err := d.Driver.Get(op.ctx, key)
+	op.span.SetAttributes(attribute.Bool("cache.hit", err == nil))
+	d.end(op, err)
+	return value, err
+}
+
+// GetMultiple retrieves multiple values.
+func (d *TracingDriver) GetMultiple(ctx context.Context, keys []string) (map[string]interface{}, error) {
+	op := d.start(ctx, "get_multiple", "")
+	op.span.SetAttributes(attribute.Int("cache.keys", len(keys)))
+	values, err := d.Driver.GetMultiple(op.ctx, keys)
+	d.end(op, err)
+	return values, err
+}
+
+// Put stores a value.
+func (d *TracingDriver) Put(ctx context.Context, key string, value interface{}, ttl time.Duration) error {
+	op := d.start(ctx, "put", key)
+	err := d.Driver.Put(op.ctx, key, value, ttl)
+	d.end(op, err)
+	return err
+}
+
+// PutMultiple stores multiple values.
+func (d *TracingDriver) PutMultiple(ctx context.Context, items map[string]interface{}, ttl time.Duration) error {
+	op := d.start(ctx, "put_multiple", "")
+	op.span.SetAttributes(attribute.Int("cache.keys", len(items)))
+	err := d.Driver.PutMultiple(op.ctx, items, ttl)
+	d.end(op, err)
+	return err
+}
+
+// Increment increments a value.
+func (d *TracingDriver) Increment(ctx context.Context, key string, value int64) (int64, error) {
+	op := d.start(ctx, "increment", key)
+	n, err := d.Driver.Increment(op.ctx, key, value)
+	d.end(op, err)
+	return n, err
+}
+
+// Decrement decrements a value.
+func (d *TracingDriver) Decrement(ctx context.Context, key string, value int64) (int64, error) {
+	op := d.start(ctx, "decrement", key)
+	n, err := d.Driver.Decrement(op.ctx, key, value)
+	d.end(op, err)
+	return n, err
+}
+
+// Forever stores a value without expiry.
+func (d *TracingDriver) Forever(ctx context.Context, key string, value interface{}) error {
+	op := d.start(ctx, "forever", key)
+	err := d.Driver.Forever(op.ctx, key, value)
+	d.end(op, err)
+	return err
+}
+
+// Forget removes a value.
+func (d *TracingDriver) Forget(ctx context.Context, key string) error {
+	op := d.start(ctx, "forget", key)
+	err := d.Driver.Forget(op.ctx, key)
+	d.end(op, err)
+	return err
+}
+
+// ForgetMultiple removes multiple values.
+func (d *TracingDriver) ForgetMultiple(ctx context.Context, keys []string) error {
+	op := d.start(ctx, "forget_multiple", "")
+	op.span.SetAttributes(attribute.Int("cache.keys", len(keys)))
+	err := d.Driver.ForgetMultiple(op.ctx, keys)
+	d.end(op, err)
+	return err
+}
+
+// Flush removes all values.
+func (d *TracingDriver) Flush(ctx context.Context) error {
+	op := d.start(ctx, "flush", "")
+	err := d.Driver.Flush(op.ctx)
+	d.end(op, err)
+	return err
+}
+
+// Has checks whether a key exists.
+func (d *TracingDriver) Has(ctx context.Context, key string) (bool, error) {
+	op := d.start(ctx, "has", key)
+	ok, err := d.Driver.Has(op.ctx, key)
+	d.end(op, err)
+	return ok, err
+}
+
+// Missing checks whether a key is absent.
+func (d *TracingDriver) Missing(ctx context.Context, key string) (bool, error) {
+	op := d.start(ctx, "missing", key)
+	ok, err := d.Driver.Missing(op.ctx, key)
+	d.end(op, err)
+	return ok, err
+}
+
+// taggedDriver adapts a tagged view to cache.Driver so TracingDriver can wrap it.
+type taggedDriver struct {
+	cache.TaggedStore
+	name string
+}
+
+func (d taggedDriver) Name() string { return d.name }
+func (d taggedDriver) Close() error { return nil }
+
+// Tags returns a traced tagged view of the wrapped driver. It panics if the
+// wrapped driver does not support tagging.
+func (d *TracingDriver) Tags(tags ...string) cache.TaggedStore {
+	taggable, ok := d.Driver.(cache.TaggedStore)
+	if !ok {
+		panic("cache: wrapped driver does not support tagging")
+	}
+	traced := &TracingDriver{
+		Driver:   taggedDriver{TaggedStore: taggable.Tags(tags...), name: d.Driver.Name()},
+		tracer:   d.tracer,
+		duration: d.duration,
+	}
+	traced.sampleRate.Store(d.sampleRate.Load())
+	return traced
+}
+
+// Ping forwards to the wrapped driver if it supports health checks.
+func (d *TracingDriver) Ping(ctx context.Context) error {
+	pinger, ok := d.Driver.(Pinger)
+	if !ok {
+		return nil
+	}
+	op := d.start(ctx, "ping", "")
+	err := pinger.Ping(op.ctx)
+	d.end(op, err)
+	return err
+}
+
+// Info forwards to the wrapped driver if it supports introspection.
+func (d *TracingDriver) Info() DriverInfo {
+	if introspectable, ok := d.Driver.(Introspectable); ok {
+		return introspectable.Info()
+	}
+	return DriverInfo{Driver: d.Name(), Prefix: d.GetPrefix()}
+}
+
+// Normalize forwards to the wrapped driver if it supports normalization.
+func (d *TracingDriver) Normalize(value interface{}) (interface{}, error) {
+	if normalizer, ok := d.Driver.(Normalizer); ok {
+		return normalizer.Normalize(value)
+	}
+	return value, nil
+}
+
+// SerializationErrors forwards to the wrapped driver, or returns 0 if it does
+// not count serialization errors.
+func (d *TracingDriver) SerializationErrors() int64 {
+	if counter, ok := d.Driver.(SerializationErrorCounter); ok {
+		return counter.SerializationErrors()
+	}
+	return 0
+}
+
+// Expirations forwards to the wrapped driver, or returns 0 if it does not
+// count expirations.
+func (d *TracingDriver) Expirations() int64 {
+	if counter, ok := d.Driver.(ExpirationCounter); ok {
+		return counter.Expirations()
+	}
+	return 0
+}
+
+// PoolUsage forwards to the wrapped driver, or returns zero stats if it has
+// no connection pool.
+func (d *TracingDriver) PoolUsage() PoolStats {
+	if reporter, ok := d.Driver.(PoolReporter); ok {
+		return reporter.PoolUsage()
+	}
+	return PoolStats{}
+}
+
+// Transaction forwards to the wrapped driver if it supports transactions.
+func (d *TracingDriver) Transaction(ctx context.Context, fn func(tx cache.Store) error) error {
+	transactional, ok := d.Driver.(Transactional)
+	if !ok {
+		return ErrNotSupported
+	}
+	op := d.start(ctx, "transaction", "")
+	err := transactional.Transaction(op.ctx, fn)
+	d.end(op, err)
+	return err
+}
+
+// Expire forwards to the wrapped driver if it supports setting TTLs.
+func (d *TracingDriver) Expire(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	expirer, ok := d.Driver.(Expirer)
+	if !ok {
+		return false, ErrNotSupported
+	}
+	op := d.start(ctx, "expire", key)
+	ok, err := expirer.Expire(op.ctx, key, ttl)
+	d.end(op, err)
+	return ok, err
+}
+
+// ExtendTTL forwards to the wrapped driver if it supports extending TTLs.
+func (d *TracingDriver) ExtendTTL(ctx context.Context, key string, ttl time.Duration) (bool, error) {
+	extender, ok := d.Driver.(TTLExtender)
+	if !ok {
+		return false, ErrNotSupported
+	}
+	op := d.start(ctx, "extend_ttl", key)
+	extended, err := extender.ExtendTTL(op.ctx, key, ttl)
+	d.end(op, err)
+	return extended, err
+}
+
+// Rename forwards to the wrapped driver if it supports renaming keys.
+func (d *TracingDriver) Rename(ctx context.Context, oldKey, newKey string) error {
+	renamer, ok := d.Driver.(Renamer)
+	if !ok {
+		return ErrNotSupported
+	}
+	op := d.start(ctx, "rename", oldKey)
+	err := renamer.Rename(op.ctx, oldKey, newKey)
+	d.end(op, err)
+	return err
+}
+
+// IncrementWithTTL forwards to the wrapped driver if it supports it.
+func (d *TracingDriver) IncrementWithTTL(ctx context.Context, key string, delta int64, ttl time.Duration) (int64, error) {
+	incrementer, ok := d.Driver.(TTLIncrementer)
+	if !ok {
+		return 0, ErrNotSupported
+	}
+	op := d.start(ctx, "increment_with_ttl", key)
+	n, err := incrementer.IncrementWithTTL(op.ctx, key, delta, ttl)
+	d.end(op, err)
+	return n, err
+}
+
+// GetWithMeta forwards to the wrapped driver if it supports reading metadata.
+func (d *TracingDriver) GetWithMeta(ctx context.Context, key string) (interface{}, ItemMeta, error) {
+	reader, ok := d.Driver.(MetaReader)
+	if !ok {
+		return nil, ItemMeta{}, ErrNotSupported
+	}
+	op := d.start(ctx, "get_with_meta", key)
+	value, meta, err := reader.GetWithMeta(op.ctx, key)
+	op.span.SetAttributes(attribute.Bool("cache.hit", err == nil))
+	d.end(op, err)
+	return value, meta, err
+}
+
+// GetItem forwards to the wrapped driver if it can return whole entries.
+func (d *TracingDriver) GetItem(ctx context.Context, key string) (*Item, error) {
+	itemStore, ok := d.Driver.(ItemStore)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+	op := d.start(ctx, "get_item", key)
+	item, err := itemStore.GetItem(op.ctx, key)
+	op.span.SetAttributes(attribute.Bool("cache.hit", err == nil))
+	d.end(op, err)
+	return item, err
+}
+
+// GetField forwards to the wrapped driver if it can read single fields.
+func (d *TracingDriver) GetField(ctx context.Context, key, path string) (interface{}, error) {
+	reader, ok := d.Driver.(FieldReader)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+	op := d.start(ctx, "get_field", key)
+	value, err := reader.GetField(op.ctx, key, path)
+	op.span.SetAttributes(attribute.Bool("cache.hit", err == nil))
+	d.end(op, err)
+	return value, err
+}
+
+// GetMultipleWithTTL forwards to the wrapped driver if it can report TTLs.
+func (d *TracingDriver) GetMultipleWithTTL(ctx context.Context, keys []string) (map[string]ValueTTL, error) {
+	reader, ok := d.Driver.(TTLReader)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+	op := d.start(ctx, "get_multiple_with_ttl", "")
+	op.span.SetAttributes(attribute.Int("cache.keys", len(keys)))
+	values, err := reader.GetMultipleWithTTL(op.ctx, keys)
+	d.end(op, err)
+	return values, err
+}
+
+// HasMultiple forwards to the wrapped driver if it can check several keys at once.
+func (d *TracingDriver) HasMultiple(ctx context.Context, keys []string) (map[string]bool, error) {
+	checker, ok := d.Driver.(MultiChecker)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+	op := d.start(ctx, "has_multiple", "")
+	op.span.SetAttributes(attribute.Int("cache.keys", len(keys)))
+	result, err := checker.HasMultiple(op.ctx, keys)
+	d.end(op, err)
+	return result, err
+}
+
+// SwapAll forwards to the wrapped driver if it supports atomic swaps.
+func (d *TracingDriver) SwapAll(ctx context.Context, items map[string]interface{}, ttl time.Duration) error {
+	swapper, ok := d.Driver.(Swapper)
+	if !ok {
+		return ErrNotSupported
+	}
+	op := d.start(ctx, "swap_all", "")
+	op.span.SetAttributes(attribute.Int("cache.keys", len(items)))
+	err := swapper.SwapAll(op.ctx, items, ttl)
+	d.end(op, err)
+	return err
+}
+
+// FlushExcept forwards to the wrapped driver if it supports selective flushes.
+func (d *TracingDriver) FlushExcept(ctx context.Context, patterns ...string) error {
+	flusher, ok := d.Driver.(SelectiveFlusher)
+	if !ok {
+		return ErrNotSupported
+	}
+	op := d.start(ctx, "flush_except", "")
+	err := flusher.FlushExcept(op.ctx, patterns...)
+	d.end(op, err)
+	return err
+}
+
+// Export forwards to the wrapped driver if it supports exporting.
+func (d *TracingDriver) Export(ctx context.Context, fn func(item Item) error) error {
+	exporter, ok := d.Driver.(Exporter)
+	if !ok {
+		return ErrNotSupported
+	}
+	op := d.start(ctx, "export", "")
+	err := exporter.Export(op.ctx, fn)
+	d.end(op, err)
+	return err
+}
+
+// KeysForTag forwards to the wrapped driver if it supports tag introspection.
+func (d *TracingDriver) KeysForTag(ctx context.Context, tag string) ([]string, error) {
+	introspectable, ok := d.Driver.(TagIntrospectable)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+	op := d.start(ctx, "keys_for_tag", "")
+	keys, err := introspectable.KeysForTag(op.ctx, tag)
+	d.end(op, err)
+	return keys, err
+}
+
+// FlushTags forwards to the wrapped driver if it supports tag introspection.
+func (d *TracingDriver) FlushTags(ctx context.Context, tags ...string) error {
+	introspectable, ok := d.Driver.(TagIntrospectable)
+	if !ok {
+		return ErrNotSupported
+	}
+	op := d.start(ctx, "flush_tags", "")
+	err := introspectable.FlushTags(op.ctx, tags...)
+	d.end(op, err)
+	return err
+}
+
+// FlushTagKeysOnly forwards to the wrapped driver if it supports flushing tag keys only.
+func (d *TracingDriver) FlushTagKeysOnly(ctx context.Context, tags ...string) error {
+	flusher, ok := d.Driver.(TagKeysFlusher)
+	if !ok {
+		return ErrNotSupported
+	}
+	op := d.start(ctx, "flush_tag_keys_only", "")
+	err := flusher.FlushTagKeysOnly(op.ctx, tags...)
+	d.end(op, err)
+	return err
+}
+
+// TagExisting forwards to the wrapped driver if it supports editing tags.
+func (d *TracingDriver) TagExisting(ctx context.Context, tag string, keys ...string) error {
+	editor, ok := d.Driver.(TagEditor)
+	if !ok {
+		return ErrNotSupported
+	}
+	op := d.start(ctx, "tag_existing", "")
+	op.span.SetAttributes(attribute.Int("cache.keys", len(keys)))
+	err := editor.TagExisting(op.ctx, tag, keys...)
+	d.end(op, err)
+	return err
+}
+
+// Untag forwards to the wrapped driver if it supports editing tags.
+func (d *TracingDriver) Untag(ctx context.Context, key string, tags ...string) error {
+	editor, ok := d.Driver.(TagEditor)
+	if !ok {
+		return ErrNotSupported
+	}
+	op := d.start(ctx, "untag", key)
+	err := editor.Untag(op.ctx, key, tags...)
+	d.end(op, err)
+	return err
+}
+
+// HGet forwards to the wrapped driver if it supports hashes.
+func (d *TracingDriver) HGet(ctx context.Context, key, field string) (interface{}, error) {
+	hashes, ok := d.Driver.(HashStore)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+	op := d.start(ctx, "hget", key)
+	value, err := hashes.HGet(op.ctx, key, field)
+	op.span.SetAttributes(attribute.Bool("cache.hit", err == nil))
+	d.end(op, err)
+	return value, err
+}
+
+// HSet forwards to the wrapped driver if it supports hashes.
+func (d *TracingDriver) HSet(ctx context.Context, key, field string, value interface{}) error {
+	hashes, ok := d.Driver.(HashStore)
+	if !ok {
+		return ErrNotSupported
+	}
+	op := d.start(ctx, "hset", key)
+	err := hashes.HSet(op.ctx, key, field, value)
+	d.end(op, err)
+	return err
+}
+
+// HGetAll forwards to the wrapped driver if it supports hashes.
+func (d *TracingDriver) HGetAll(ctx context.Context, key string) (map[string]interface{}, error) {
+	hashes, ok := d.Driver.(HashStore)
+	if !ok {
+		return nil, ErrNotSupported
+	}
+	op := d.start(ctx, "hgetall", key)
+	fields, err := hashes.HGetAll(op.ctx, key)
+	d.end(op, err)
+	return fields, err
+}
+
+// HDel forwards to the wrapped driver if it supports hashes.
+func (d *TracingDriver) HDel(ctx context.Context, key string, fields ...string) error {
+	hashes, ok := d.Driver.(HashStore)
+	if !ok {
+		return ErrNotSupported
+	}
+	op := d.start(ctx, "hdel", key)
+	err := hashes.HDel(op.ctx, key, fields...)
+	d.end(op, err)
+	return err
+}
@@ -0,0 +1,221 @@
+package dgcache_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	dgcache "github.com/donnigundala/dg-cache"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	"go.opentelemetry.io/otel/trace"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+)
+
+// recordingTracerProvider records the name and attributes of every span.
+type recordingTracerProvider struct {
+	tracenoop.TracerProvider
+	mu    sync.Mutex
+	spans []*recordingSpan
+}
+
+func (p *recordingTracerProvider) Tracer(string, ...trace.TracerOption) trace.Tracer {
+	return &recordingTracer{provider: p}
+}
+
+type recordingTracer struct {
+	tracenoop.Tracer
+	provider *recordingTracerProvider
+}
+
+func (t *recordingTracer) Start(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	span := &recordingSpan{name: name, attrs: map[attribute.Key]attribute.Value{}}
+	config := trace.NewSpanStartConfig(opts...)
+	span.SetAttributes(config.Attributes()...)
+
+	t.provider.mu.Lock()
+	t.provider.spans = append(t.provider.spans, span)
+	t.provider.mu.Unlock()
+	return trace.ContextWithSpan(ctx, span), span
+}
+
+type recordingSpan struct {
+	tracenoop.Span
+	name  string
+	attrs map[attribute.Key]attribute.Value
+}
+
+func (s *recordingSpan) SetAttributes(kv ...attribute.KeyValue) {
+	for _, attr := range kv {
+		s.attrs[attr.Key] = attr.Value
+	}
+}
+
+// recordingMeterProvider records the attributes of every histogram sample.
+type recordingMeterProvider struct {
+	metricnoop.MeterProvider
+	mu      sync.Mutex
+	samples []attribute.Set
+}
+
+func (p *recordingMeterProvider) Meter(string, ...metric.MeterOption) metric.Meter {
+	return &recordingMeter{provider: p}
+}
+
+type recordingMeter struct {
+	metricnoop.Meter
+	provider *recordingMeterProvider
+}
+
+func (m *recordingMeter) Float64Histogram(string, ...metric.Float64HistogramOption) (metric.Float64Histogram, error) {
+	return &recordingHistogram{provider: m.provider}, nil
+}
+
+type recordingHistogram struct {
+	metricnoop.Float64Histogram
+	provider *recordingMeterProvider
+}
+
+func (h *recordingHistogram) Record(_ context.Context, _ float64, opts ...metric.RecordOption) {
+	h.provider.mu.Lock()
+	defer h.provider.mu.Unlock()
+	h.provider.samples = append(h.provider.samples, metric.NewRecordConfig(opts).Attributes())
+}
+
+func createTracedManager(t *testing.T) (*dgcache.Manager, *recordingTracerProvider, *recordingMeterProvider) {
+	t.Helper()
+	return createSampledManager(t, nil)
+}
+
+// createSampledManager is createTracedManager with the store's sample_rate set.
+func createSampledManager(t *testing.T, sampleRate *float64) (*dgcache.Manager, *recordingTracerProvider, *recordingMeterProvider) {
+	t.Helper()
+
+	tracer := &recordingTracerProvider{}
+	previousTracer := otel.GetTracerProvider()
+	otel.SetTracerProvider(tracer)
+	t.Cleanup(func() { otel.SetTracerProvider(previousTracer) })
+
+	meter := &recordingMeterProvider{}
+	previousMeter := otel.GetMeterProvider()
+	otel.SetMeterProvider(meter)
+	t.Cleanup(func() { otel.SetMeterProvider(previousMeter) })
+
+	cfg := dgcache.DefaultConfig().WithStore("memory", dgcache.StoreConfig{
+		Driver:     "memory",
+		Middleware: []string{"tracing"},
+		SampleRate: sampleRate,
+	})
+	manager, err := dgcache.NewManager(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() { manager.Close() })
+
+	return manager, tracer, meter
+}
+
+func TestTracingDriver_Spans(t *testing.T) {
+	manager, tracer, meter := createTracedManager(t)
+	ctx := context.Background()
+
+	require.NoError(t, manager.Put(ctx, "user:42", "john", 0))
+	_, err := manager.Get(ctx, "user:42")
+	require.NoError(t, err)
+
+	require.Len(t, tracer.spans, 2)
+	put, get := tracer.spans[0], tracer.spans[1]
+	assert.Equal(t, "cache.put", put.name)
+	assert.Equal(t, "cache.get", get.name)
+	assert.Equal(t, "user:42", get.attrs["cache.key"].AsString())
+	assert.Equal(t, "memory", get.attrs["cache.driver"].AsString())
+	assert.True(t, get.attrs["cache.hit"].AsBool())
+
+	// Metrics never carry the raw key
+	require.Len(t, meter.samples, 2)
+	for _, sample := range meter.samples {
+		assert.False(t, sample.HasValue("cache.key"))
+	}
+}
+
+func TestTracingDriver_Capabilities(t *testing.T) {
+	manager, tracer, _ := createTracedManager(t)
+	ctx := context.Background()
+
+	require.NoError(t, manager.Tags("users").Put(ctx, "user:42", "john", time.Minute))
+	ok, err := manager.Expire(ctx, "user:42", time.Hour)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	require.Len(t, tracer.spans, 2)
+	assert.Equal(t, "cache.put", tracer.spans[0].name)
+	assert.Equal(t, "cache.expire", tracer.spans[1].name)
+	assert.Equal(t, "user:42", tracer.spans[1].attrs["cache.key"].AsString())
+}
+
+func TestTracingDriver_Label(t *testing.T) {
+	manager, tracer, meter := createTracedManager(t)
+	ctx := dgcache.WithLabel(context.Background(), "user_profile_lookup")
+
+	_, err := manager.Get(ctx, "user:42")
+	assert.ErrorIs(t, err, dgcache.ErrKeyNotFound)
+
+	require.Len(t, tracer.spans, 1)
+	span := tracer.spans[0]
+	assert.Equal(t, "user_profile_lookup", span.attrs["cache.label"].AsString())
+	assert.NotContains(t, span.attrs, attribute.Key("cache.key"))
+	assert.False(t, span.attrs["cache.hit"].AsBool())
+
+	require.Len(t, meter.samples, 1)
+	label, ok := meter.samples[0].Value("cache.label")
+	require.True(t, ok)
+	assert.Equal(t, "user_profile_lookup", label.AsString())
+	op, _ := meter.samples[0].Value("cache.operation")
+	assert.Equal(t, "get", op.AsString())
+}
+
+func TestTracingDriver_SampleRate(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("rate 0 traces nothing", func(t *testing.T) {
+		rate := 0.0
+		manager, tracer, meter := createSampledManager(t, &rate)
+
+		for i := 0; i < 10; i++ {
+			require.NoError(t, manager.Put(ctx, "key", i, 0))
+			_, err := manager.Get(ctx, "key")
+			require.NoError(t, err)
+		}
+
+		assert.Empty(t, tracer.spans)
+		assert.Empty(t, meter.samples)
+	})
+
+	t.Run("rate 1 traces every operation", func(t *testing.T) {
+		rate := 1.0
+		manager, tracer, meter := createSampledManager(t, &rate)
+
+		for i := 0; i < 10; i++ {
+			require.NoError(t, manager.Put(ctx, "key", i, 0))
+			_, err := manager.Get(ctx, "key")
+			require.NoError(t, err)
+		}
+
+		assert.Len(t, tracer.spans, 20)
+		assert.Len(t, meter.samples, 20)
+	})
+
+	t.Run("rate out of range is rejected", func(t *testing.T) {
+		rate := 1.5
+		cfg := dgcache.DefaultConfig().WithStore("memory", dgcache.StoreConfig{
+			Driver:     "memory",
+			Middleware: []string{"tracing"},
+			SampleRate: &rate,
+		})
+		_, err := dgcache.NewManager(cfg)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,100 @@
+package dgcache
+
+import (
+	"fmt"
+	"time"
+)
+
+// TTLLimits bounds the TTL of values written to a store.
+type TTLLimits struct {
+	// Min is the shortest positive TTL a value may be written with.
+	// Writes without expiry bypass the floor.
+	// 0 means no floor.
+	Min time.Duration
+
+	// Max is the longest TTL a value may be written with.
+	// Writes without expiry (Forever) are capped to Max as well.
+	// 0 means unlimited.
+	Max time.Duration
+
+	// Reject makes out-of-range TTLs fail with ErrTTLOutOfRange instead of being clamped.
+	Reject bool
+}
+
+// Apply returns the TTL to use for a write requested with ttl.
+func (l TTLLimits) Apply(ttl time.Duration) (time.Duration, error) {
+	if l.Min > 0 && ttl > 0 && ttl < l.Min {
+		if l.Reject {
+			return 0, fmt.Errorf("%w: %s is below min_ttl %s", ErrTTLOutOfRange, ttl, l.Min)
+		}
+		ttl = l.Min
+	}
+	if l.Max > 0 && (ttl <= 0 || ttl > l.Max) {
+		if l.Reject {
+			return 0, fmt.Errorf("%w: %s exceeds max_ttl %s", ErrTTLOutOfRange, describeTTL(ttl), l.Max)
+		}
+		return l.Max, nil
+	}
+	return ttl, nil
+}
+
+// describeTTL formats a TTL for error messages.
+func describeTTL(ttl time.Duration) string {
+	if ttl <= 0 {
+		return "no expiry"
+	}
+	return ttl.String()
+}
+
+// TTLLimits reads the TTL limits from the store options. A malformed option,
+// a negative limit, or a min_ttl above a non-zero max_ttl yields an
+// ErrInvalidConfig error.
+//
+// Supported options:
+//   - min_ttl: shortest allowed positive TTL (time.Duration or duration string)
+//   - max_ttl: longest allowed TTL (time.Duration or duration string)
+//   - ttl_policy: "clamp" (default) or "reject"
+func (c StoreConfig) TTLLimits() (TTLLimits, error) {
+	var limits TTLLimits
+	var err error
+	if limits.Min, _, err = c.Duration("min_ttl"); err != nil {
+		return TTLLimits{}, err
+	}
+	if limits.Max, _, err = c.Duration("max_ttl"); err != nil {
+		return TTLLimits{}, err
+	}
+	if limits.Min < 0 || limits.Max < 0 {
+		return TTLLimits{}, ErrInvalidConfig("min_ttl and max_ttl must not be negative")
+	}
+	if limits.Max > 0 && limits.Min > limits.Max {
+		return TTLLimits{}, ErrInvalidConfig("min_ttl %s exceeds max_ttl %s", limits.Min, limits.Max)
+	}
+	switch policy := c.Options["ttl_policy"]; policy {
+	case nil, "clamp":
+	case "reject":
+		limits.Reject = true
+	default:
+		return TTLLimits{}, ErrInvalidConfig("unknown ttl_policy '%v'", policy)
+	}
+	return limits, nil
+}
+
+// Duration reads a duration option, accepting a time.Duration or a duration
+// string, and reports whether it is set. A value of any other type, such as a
+// bare number, or a string that does not parse yields an ErrInvalidConfig error.
+func (c StoreConfig) Duration(key string) (time.Duration, bool, error) {
+	switch val := c.Options[key].(type) {
+	case nil:
+		return 0, false, nil
+	case time.Duration:
+		return val, true, nil
+	case string:
+		d, err := time.ParseDuration(val)
+		if err != nil {
+			return 0, false, ErrInvalidConfig("%s: %v", key, err)
+		}
+		return d, true, nil
+	default:
+		return 0, false, ErrInvalidConfig("%s must be a duration or duration string like \"10m\", got %T", key, val)
+	}
+}